@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
 
 	"bmad-studio/backend/api/response"
@@ -13,12 +15,15 @@ import (
 // ArtifactHandler handles artifact-related API requests
 type ArtifactHandler struct {
 	artifactService *services.ArtifactService
+	gitService      *services.GitService
 }
 
-// NewArtifactHandler creates a new ArtifactHandler instance
-func NewArtifactHandler(artifactService *services.ArtifactService) *ArtifactHandler {
+// NewArtifactHandler creates a new ArtifactHandler instance.
+// gitService may be nil, in which case artifacts carry no git status.
+func NewArtifactHandler(artifactService *services.ArtifactService, gitService *services.GitService) *ArtifactHandler {
 	return &ArtifactHandler{
 		artifactService: artifactService,
+		gitService:      gitService,
 	}
 }
 
@@ -32,8 +37,16 @@ func writeArtifactError(w http.ResponseWriter, err error) bool {
 
 	var status int
 	switch svcErr.Code {
-	case services.ErrCodeArtifactNotFound:
+	case services.ErrCodeArtifactNotFound, services.ErrCodeSectionNotFound:
 		status = http.StatusNotFound
+	case services.ErrCodeInvalidFrontmatter, services.ErrCodeInvalidArtifact:
+		status = http.StatusUnprocessableEntity
+	case services.ErrCodeArtifactExists:
+		status = http.StatusConflict
+	case services.ErrCodeETagMismatch:
+		status = http.StatusPreconditionFailed
+	case services.ErrCodeETagRequired:
+		status = http.StatusPreconditionRequired
 	case services.ErrCodeArtifactConfigNotLoaded, services.ErrCodeArtifactsNotLoaded:
 		status = http.StatusServiceUnavailable
 	default:
@@ -54,6 +67,10 @@ func (h *ArtifactHandler) GetArtifacts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.gitService != nil {
+		h.gitService.Annotate(r.Context(), artifacts)
+	}
+
 	response.WriteJSON(w, http.StatusOK, types.ArtifactsResponse{Artifacts: artifacts})
 }
 
@@ -69,5 +86,146 @@ func (h *ArtifactHandler) GetArtifact(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.gitService != nil {
+		annotated := []types.ArtifactResponse{*artifact}
+		h.gitService.Annotate(r.Context(), annotated)
+		artifact = &annotated[0]
+	}
+
+	w.Header().Set("ETag", services.ArtifactETag(artifact))
 	response.WriteJSON(w, http.StatusOK, artifact)
 }
+
+// GetArtifactContent handles GET /api/v1/bmad/artifacts/{id}/content
+// and returns the artifact's markdown file unmodified, with an ETag for later writes.
+func (h *ArtifactHandler) GetArtifactContent(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	content, etag, err := h.artifactService.GetRawContentWithETag(id)
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// GetArtifactFrontmatter handles GET /api/v1/bmad/artifacts/{id}/frontmatter
+func (h *ArtifactHandler) GetArtifactFrontmatter(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	frontmatter, err := h.artifactService.GetFrontmatter(id)
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, frontmatter)
+}
+
+// GetArtifactOutline handles GET /api/v1/bmad/artifacts/{id}/outline
+func (h *ArtifactHandler) GetArtifactOutline(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	outline, err := h.artifactService.GetOutline(id)
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, outline)
+}
+
+// GetArtifactSection handles GET /api/v1/bmad/artifacts/{id}/sections/{sectionId}
+func (h *ArtifactHandler) GetArtifactSection(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	sectionID := chi.URLParam(r, "sectionId")
+
+	section, err := h.artifactService.GetSection(id, sectionID)
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, section)
+}
+
+// PutArtifactContent handles PUT /api/v1/bmad/artifacts/{id}/content.
+// The body is the new markdown; If-Match must carry the ETag the client last read.
+func (h *ArtifactHandler) PutArtifactContent(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.WriteInvalidRequest(w, "Failed to read request body")
+		return
+	}
+
+	artifact, err := h.artifactService.WriteContent(id, content, r.Header.Get("If-Match"))
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("ETag", services.ArtifactETag(artifact))
+	response.WriteJSON(w, http.StatusOK, artifact)
+}
+
+// PatchArtifactFrontmatter handles PATCH /api/v1/bmad/artifacts/{id}/frontmatter
+func (h *ArtifactHandler) PatchArtifactFrontmatter(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var update types.ArtifactFrontmatterUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+
+	artifact, err := h.artifactService.UpdateFrontmatter(id, update, r.Header.Get("If-Match"))
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("ETag", services.ArtifactETag(artifact))
+	response.WriteJSON(w, http.StatusOK, artifact)
+}
+
+// CreateArtifact handles POST /api/v1/bmad/artifacts
+func (h *ArtifactHandler) CreateArtifact(w http.ResponseWriter, r *http.Request) {
+	var req types.CreateArtifactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+	if req.Type == "" {
+		response.WriteInvalidRequest(w, "type is required")
+		return
+	}
+
+	artifact, err := h.artifactService.CreateArtifact(req)
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("ETag", services.ArtifactETag(artifact))
+	response.WriteJSON(w, http.StatusCreated, artifact)
+}
@@ -127,3 +127,67 @@ func (h *BMadHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 
 	response.WriteJSON(w, http.StatusOK, status)
 }
+
+// GetTracks handles GET /api/v1/bmad/tracks
+func (h *BMadHandler) GetTracks(w http.ResponseWriter, r *http.Request) {
+	if h.workflowPathService == nil {
+		response.WriteError(w, "path_files_not_found", "Workflow path service not available.", http.StatusServiceUnavailable)
+		return
+	}
+
+	tracks, err := h.workflowPathService.GetTracks()
+	if err != nil {
+		writeTrackError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, tracks)
+}
+
+// GetTrackPhases handles GET /api/v1/bmad/tracks/{track}/phases
+func (h *BMadHandler) GetTrackPhases(w http.ResponseWriter, r *http.Request) {
+	if h.workflowPathService == nil {
+		response.WriteError(w, "path_files_not_found", "Workflow path service not available.", http.StatusServiceUnavailable)
+		return
+	}
+
+	phases, err := h.workflowPathService.GetTrackPhases(chi.URLParam(r, "track"))
+	if err != nil {
+		writeTrackError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, phases)
+}
+
+// CompareTrack handles GET /api/v1/bmad/tracks/{track}/compare
+func (h *BMadHandler) CompareTrack(w http.ResponseWriter, r *http.Request) {
+	if h.workflowStatusService == nil {
+		response.WriteError(w, "status_not_loaded", "Workflow status service not available.", http.StatusServiceUnavailable)
+		return
+	}
+
+	comparison, err := h.workflowStatusService.CompareTrack(chi.URLParam(r, "track"))
+	if err != nil {
+		writeTrackError(w, err)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, comparison)
+}
+
+// writeTrackError maps path and status errors of the track endpoints to HTTP responses
+func writeTrackError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case *services.WorkflowPathError:
+		if e.Code == services.ErrCodeTrackNotFound {
+			response.WriteError(w, e.Code, e.Message, http.StatusNotFound)
+			return
+		}
+		response.WriteError(w, e.Code, e.Message, http.StatusServiceUnavailable)
+	case *services.WorkflowStatusError:
+		response.WriteError(w, e.Code, e.Message, http.StatusServiceUnavailable)
+	default:
+		response.WriteError(w, "internal_error", err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+)
+
+// ExportHandler handles the artifact export endpoints
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler instance
+func NewExportHandler(es *services.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: es}
+}
+
+// writeExportError maps export and artifact errors to HTTP responses.
+func writeExportError(w http.ResponseWriter, err error) {
+	var svcErr *services.ExportServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeInvalidExportRequest:
+			response.WriteInvalidRequest(w, svcErr.Message)
+		case services.ErrCodeExportConfigNotLoaded:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusServiceUnavailable)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+	if !writeArtifactError(w, err) {
+		response.WriteInternalError(w, err.Error())
+	}
+}
+
+// Export handles POST /api/v1/bmad/export
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var req types.ExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+	h.export(w, req)
+}
+
+// ExportDownload handles GET /api/v1/bmad/export, for download links:
+// ?artifact_ids=a,b or ?phase=2, with optional title and pdf=true.
+func (h *ExportHandler) ExportDownload(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := types.ExportRequest{Title: query.Get("title")}
+	for _, id := range strings.Split(query.Get("artifact_ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			req.ArtifactIDs = append(req.ArtifactIDs, id)
+		}
+	}
+	if value := query.Get("phase"); value != "" {
+		phase, err := strconv.Atoi(value)
+		if err != nil {
+			response.WriteInvalidRequest(w, "phase must be a number")
+			return
+		}
+		req.Phase = &phase
+	}
+	if value := query.Get("pdf"); value != "" {
+		includePDF, err := strconv.ParseBool(value)
+		if err != nil {
+			response.WriteInvalidRequest(w, "pdf must be true or false")
+			return
+		}
+		req.IncludePDF = includePDF
+	}
+	h.export(w, req)
+}
+
+func (h *ExportHandler) export(w http.ResponseWriter, req types.ExportRequest) {
+	bundle, err := h.exportService.Export(req)
+	if err != nil {
+		writeExportError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", bundle.Filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(bundle.Content)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+)
+
+// GitHandler handles git repository endpoints
+type GitHandler struct {
+	gitService *services.GitService
+}
+
+// NewGitHandler creates a new GitHandler instance
+func NewGitHandler(gs *services.GitService) *GitHandler {
+	return &GitHandler{gitService: gs}
+}
+
+// writeGitError maps git and artifact errors to HTTP responses.
+func writeGitError(w http.ResponseWriter, err error) {
+	var svcErr *services.GitServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeInvalidCommit:
+			response.WriteInvalidRequest(w, svcErr.Message)
+		case services.ErrCodeNotGitRepo, services.ErrCodeNothingToCommit:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusConflict)
+		case services.ErrCodeGitConfigNotLoaded:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusServiceUnavailable)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+
+	if !writeArtifactError(w, err) {
+		response.WriteInternalError(w, "Failed to process git request")
+	}
+}
+
+// GetStatus handles GET /api/v1/bmad/git/status
+func (h *GitHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.gitService.RepoStatus(r.Context())
+	if err != nil {
+		writeGitError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, status)
+}
+
+// Commit handles POST /api/v1/bmad/git/commit
+func (h *GitHandler) Commit(w http.ResponseWriter, r *http.Request) {
+	var req types.GitCommitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+
+	result, err := h.gitService.Commit(r.Context(), req)
+	if err != nil {
+		writeGitError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusCreated, result)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+)
+
+// GraphHandler handles the artifact dependency graph endpoint
+type GraphHandler struct {
+	graphService *services.ArtifactGraphService
+}
+
+// NewGraphHandler creates a new GraphHandler instance
+func NewGraphHandler(gs *services.ArtifactGraphService) *GraphHandler {
+	return &GraphHandler{graphService: gs}
+}
+
+// GetGraph handles GET /api/v1/bmad/artifacts/graph
+func (h *GraphHandler) GetGraph(w http.ResponseWriter, r *http.Request) {
+	graph, err := h.graphService.Graph()
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteInternalError(w, "Failed to build artifact graph")
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, graph)
+}
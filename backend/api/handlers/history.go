@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// HistoryHandler handles artifact version history endpoints
+type HistoryHandler struct {
+	historyService *services.HistoryService
+}
+
+// NewHistoryHandler creates a new HistoryHandler instance
+func NewHistoryHandler(hs *services.HistoryService) *HistoryHandler {
+	return &HistoryHandler{historyService: hs}
+}
+
+// writeHistoryError maps history and artifact errors to HTTP responses.
+func writeHistoryError(w http.ResponseWriter, err error) {
+	var svcErr *services.HistoryServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeVersionNotFound:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusNotFound)
+		case services.ErrCodeInvalidDiffMode:
+			response.WriteInvalidRequest(w, svcErr.Message)
+		case services.ErrCodeHistoryConfigNotLoaded:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusServiceUnavailable)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+
+	if !writeArtifactError(w, err) {
+		response.WriteInternalError(w, "Failed to process history request")
+	}
+}
+
+// GetHistory handles GET /api/v1/bmad/artifacts/{id}/history
+func (h *HistoryHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	history, err := h.historyService.History(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeHistoryError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, history)
+}
+
+// GetVersion handles GET /api/v1/bmad/artifacts/{id}/history/{versionId}
+// and returns the artifact's markdown at that version.
+func (h *HistoryHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	content, err := h.historyService.VersionContent(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "versionId"))
+	if err != nil {
+		writeHistoryError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// GetDiff handles GET /api/v1/bmad/artifacts/{id}/diff?from=&to=&mode=
+// to defaults to the current content and mode to unified.
+func (h *HistoryHandler) GetDiff(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("from") == "" {
+		response.WriteInvalidRequest(w, "from is required")
+		return
+	}
+
+	diff, err := h.historyService.Diff(r.Context(), chi.URLParam(r, "id"), q.Get("from"), q.Get("to"), q.Get("mode"))
+	if err != nil {
+		writeHistoryError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, diff)
+}
+
+// RestoreVersion handles POST /api/v1/bmad/artifacts/{id}/restore.
+// If-Match must carry the artifact's current ETag.
+func (h *HistoryHandler) RestoreVersion(w http.ResponseWriter, r *http.Request) {
+	var req types.RestoreVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+	if req.VersionID == "" {
+		response.WriteInvalidRequest(w, "version_id is required")
+		return
+	}
+
+	artifact, err := h.historyService.Restore(r.Context(), chi.URLParam(r, "id"), req.VersionID, r.Header.Get("If-Match"))
+	if err != nil {
+		writeHistoryError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", services.ArtifactETag(artifact))
+	response.WriteJSON(w, http.StatusOK, artifact)
+}
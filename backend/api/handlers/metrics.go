@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+)
+
+// MetricsHandler handles the progress metrics endpoints
+type MetricsHandler struct {
+	metricsService *services.MetricsService
+}
+
+// NewMetricsHandler creates a new MetricsHandler instance
+func NewMetricsHandler(ms *services.MetricsService) *MetricsHandler {
+	return &MetricsHandler{metricsService: ms}
+}
+
+// writeMetricsError maps metrics errors to HTTP responses.
+func writeMetricsError(w http.ResponseWriter, err error) {
+	var svcErr *services.MetricsServiceError
+	if errors.As(err, &svcErr) && svcErr.Code == services.ErrCodeMetricsConfigNotLoaded {
+		response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusServiceUnavailable)
+		return
+	}
+	response.WriteInternalError(w, err.Error())
+}
+
+// GetMetrics handles GET /api/v1/bmad/metrics
+func (h *MetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics, err := h.metricsService.Metrics()
+	if err != nil {
+		writeMetricsError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, metrics)
+}
+
+// GetTimeline handles GET /api/v1/bmad/metrics/timeline
+func (h *MetricsHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	timeline, err := h.metricsService.Timeline()
+	if err != nil {
+		writeMetricsError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, timeline)
+}
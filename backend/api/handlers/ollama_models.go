@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/api/websocket"
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// OllamaModelHandler handles Ollama model management endpoints (pull, delete, show).
+type OllamaModelHandler struct {
+	providerService *services.ProviderService
+	hub             *websocket.Hub
+}
+
+// NewOllamaModelHandler creates a new OllamaModelHandler.
+// Pull progress is broadcast over hub; hub may be nil, in which case progress is not relayed.
+func NewOllamaModelHandler(ps *services.ProviderService, hub *websocket.Hub) *OllamaModelHandler {
+	return &OllamaModelHandler{providerService: ps, hub: hub}
+}
+
+// pullRequest is the expected JSON body for POST /api/v1/providers/ollama/pull.
+type pullRequest struct {
+	Model    string `json:"model"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// pullResponse is the JSON response for an accepted pull.
+type pullResponse struct {
+	Model  string `json:"model"`
+	Status string `json:"status"`
+}
+
+// writeOllamaError maps a ProviderError to an HTTP response.
+func writeOllamaError(w http.ResponseWriter, err error, fallback string) {
+	pErr, ok := err.(*providers.ProviderError)
+	if !ok {
+		response.WriteInternalError(w, fallback)
+		return
+	}
+
+	switch pErr.Code {
+	case "model_not_found":
+		response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusNotFound)
+	case "pull_in_progress":
+		response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusConflict)
+	case "invalid_request":
+		response.WriteInvalidRequest(w, pErr.UserMessage)
+	case "connection_error", "timeout":
+		response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusBadGateway)
+	default:
+		response.WriteInternalError(w, pErr.UserMessage)
+	}
+}
+
+// modelNameParam extracts the model name from the wildcard route segment.
+// Model names may contain slashes and tags (e.g. "hf.co/org/model:Q4_K_M").
+func modelNameParam(r *http.Request) string {
+	return strings.Trim(chi.URLParam(r, "*"), "/")
+}
+
+// PullModel handles POST /api/v1/providers/ollama/pull.
+// The pull runs in the background; progress is broadcast as model:pull-progress events.
+func (h *OllamaModelHandler) PullModel(w http.ResponseWriter, r *http.Request) {
+	var req pullRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid request body")
+		return
+	}
+	if req.Model == "" {
+		response.WriteValidationError(w, "Model name is required")
+		return
+	}
+
+	release, err := h.providerService.StartOllamaPull(req.Endpoint, req.Model)
+	if err != nil {
+		writeOllamaError(w, err, "Failed to start model pull")
+		return
+	}
+
+	go func() {
+		defer release()
+
+		var throttle pullProgressThrottle
+		err := h.providerService.PullOllamaModel(context.Background(), req.Endpoint, req.Model, func(p providers.PullProgress) {
+			payload := &types.ModelPullProgressPayload{
+				Provider:  "ollama",
+				Model:     req.Model,
+				Status:    p.Status,
+				Digest:    p.Digest,
+				Total:     p.Total,
+				Completed: p.Completed,
+				Percent:   pullPercent(p),
+				Done:      p.Status == "success",
+			}
+			if throttle.shouldSend(payload) {
+				h.broadcast(payload)
+			}
+		})
+		if err != nil {
+			message := "Failed to pull model"
+			if pErr, ok := err.(*providers.ProviderError); ok {
+				message = pErr.UserMessage
+			}
+			log.Printf("Warning: Ollama pull of %s failed: %v", req.Model, err)
+			h.broadcast(&types.ModelPullProgressPayload{
+				Provider: "ollama",
+				Model:    req.Model,
+				Status:   "error",
+				Done:     true,
+				Error:    message,
+			})
+			return
+		}
+		if last := throttle.unsent(); last != nil {
+			h.broadcast(last)
+		}
+	}()
+
+	response.WriteJSON(w, http.StatusAccepted, pullResponse{Model: req.Model, Status: "pulling"})
+}
+
+// broadcast relays a pull progress payload if a hub is configured.
+func (h *OllamaModelHandler) broadcast(payload *types.ModelPullProgressPayload) {
+	if h.hub == nil {
+		return
+	}
+	h.hub.BroadcastEvent(types.NewModelPullProgressEvent(payload))
+}
+
+// pullProgressThrottle thins out pull progress: Ollama reports every chunk it downloads,
+// which is thousands of updates per layer. An update is sent when the status or layer
+// changes, the percentage rises by at least one point, or the pull is done.
+type pullProgressThrottle struct {
+	last    *types.ModelPullProgressPayload // Last update sent
+	pending *types.ModelPullProgressPayload // Newest update held back, if any
+}
+
+// shouldSend reports whether payload should be broadcast, recording it either way.
+func (t *pullProgressThrottle) shouldSend(payload *types.ModelPullProgressPayload) bool {
+	if t.last != nil && !payload.Done && payload.Status == t.last.Status &&
+		payload.Digest == t.last.Digest && payload.Percent < t.last.Percent+1 {
+		t.pending = payload
+		return false
+	}
+	t.last = payload
+	t.pending = nil
+	return true
+}
+
+// unsent returns the newest update that was held back, so the final state is always sent.
+func (t *pullProgressThrottle) unsent() *types.ModelPullProgressPayload {
+	return t.pending
+}
+
+// pullPercent computes layer download progress as a percentage.
+func pullPercent(p providers.PullProgress) int {
+	if p.Status == "success" {
+		return 100
+	}
+	if p.Total <= 0 {
+		return 0
+	}
+	return int(p.Completed * 100 / p.Total)
+}
+
+// GetModel handles GET /api/v1/providers/ollama/models/*.
+func (h *OllamaModelHandler) GetModel(w http.ResponseWriter, r *http.Request) {
+	name := modelNameParam(r)
+	if name == "" {
+		response.WriteInvalidRequest(w, "Model name is required")
+		return
+	}
+
+	details, err := h.providerService.ShowOllamaModel(r.Context(), r.URL.Query().Get("endpoint"), name)
+	if err != nil {
+		writeOllamaError(w, err, "Failed to get model details")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, details)
+}
+
+// DeleteModel handles DELETE /api/v1/providers/ollama/models/*.
+func (h *OllamaModelHandler) DeleteModel(w http.ResponseWriter, r *http.Request) {
+	name := modelNameParam(r)
+	if name == "" {
+		response.WriteInvalidRequest(w, "Model name is required")
+		return
+	}
+
+	if err := h.providerService.DeleteOllamaModel(r.Context(), r.URL.Query().Get("endpoint"), name); err != nil {
+		writeOllamaError(w, err, "Failed to delete model")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// newOllamaModelRouter mounts the Ollama model routes the same way the main router does.
+func newOllamaModelRouter(ps *services.ProviderService) *chi.Mux {
+	h := NewOllamaModelHandler(ps, nil)
+	r := chi.NewRouter()
+	r.Post("/api/v1/providers/ollama/pull", h.PullModel)
+	r.Get("/api/v1/providers/ollama/models/*", h.GetModel)
+	r.Delete("/api/v1/providers/ollama/models/*", h.DeleteModel)
+	return r
+}
+
+func TestOllamaModelHandler_GetModel_NameWithSlashes(t *testing.T) {
+	var requested string
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		requested = body["model"]
+		fmt.Fprint(w, `{"details":{"family":"qwen2","parameter_size":"7.6B","quantization_level":"Q4_K_M"},"model_info":{"general.architecture":"qwen2","qwen2.context_length":32768}}`)
+	}))
+	defer ollama.Close()
+
+	router := newOllamaModelRouter(services.NewProviderService())
+	path := "/api/v1/providers/ollama/models/hf.co/org/qwen:Q4_K_M?endpoint=" + url.QueryEscape(ollama.URL)
+	req, _ := http.NewRequest("GET", path, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if requested != "hf.co/org/qwen:Q4_K_M" {
+		t.Errorf("Expected model name 'hf.co/org/qwen:Q4_K_M', got %q", requested)
+	}
+
+	var details struct {
+		Family        string `json:"family"`
+		ContextLength int    `json:"context_length"`
+	}
+	json.NewDecoder(rr.Body).Decode(&details)
+	if details.Family != "qwen2" || details.ContextLength != 32768 {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+}
+
+func TestOllamaModelHandler_GetModel_NotFound(t *testing.T) {
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ollama.Close()
+
+	router := newOllamaModelRouter(services.NewProviderService())
+	req, _ := http.NewRequest("GET", "/api/v1/providers/ollama/models/missing?endpoint="+url.QueryEscape(ollama.URL), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", rr.Code)
+	}
+}
+
+func TestOllamaModelHandler_DeleteModel(t *testing.T) {
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE, got %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ollama.Close()
+
+	router := newOllamaModelRouter(services.NewProviderService())
+	req, _ := http.NewRequest("DELETE", "/api/v1/providers/ollama/models/llama3.2:latest?endpoint="+url.QueryEscape(ollama.URL), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestOllamaModelHandler_PullModel_MissingModel(t *testing.T) {
+	router := newOllamaModelRouter(services.NewProviderService())
+	req, _ := http.NewRequest("POST", "/api/v1/providers/ollama/pull", strings.NewReader(`{}`))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", rr.Code)
+	}
+}
+
+func TestOllamaModelHandler_PullModel_AcceptedAndDeduplicated(t *testing.T) {
+	release := make(chan struct{})
+	done := make(chan struct{})
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		<-release
+		fmt.Fprintln(w, `{"status":"success"}`)
+	}))
+	defer ollama.Close()
+
+	ps := services.NewProviderService()
+	router := newOllamaModelRouter(ps)
+	body := fmt.Sprintf(`{"model":"llama3.2","endpoint":%q}`, ollama.URL)
+
+	req, _ := http.NewRequest("POST", "/api/v1/providers/ollama/pull", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	// A second pull of the same model while the first is running is rejected
+	req, _ = http.NewRequest("POST", "/api/v1/providers/ollama/pull", strings.NewReader(body))
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for duplicate pull, got %d", rr.Code)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestPullProgressThrottle(t *testing.T) {
+	var throttle pullProgressThrottle
+	progress := func(status string, completed int64, done bool) *types.ModelPullProgressPayload {
+		p := providers.PullProgress{Status: status, Digest: "sha256:abc", Total: 1000, Completed: completed}
+		if done {
+			p = providers.PullProgress{Status: status}
+		}
+		return &types.ModelPullProgressPayload{Status: p.Status, Digest: p.Digest, Total: p.Total, Completed: p.Completed, Percent: pullPercent(p), Done: done}
+	}
+
+	sent := 0
+	for completed := int64(0); completed <= 1000; completed++ {
+		if throttle.shouldSend(progress("pulling abc", completed, false)) {
+			sent++
+		}
+	}
+	if sent != 101 {
+		t.Errorf("Expected one update per percent, got %d", sent)
+	}
+	if throttle.unsent() != nil {
+		t.Errorf("Expected nothing held back after the last percent was sent")
+	}
+
+	if throttle.shouldSend(progress("pulling abc", 1000, false)) {
+		t.Error("Expected a repeated update to be held back")
+	}
+	if throttle.unsent() == nil {
+		t.Error("Expected the held-back update to be returned as unsent")
+	}
+	if !throttle.shouldSend(progress("verifying sha256 digest", 0, false)) {
+		t.Error("Expected a status change to be sent")
+	}
+	if !throttle.shouldSend(progress("success", 0, true)) {
+		t.Error("Expected the final update to be sent")
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/services"
+)
+
+// RetrievalHandler handles semantic retrieval endpoints
+type RetrievalHandler struct {
+	retrievalService *services.RetrievalService
+}
+
+// NewRetrievalHandler creates a new RetrievalHandler instance
+func NewRetrievalHandler(rs *services.RetrievalService) *RetrievalHandler {
+	return &RetrievalHandler{retrievalService: rs}
+}
+
+// retrievalQueryRequest is the expected JSON body for POST /api/v1/retrieval/query
+type retrievalQueryRequest struct {
+	Query        string `json:"query"`
+	TopK         int    `json:"top_k,omitempty"`
+	ArtifactType string `json:"artifact_type,omitempty"`
+	APIKey       string `json:"api_key,omitempty"`
+}
+
+// reindexRequest is the expected JSON body for POST /api/v1/retrieval/index
+type reindexRequest struct {
+	APIKey string `json:"api_key,omitempty"`
+}
+
+// writeRetrievalError maps retrieval, tool, artifact and embedding provider errors to HTTP responses.
+func writeRetrievalError(w http.ResponseWriter, err error) {
+	var svcErr *services.RetrievalServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeRetrievalConfigNotLoaded:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusServiceUnavailable)
+		case services.ErrCodeInvalidRetrievalQuery:
+			response.WriteInvalidRequest(w, svcErr.Message)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+
+	var toolErr *services.ToolRegistryError
+	if errors.As(err, &toolErr) {
+		switch toolErr.Code {
+		case services.ErrCodeToolNotFound:
+			response.WriteError(w, toolErr.Code, toolErr.Message, http.StatusNotFound)
+		default:
+			response.WriteError(w, toolErr.Code, toolErr.Message, http.StatusBadRequest)
+		}
+		return
+	}
+
+	var pErr *providers.ProviderError
+	if errors.As(err, &pErr) {
+		switch pErr.Code {
+		case "unsupported_provider", "embeddings_unsupported", "invalid_request", "model_not_found":
+			response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusBadRequest)
+		case "auth_error":
+			response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusUnauthorized)
+		case "rate_limit":
+			response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusTooManyRequests)
+		default:
+			response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusBadGateway)
+		}
+		return
+	}
+
+	if !writeArtifactError(w, err) {
+		response.WriteInternalError(w, "Failed to process retrieval request")
+	}
+}
+
+// GetStatus handles GET /api/v1/retrieval/status
+func (h *RetrievalHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.retrievalService.Status()
+	if err != nil {
+		writeRetrievalError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, status)
+}
+
+// Reindex handles POST /api/v1/retrieval/index.
+// Embeds new and modified artifacts; unchanged artifacts are not re-embedded.
+func (h *RetrievalHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	var req reindexRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.WriteInvalidRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	status, err := h.retrievalService.Sync(r.Context(), req.APIKey)
+	if err != nil {
+		writeRetrievalError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, status)
+}
+
+// Query handles POST /api/v1/retrieval/query
+func (h *RetrievalHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req retrievalQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid request body")
+		return
+	}
+	if req.TopK < 0 {
+		response.WriteInvalidRequest(w, "top_k must not be negative")
+		return
+	}
+
+	results, err := h.retrievalService.Retrieve(r.Context(), services.RetrievalQuery{
+		Query:        req.Query,
+		TopK:         req.TopK,
+		ArtifactType: req.ArtifactType,
+		APIKey:       req.APIKey,
+	})
+	if err != nil {
+		writeRetrievalError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, results)
+}
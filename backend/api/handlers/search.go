@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+)
+
+// SearchHandler handles full-text search requests
+type SearchHandler struct {
+	searchService *services.SearchService
+}
+
+// NewSearchHandler creates a new SearchHandler instance
+func NewSearchHandler(searchService *services.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search handles GET /api/v1/search?q=
+// Optional filters: kind (artifact, message), type and phase (artifacts), agent (messages),
+// plus limit and offset for paging.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := services.SearchQuery{
+		Query:        params.Get("q"),
+		Kind:         params.Get("kind"),
+		ArtifactType: params.Get("type"),
+		AgentID:      params.Get("agent"),
+	}
+	if query.Query == "" {
+		response.WriteInvalidRequest(w, "Query parameter q is required")
+		return
+	}
+	if query.Kind != "" && query.Kind != types.SearchKindArtifact && query.Kind != types.SearchKindMessage {
+		response.WriteInvalidRequest(w, "Invalid kind. Must be one of: artifact, message")
+		return
+	}
+	if raw := params.Get("phase"); raw != "" {
+		phase, err := strconv.Atoi(raw)
+		if err != nil {
+			response.WriteInvalidRequest(w, "phase must be an integer")
+			return
+		}
+		query.Phase = &phase
+	}
+	for name, dest := range map[string]*int{"limit": &query.Limit, "offset": &query.Offset} {
+		if raw := params.Get(name); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 0 {
+				response.WriteInvalidRequest(w, name+" must be a non-negative integer")
+				return
+			}
+			*dest = n
+		}
+	}
+
+	results, err := h.searchService.Search(query)
+	if err != nil {
+		var svcErr *services.SearchServiceError
+		if errors.As(err, &svcErr) && svcErr.Code == services.ErrCodeInvalidQuery {
+			response.WriteInvalidRequest(w, svcErr.Message)
+			return
+		}
+		response.WriteInternalError(w, "Failed to search")
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, results)
+}
@@ -1,9 +1,18 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/api/websocket"
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // ListSessions handles GET /api/v1/sessions
@@ -15,3 +24,250 @@ func ListSessions(w http.ResponseWriter, r *http.Request) {
 func GetSession(w http.ResponseWriter, r *http.Request) {
 	response.WriteNotImplemented(w)
 }
+
+// SessionHandler handles session and message-tree endpoints.
+type SessionHandler struct {
+	sessionService *services.SessionService
+	hub            *websocket.Hub
+}
+
+// NewSessionHandler creates a new SessionHandler.
+// Reply chunks are broadcast over hub as session:stream events; hub may be nil.
+func NewSessionHandler(ss *services.SessionService, hub *websocket.Hub) *SessionHandler {
+	return &SessionHandler{sessionService: ss, hub: hub}
+}
+
+// generateRequest holds the fields shared by every endpoint that produces an assistant reply.
+type generateRequest struct {
+	APIKey        string                  `json:"api_key,omitempty"`
+	UtilityAPIKey string                  `json:"utility_api_key,omitempty"`
+	Generation    *types.GenerationPreset `json:"generation,omitempty"`
+}
+
+// messageRequest is the expected JSON body for sending or editing a message.
+type messageRequest struct {
+	generateRequest
+	Content string `json:"content"`
+}
+
+// branchRequest is the expected JSON body for PUT /api/v1/sessions/{id}/branch.
+type branchRequest struct {
+	MessageID string `json:"message_id"`
+}
+
+// writeSessionError maps session and provider errors to HTTP responses.
+func writeSessionError(w http.ResponseWriter, err error) {
+	var svcErr *services.SessionServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeSessionNotFound, services.ErrCodeMessageNotFound:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusNotFound)
+		case services.ErrCodeInvalidMessage, services.ErrCodeNothingToRegenerate:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusBadRequest)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+
+	var pErr *providers.ProviderError
+	if errors.As(err, &pErr) {
+		switch pErr.Code {
+		case "unsupported_provider", "invalid_role", "invalid_parameters":
+			response.WriteInvalidRequest(w, pErr.UserMessage)
+		case "auth_error":
+			response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusUnauthorized)
+		case "rate_limit":
+			response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusTooManyRequests)
+		default:
+			response.WriteError(w, pErr.Code, pErr.UserMessage, http.StatusBadGateway)
+		}
+		return
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return // Client went away; nothing to write
+	}
+	response.WriteInternalError(w, "Failed to process session request")
+}
+
+// generateOptions validates a generate request and builds the service options,
+// relaying streamed chunks for sessionID over the hub.
+func (h *SessionHandler) generateOptions(w http.ResponseWriter, sessionID string, req generateRequest) (services.GenerateOptions, bool) {
+	opts := services.GenerateOptions{APIKey: req.APIKey, UtilityAPIKey: req.UtilityAPIKey}
+	if req.Generation != nil {
+		if err := services.ValidateGenerationPreset(*req.Generation); err != nil {
+			response.WriteInvalidRequest(w, "Invalid generation parameters: "+err.Error())
+			return opts, false
+		}
+		opts.Generation = services.GenerationParamsFromPreset(req.Generation)
+	}
+
+	if h.hub != nil {
+		opts.OnChunk = func(messageID string, chunk providers.StreamChunk) {
+			payload := &types.SessionStreamPayload{
+				SessionID: sessionID,
+				MessageID: messageID,
+				Type:      chunk.Type,
+				Content:   chunk.Content,
+				Index:     chunk.Index,
+			}
+			if chunk.Usage != nil {
+				payload.Usage = &types.TokenUsage{
+					InputTokens:      chunk.Usage.InputTokens,
+					OutputTokens:     chunk.Usage.OutputTokens,
+					CacheReadTokens:  chunk.Usage.CacheReadTokens,
+					CacheWriteTokens: chunk.Usage.CacheWriteTokens,
+				}
+			}
+			h.hub.BroadcastEvent(types.NewSessionStreamEvent(payload))
+		}
+	}
+	return opts, true
+}
+
+// ListSessions handles GET /api/v1/sessions
+func (h *SessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.sessionService.ListSessions()
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, types.SessionsResponse{Sessions: sessions})
+}
+
+// CreateSession handles POST /api/v1/sessions
+func (h *SessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	var req services.CreateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid request body")
+		return
+	}
+	if req.Provider != "" && !validProviders[req.Provider] {
+		response.WriteInvalidRequest(w, "Invalid provider. Must be one of: claude, openai, ollama")
+		return
+	}
+
+	session, err := h.sessionService.CreateSession(req)
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusCreated, services.NewSessionResponse(session))
+}
+
+// GetSession handles GET /api/v1/sessions/{id}
+func (h *SessionHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.sessionService.GetSession(chi.URLParam(r, "id"))
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, services.NewSessionResponse(session))
+}
+
+// DeleteSession handles DELETE /api/v1/sessions/{id}
+func (h *SessionHandler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	if err := h.sessionService.DeleteSession(chi.URLParam(r, "id")); err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SendMessage handles POST /api/v1/sessions/{id}/messages.
+// The reply is streamed as session:stream events and the updated session is returned once complete.
+func (h *SessionHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req messageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid request body")
+		return
+	}
+	opts, ok := h.generateOptions(w, id, req.generateRequest)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessionService.SendMessage(r.Context(), id, req.Content, opts)
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, services.NewSessionResponse(session))
+}
+
+// ForkMessage handles POST /api/v1/sessions/{id}/messages/{messageId}/fork.
+// Creates an edited sibling of a user message and regenerates from that point.
+func (h *SessionHandler) ForkMessage(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req messageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid request body")
+		return
+	}
+	opts, ok := h.generateOptions(w, id, req.generateRequest)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessionService.ForkMessage(r.Context(), id, chi.URLParam(r, "messageId"), req.Content, opts)
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, services.NewSessionResponse(session))
+}
+
+// Regenerate handles POST /api/v1/sessions/{id}/regenerate
+func (h *SessionHandler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req generateRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.WriteInvalidRequest(w, "Invalid request body")
+			return
+		}
+	}
+	opts, ok := h.generateOptions(w, id, req)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessionService.Regenerate(r.Context(), id, opts)
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, services.NewSessionResponse(session))
+}
+
+// SwitchBranch handles PUT /api/v1/sessions/{id}/branch
+func (h *SessionHandler) SwitchBranch(w http.ResponseWriter, r *http.Request) {
+	var req branchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid request body")
+		return
+	}
+	if req.MessageID == "" {
+		response.WriteValidationError(w, "message_id is required")
+		return
+	}
+
+	session, err := h.sessionService.SwitchBranch(chi.URLParam(r, "id"), req.MessageID)
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, services.NewSessionResponse(session))
+}
+
+// GetSiblings handles GET /api/v1/sessions/{id}/messages/{messageId}/siblings
+func (h *SessionHandler) GetSiblings(w http.ResponseWriter, r *http.Request) {
+	siblings, err := h.sessionService.Siblings(chi.URLParam(r, "id"), chi.URLParam(r, "messageId"))
+	if err != nil {
+		writeSessionError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, siblings)
+}
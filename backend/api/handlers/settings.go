@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
 	"bmad-studio/backend/storage"
 	"bmad-studio/backend/types"
 )
@@ -59,6 +60,36 @@ func (h *SettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// Validate generation presets
+	if req.GenerationDefaults != nil {
+		if err := services.ValidateGenerationPreset(*req.GenerationDefaults); err != nil {
+			response.WriteInvalidRequest(w, "Invalid generation defaults: "+err.Error())
+			return
+		}
+	}
+	for agentID, preset := range req.AgentGenerationDefaults {
+		if err := services.ValidateGenerationPreset(preset); err != nil {
+			response.WriteInvalidRequest(w, "Invalid generation defaults for agent "+agentID+": "+err.Error())
+			return
+		}
+	}
+
+	if req.UtilityModel != nil {
+		if req.UtilityModel.Provider != "" && !validProviders[req.UtilityModel.Provider] {
+			response.WriteInvalidRequest(w, "Invalid utility model provider. Must be one of: claude, openai, ollama")
+			return
+		}
+		if req.UtilityModel.SummarizeAfterTokens < 0 {
+			response.WriteInvalidRequest(w, "summarize_after_tokens must not be negative")
+			return
+		}
+	}
+
+	if req.Embedding != nil && req.Embedding.Provider != "" && req.Embedding.Provider != "openai" && req.Embedding.Provider != "ollama" {
+		response.WriteInvalidRequest(w, "Invalid embedding provider. Must be one of: openai, ollama")
+		return
+	}
+
 	var result types.Settings
 	err := h.store.Update(func(current *types.Settings) {
 		if req.DefaultProvider != "" {
@@ -75,6 +106,23 @@ func (h *SettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request)
 				current.Providers[k] = v
 			}
 		}
+		if req.GenerationDefaults != nil {
+			current.GenerationDefaults = req.GenerationDefaults
+		}
+		if req.AgentGenerationDefaults != nil {
+			if current.AgentGenerationDefaults == nil {
+				current.AgentGenerationDefaults = make(map[string]types.GenerationPreset)
+			}
+			for agentID, preset := range req.AgentGenerationDefaults {
+				current.AgentGenerationDefaults[agentID] = preset
+			}
+		}
+		if req.UtilityModel != nil {
+			current.UtilityModel = req.UtilityModel
+		}
+		if req.Embedding != nil {
+			current.Embedding = req.Embedding
+		}
 		result = *current
 	})
 	if err != nil {
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SprintHandler handles sprint board endpoints
+type SprintHandler struct {
+	sprintService *services.SprintService
+}
+
+// NewSprintHandler creates a new SprintHandler instance
+func NewSprintHandler(ss *services.SprintService) *SprintHandler {
+	return &SprintHandler{sprintService: ss}
+}
+
+// writeSprintError maps sprint, story and artifact errors to HTTP responses.
+func writeSprintError(w http.ResponseWriter, err error) {
+	var svcErr *services.SprintServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeInvalidStoryStatus:
+			response.WriteInvalidRequest(w, svcErr.Message)
+		case services.ErrCodeInvalidStoryTransition:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusConflict)
+		case services.ErrCodeSprintStatusNotFound, services.ErrCodeStoryNotInSprint:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusNotFound)
+		case services.ErrCodeSprintConfigNotLoaded:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusServiceUnavailable)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+
+	writeStoryError(w, err)
+}
+
+// MoveStory handles POST /api/v1/bmad/stories/{key}/move
+func (h *SprintHandler) MoveStory(w http.ResponseWriter, r *http.Request) {
+	var req types.StoryMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+
+	story, err := h.sprintService.MoveStory(chi.URLParam(r, "key"), req.Status)
+	if err != nil {
+		writeSprintError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, story)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StoryHandler handles the epic and story endpoints
+type StoryHandler struct {
+	storyService *services.StoryService
+}
+
+// NewStoryHandler creates a new StoryHandler instance
+func NewStoryHandler(ss *services.StoryService) *StoryHandler {
+	return &StoryHandler{storyService: ss}
+}
+
+// writeStoryError maps story and artifact errors to HTTP responses.
+func writeStoryError(w http.ResponseWriter, err error) {
+	var svcErr *services.StoryServiceError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeStoryNotFound:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusNotFound)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+
+	if !writeArtifactError(w, err) {
+		response.WriteInternalError(w, "Failed to load stories")
+	}
+}
+
+// GetEpics handles GET /api/v1/bmad/epics
+func (h *StoryHandler) GetEpics(w http.ResponseWriter, r *http.Request) {
+	epics, err := h.storyService.Epics()
+	if err != nil {
+		writeStoryError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, types.EpicsResponse{Epics: epics})
+}
+
+// GetStories handles GET /api/v1/bmad/stories, optionally filtered with ?epic=N
+func (h *StoryHandler) GetStories(w http.ResponseWriter, r *http.Request) {
+	epic := -1
+	if raw := r.URL.Query().Get("epic"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			response.WriteInvalidRequest(w, "epic must be a non-negative integer")
+			return
+		}
+		epic = n
+	}
+
+	stories, err := h.storyService.Stories()
+	if err != nil {
+		writeStoryError(w, err)
+		return
+	}
+	if epic >= 0 {
+		filtered := make([]types.Story, 0, len(stories))
+		for _, story := range stories {
+			if story.EpicNum == epic {
+				filtered = append(filtered, story)
+			}
+		}
+		stories = filtered
+	}
+	response.WriteJSON(w, http.StatusOK, types.StoriesResponse{Stories: stories})
+}
+
+// GetStory handles GET /api/v1/bmad/stories/{key}
+func (h *StoryHandler) GetStory(w http.ResponseWriter, r *http.Request) {
+	story, err := h.storyService.Story(chi.URLParam(r, "key"))
+	if err != nil {
+		writeStoryError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, story)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ToolHandler handles agent tool endpoints
+type ToolHandler struct {
+	registry *services.ToolRegistry
+}
+
+// NewToolHandler creates a new ToolHandler instance
+func NewToolHandler(registry *services.ToolRegistry) *ToolHandler {
+	return &ToolHandler{registry: registry}
+}
+
+// invokeToolRequest is the expected JSON body for POST /api/v1/tools/{name}
+type invokeToolRequest struct {
+	Input  json.RawMessage `json:"input"`
+	APIKey string          `json:"api_key,omitempty"`
+}
+
+// ListTools handles GET /api/v1/tools
+func (h *ToolHandler) ListTools(w http.ResponseWriter, r *http.Request) {
+	response.WriteJSON(w, http.StatusOK, types.ToolsResponse{Tools: h.registry.Definitions()})
+}
+
+// InvokeTool handles POST /api/v1/tools/{name}
+func (h *ToolHandler) InvokeTool(w http.ResponseWriter, r *http.Request) {
+	var req invokeToolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid request body")
+		return
+	}
+
+	result, err := h.registry.Invoke(r.Context(), chi.URLParam(r, "name"), req.Input, req.APIKey)
+	if err != nil {
+		writeRetrievalError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, result)
+}
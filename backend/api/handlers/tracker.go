@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+)
+
+// TrackerHandler handles the issue tracker sync endpoints
+type TrackerHandler struct {
+	trackerSyncService *services.TrackerSyncService
+}
+
+// NewTrackerHandler creates a new TrackerHandler instance
+func NewTrackerHandler(ts *services.TrackerSyncService) *TrackerHandler {
+	return &TrackerHandler{trackerSyncService: ts}
+}
+
+// writeTrackerError maps tracker sync and sprint errors to HTTP responses.
+func writeTrackerError(w http.ResponseWriter, err error) {
+	var svcErr *services.TrackerSyncError
+	if errors.As(err, &svcErr) {
+		switch svcErr.Code {
+		case services.ErrCodeInvalidResolution:
+			response.WriteInvalidRequest(w, svcErr.Message)
+		case services.ErrCodeTrackerNotConfigured:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusConflict)
+		case services.ErrCodeTrackerRequestFailed:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusBadGateway)
+		case services.ErrCodeTrackerConfigNotLoaded:
+			response.WriteError(w, svcErr.Code, svcErr.Message, http.StatusServiceUnavailable)
+		default:
+			response.WriteInternalError(w, svcErr.Message)
+		}
+		return
+	}
+
+	writeSprintError(w, err)
+}
+
+// GetStatus handles GET /api/v1/bmad/tracker
+func (h *TrackerHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.trackerSyncService.Status()
+	if err != nil {
+		writeTrackerError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, status)
+}
+
+// Sync handles POST /api/v1/bmad/tracker/sync. The request body is optional.
+func (h *TrackerHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	var req types.TrackerSyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+
+	result, err := h.trackerSyncService.Sync(r.Context(), req)
+	if err != nil {
+		writeTrackerError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ValidationHandler handles artifact diagnostics endpoints
+type ValidationHandler struct {
+	validationService *services.ValidationService
+}
+
+// NewValidationHandler creates a new ValidationHandler instance
+func NewValidationHandler(vs *services.ValidationService) *ValidationHandler {
+	return &ValidationHandler{validationService: vs}
+}
+
+// GetDiagnostics handles GET /api/v1/bmad/artifacts/{id}/diagnostics
+func (h *ValidationHandler) GetDiagnostics(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	diagnostics, err := h.validationService.Validate(id)
+	if err != nil {
+		if !writeArtifactError(w, err) {
+			response.WriteInternalError(w, "Failed to validate artifact")
+		}
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, diagnostics)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WorkflowStatusHandler handles the workflow status write endpoints
+type WorkflowStatusHandler struct {
+	writer *services.WorkflowStatusWriter
+}
+
+// NewWorkflowStatusHandler creates a new WorkflowStatusHandler instance
+func NewWorkflowStatusHandler(wsw *services.WorkflowStatusWriter) *WorkflowStatusHandler {
+	return &WorkflowStatusHandler{writer: wsw}
+}
+
+// writeWorkflowStatusError maps workflow status errors to HTTP responses.
+func writeWorkflowStatusError(w http.ResponseWriter, err error) {
+	var statusErr *services.WorkflowStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.Code {
+		case services.ErrCodeInvalidWorkflowStatus:
+			response.WriteInvalidRequest(w, statusErr.Message)
+		case services.ErrCodeWorkflowNotFound, services.ErrCodeTrackNotFound:
+			response.WriteError(w, statusErr.Code, statusErr.Message, http.StatusNotFound)
+		case services.ErrCodeConfigNotLoaded, services.ErrCodePathsNotLoaded:
+			response.WriteError(w, statusErr.Code, statusErr.Message, http.StatusServiceUnavailable)
+		default:
+			response.WriteInternalError(w, statusErr.Message)
+		}
+		return
+	}
+	response.WriteInternalError(w, err.Error())
+}
+
+// UpdateWorkflowStatus handles PUT /api/v1/bmad/status/workflows/{id}
+func (h *WorkflowStatusHandler) UpdateWorkflowStatus(w http.ResponseWriter, r *http.Request) {
+	var req types.WorkflowStatusUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+
+	status, err := h.writer.SetWorkflowStatus(chi.URLParam(r, "id"), req)
+	if err != nil {
+		writeWorkflowStatusError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, status)
+}
+
+// UpdateTrack handles PUT /api/v1/bmad/status/track
+func (h *WorkflowStatusHandler) UpdateTrack(w http.ResponseWriter, r *http.Request) {
+	var req types.TrackUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteInvalidRequest(w, "Invalid JSON in request body")
+		return
+	}
+	if req.Track == "" {
+		response.WriteInvalidRequest(w, "track is required")
+		return
+	}
+
+	status, err := h.writer.SetSelectedTrack(req.Track)
+	if err != nil {
+		writeWorkflowStatusError(w, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, status)
+}
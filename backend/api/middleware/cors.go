@@ -12,8 +12,8 @@ func CORS(next http.Handler) http.Handler {
 	corsHandler := cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3007", "tauri://localhost"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
-		ExposedHeaders:   []string{"Link", "X-Request-ID"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match", "X-Request-ID"},
+		ExposedHeaders:   []string{"ETag", "Link", "X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	})
@@ -18,8 +18,22 @@ type RouterServices struct {
 	WorkflowPath   *services.WorkflowPathService
 	Agent          *services.AgentService
 	WorkflowStatus *services.WorkflowStatusService
+	StatusWriter   *services.WorkflowStatusWriter
 	Artifact       *services.ArtifactService
+	ArtifactGraph  *services.ArtifactGraphService
+	History        *services.HistoryService
+	Validation     *services.ValidationService
+	Git            *services.GitService
+	Story          *services.StoryService
+	Sprint         *services.SprintService
+	Metrics        *services.MetricsService
+	TrackerSync    *services.TrackerSyncService
+	Export         *services.ExportService
 	Provider       *services.ProviderService
+	Session        *services.SessionService
+	Search         *services.SearchService
+	Retrieval      *services.RetrievalService
+	Tools          *services.ToolRegistry
 	ConfigStore    *storage.ConfigStore
 	Hub            *websocket.Hub
 }
@@ -56,12 +70,53 @@ func NewRouterWithServices(svc RouterServices) *chi.Mux {
 
 		// Sessions resource
 		r.Route("/sessions", func(r chi.Router) {
-			r.Get("/", handlers.ListSessions)
+			if svc.Session == nil {
+				r.Get("/", handlers.ListSessions)
+				r.Route("/{id}", func(r chi.Router) {
+					r.Get("/", handlers.GetSession)
+				})
+				return
+			}
+
+			sessionHandler := handlers.NewSessionHandler(svc.Session, svc.Hub)
+			r.Get("/", sessionHandler.ListSessions)
+			r.Post("/", sessionHandler.CreateSession)
 			r.Route("/{id}", func(r chi.Router) {
-				r.Get("/", handlers.GetSession)
+				r.Get("/", sessionHandler.GetSession)
+				r.Delete("/", sessionHandler.DeleteSession)
+				r.Post("/messages", sessionHandler.SendMessage)
+				r.Post("/messages/{messageId}/fork", sessionHandler.ForkMessage)
+				r.Get("/messages/{messageId}/siblings", sessionHandler.GetSiblings)
+				r.Put("/branch", sessionHandler.SwitchBranch)
+				r.Post("/regenerate", sessionHandler.Regenerate)
 			})
 		})
 
+		// Full-text search
+		if svc.Search != nil {
+			searchHandler := handlers.NewSearchHandler(svc.Search)
+			r.Get("/search", searchHandler.Search)
+		}
+
+		// Semantic retrieval
+		if svc.Retrieval != nil {
+			retrievalHandler := handlers.NewRetrievalHandler(svc.Retrieval)
+			r.Route("/retrieval", func(r chi.Router) {
+				r.Get("/status", retrievalHandler.GetStatus)
+				r.Post("/index", retrievalHandler.Reindex)
+				r.Post("/query", retrievalHandler.Query)
+			})
+		}
+
+		// Agent tools
+		if svc.Tools != nil {
+			toolHandler := handlers.NewToolHandler(svc.Tools)
+			r.Route("/tools", func(r chi.Router) {
+				r.Get("/", toolHandler.ListTools)
+				r.Post("/{name}", toolHandler.InvokeTool)
+			})
+		}
+
 		// Settings resource
 		r.Route("/settings", func(r chi.Router) {
 			if svc.ConfigStore != nil {
@@ -80,6 +135,11 @@ func NewRouterWithServices(svc RouterServices) *chi.Mux {
 				providerHandler := handlers.NewProviderHandler(svc.Provider)
 				r.Post("/validate", providerHandler.ValidateProvider)
 				r.Get("/{type}/models", providerHandler.ListModels)
+
+				ollamaHandler := handlers.NewOllamaModelHandler(svc.Provider, svc.Hub)
+				r.Post("/ollama/pull", ollamaHandler.PullModel)
+				r.Get("/ollama/models/*", ollamaHandler.GetModel)
+				r.Delete("/ollama/models/*", ollamaHandler.DeleteModel)
 			}
 		})
 
@@ -92,12 +152,92 @@ func NewRouterWithServices(svc RouterServices) *chi.Mux {
 				r.Get("/agents", bmadHandler.GetAgents)
 				r.Get("/agents/{id}", bmadHandler.GetAgent)
 				r.Get("/status", bmadHandler.GetStatus)
+				r.Get("/tracks", bmadHandler.GetTracks)
+				r.Get("/tracks/{track}/phases", bmadHandler.GetTrackPhases)
+				r.Get("/tracks/{track}/compare", bmadHandler.CompareTrack)
+
+				// Workflow status write routes
+				if svc.StatusWriter != nil {
+					statusHandler := handlers.NewWorkflowStatusHandler(svc.StatusWriter)
+					r.Put("/status/workflows/{id}", statusHandler.UpdateWorkflowStatus)
+					r.Put("/status/track", statusHandler.UpdateTrack)
+				}
 
 				// Artifact routes
 				if svc.Artifact != nil {
-					artifactHandler := handlers.NewArtifactHandler(svc.Artifact)
+					artifactHandler := handlers.NewArtifactHandler(svc.Artifact, svc.Git)
 					r.Get("/artifacts", artifactHandler.GetArtifacts)
 					r.Get("/artifacts/{id}", artifactHandler.GetArtifact)
+					r.Post("/artifacts", artifactHandler.CreateArtifact)
+					r.Get("/artifacts/{id}/content", artifactHandler.GetArtifactContent)
+					r.Put("/artifacts/{id}/content", artifactHandler.PutArtifactContent)
+					r.Patch("/artifacts/{id}/frontmatter", artifactHandler.PatchArtifactFrontmatter)
+					r.Get("/artifacts/{id}/frontmatter", artifactHandler.GetArtifactFrontmatter)
+					r.Get("/artifacts/{id}/outline", artifactHandler.GetArtifactOutline)
+					r.Get("/artifacts/{id}/sections/{sectionId}", artifactHandler.GetArtifactSection)
+				}
+
+				// Artifact export routes
+				if svc.Export != nil {
+					exportHandler := handlers.NewExportHandler(svc.Export)
+					r.Get("/export", exportHandler.ExportDownload)
+					r.Post("/export", exportHandler.Export)
+				}
+
+				// Artifact dependency graph route
+				if svc.ArtifactGraph != nil {
+					graphHandler := handlers.NewGraphHandler(svc.ArtifactGraph)
+					r.Get("/artifacts/graph", graphHandler.GetGraph)
+				}
+
+				// Artifact diagnostics route
+				if svc.Validation != nil {
+					validationHandler := handlers.NewValidationHandler(svc.Validation)
+					r.Get("/artifacts/{id}/diagnostics", validationHandler.GetDiagnostics)
+				}
+
+				// Artifact history routes
+				if svc.History != nil {
+					historyHandler := handlers.NewHistoryHandler(svc.History)
+					r.Get("/artifacts/{id}/history", historyHandler.GetHistory)
+					r.Get("/artifacts/{id}/history/{versionId}", historyHandler.GetVersion)
+					r.Get("/artifacts/{id}/diff", historyHandler.GetDiff)
+					r.Post("/artifacts/{id}/restore", historyHandler.RestoreVersion)
+				}
+
+				// Epic and story routes
+				if svc.Story != nil {
+					storyHandler := handlers.NewStoryHandler(svc.Story)
+					r.Get("/epics", storyHandler.GetEpics)
+					r.Get("/stories", storyHandler.GetStories)
+					r.Get("/stories/{key}", storyHandler.GetStory)
+				}
+
+				// Sprint board routes
+				if svc.Sprint != nil {
+					sprintHandler := handlers.NewSprintHandler(svc.Sprint)
+					r.Post("/stories/{key}/move", sprintHandler.MoveStory)
+				}
+
+				// Progress metrics routes
+				if svc.Metrics != nil {
+					metricsHandler := handlers.NewMetricsHandler(svc.Metrics)
+					r.Get("/metrics", metricsHandler.GetMetrics)
+					r.Get("/metrics/timeline", metricsHandler.GetTimeline)
+				}
+
+				// Issue tracker sync routes
+				if svc.TrackerSync != nil {
+					trackerHandler := handlers.NewTrackerHandler(svc.TrackerSync)
+					r.Get("/tracker", trackerHandler.GetStatus)
+					r.Post("/tracker/sync", trackerHandler.Sync)
+				}
+
+				// Git routes
+				if svc.Git != nil {
+					gitHandler := handlers.NewGitHandler(svc.Git)
+					r.Get("/git/status", gitHandler.GetStatus)
+					r.Post("/git/commit", gitHandler.Commit)
 				}
 			})
 		}
@@ -33,6 +33,7 @@ func main() {
 	var workflowPathService *services.WorkflowPathService
 	var agentService *services.AgentService
 	var workflowStatusService *services.WorkflowStatusService
+	var statusWriter *services.WorkflowStatusWriter
 	var artifactService *services.ArtifactService
 	var fileWatcherService *services.FileWatcherService
 
@@ -57,12 +58,17 @@ func main() {
 			if err := workflowStatusService.LoadStatus(); err != nil {
 				log.Printf("Warning: Failed to load workflow status: %v", err)
 			}
+			statusWriter = services.NewWorkflowStatusWriter(configService, workflowPathService, workflowStatusService, hub)
 		}
 
+		// Reuse unchanged entries from artifact-registry.json so large projects start quickly
 		artifactService = services.NewArtifactService(configService, workflowStatusService)
-		if err := artifactService.LoadArtifacts(); err != nil {
+		if err := artifactService.WarmStart(); err != nil {
 			log.Printf("Warning: Failed to load artifacts: %v", err)
 		}
+		if workflowStatusService != nil {
+			workflowStatusService.UseArtifacts(artifactService)
+		}
 
 		if artifactService != nil && workflowStatusService != nil {
 			fileWatcherService = services.NewFileWatcherService(hub, configService, artifactService, workflowStatusService)
@@ -81,14 +87,145 @@ func main() {
 		log.Printf("Warning: Failed to initialize config store: %v", err)
 	}
 
+	// Initialize session persistence; sessions are unavailable if the store cannot be created
+	var sessionService *services.SessionService
+	sessionStore, err := storage.NewSessionStore()
+	if err != nil {
+		log.Printf("Warning: Failed to initialize session store: %v", err)
+	} else {
+		sessionService = services.NewSessionService(sessionStore, providerService, configStore)
+	}
+
+	// Build the search index and keep it current from watcher and session events
+	searchService := services.NewSearchService(artifactService, sessionStore)
+	if err := searchService.Rebuild(); err != nil {
+		log.Printf("Warning: Failed to build search index: %v", err)
+	}
+	if fileWatcherService != nil {
+		fileWatcherService.AddListener(searchService)
+	}
+	if sessionService != nil {
+		sessionService.AddListener(searchService)
+	}
+
+	// The dependency graph is derived from the artifact registry on each request
+	var artifactGraphService *services.ArtifactGraphService
+	if artifactService != nil {
+		artifactGraphService = services.NewArtifactGraphService(configService, artifactService)
+	}
+
+	// Artifacts are re-validated on every change and the diagnostics broadcast to clients
+	var validationService *services.ValidationService
+	if artifactService != nil {
+		validationService = services.NewValidationService(configService, artifactService, hub)
+		if fileWatcherService != nil {
+			fileWatcherService.AddListener(validationService)
+		}
+	}
+
+	// Snapshot artifact versions on startup and on every change so history has each prior version
+	var historyService *services.HistoryService
+	if artifactService != nil {
+		historyStore, err := storage.NewHistoryStore()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize history store: %v", err)
+		} else {
+			historyService = services.NewHistoryService(configService, artifactService, historyStore)
+			if err := historyService.Seed(); err != nil {
+				log.Printf("Warning: Failed to snapshot artifacts: %v", err)
+			}
+			if fileWatcherService != nil {
+				fileWatcherService.AddListener(historyService)
+			}
+		}
+	}
+
+	// Export bundles combine artifacts into markdown, HTML and PDF documents
+	var exportService *services.ExportService
+	if artifactService != nil {
+		exportService = services.NewExportService(configService, artifactService)
+	}
+
+	// Git status is read on demand; watcher changes invalidate the cached status
+	var gitService *services.GitService
+	if artifactService != nil {
+		gitService = services.NewGitService(configService, artifactService)
+		if fileWatcherService != nil {
+			fileWatcherService.AddListener(gitService)
+		}
+	}
+
+	// Epics and stories are parsed from the artifacts and joined with sprint-status.yaml
+	var storyService *services.StoryService
+	var sprintService *services.SprintService
+	if artifactService != nil {
+		storyService = services.NewStoryService(artifactService, workflowStatusService)
+		if workflowStatusService != nil {
+			sprintService = services.NewSprintService(configService, artifactService, workflowStatusService, storyService, hub)
+		}
+	}
+
+	// Sync development_status with the issue tracker named by sprint-status.yaml's tracking_system
+	var trackerSyncService *services.TrackerSyncService
+	if sprintService != nil {
+		trackerSyncService = services.NewTrackerSyncService(configService, workflowStatusService, storyService, sprintService)
+		fileTracker, err := services.NewFileTracker()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize file tracker: %v", err)
+		} else {
+			trackerSyncService.Register(services.FileTrackerSystem, fileTracker)
+		}
+	}
+
+	// Record status transitions under ~/bmad-studio/events for progress metrics
+	var metricsService *services.MetricsService
+	if workflowStatusService != nil {
+		eventStore, err := storage.NewEventStore()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize event store: %v", err)
+		} else {
+			metricsService = services.NewMetricsService(configService, workflowPathService, workflowStatusService, eventStore)
+			if err := metricsService.Seed(); err != nil {
+				log.Printf("Warning: Failed to record progress events: %v", err)
+			}
+			workflowStatusService.AddListener(metricsService)
+		}
+	}
+
+	// Semantic retrieval needs artifacts; vectors persist under ~/bmad-studio/vectors
+	var retrievalService *services.RetrievalService
+	toolRegistry := services.NewToolRegistry()
+	if artifactService != nil {
+		vectorStore, err := storage.NewVectorStore()
+		if err != nil {
+			log.Printf("Warning: Failed to initialize vector store: %v", err)
+		}
+		retrievalService = services.NewRetrievalService(configService, artifactService, providerService, configStore, vectorStore)
+		toolRegistry.Register(services.NewRetrievalTool(retrievalService))
+	}
+
 	// Create router with all services
 	router := api.NewRouterWithServices(api.RouterServices{
 		BMadConfig:     configService,
 		WorkflowPath:   workflowPathService,
 		Agent:          agentService,
 		WorkflowStatus: workflowStatusService,
+		StatusWriter:   statusWriter,
 		Artifact:       artifactService,
+		ArtifactGraph:  artifactGraphService,
+		History:        historyService,
+		Validation:     validationService,
+		Git:            gitService,
+		Story:          storyService,
+		Sprint:         sprintService,
+		Metrics:        metricsService,
+		TrackerSync:    trackerSyncService,
+		Export:         exportService,
 		Provider:       providerService,
+		Session:        sessionService,
+		Search:         searchService,
+		Retrieval:      retrievalService,
+		Tools:          toolRegistry,
 		ConfigStore:    configStore,
 		Hub:            hub,
 	})
@@ -114,6 +251,11 @@ func main() {
 	if fileWatcherService != nil {
 		fileWatcherService.Stop()
 	}
+	if artifactService != nil {
+		if err := artifactService.FlushRegistry(); err != nil {
+			log.Printf("Warning: Failed to save artifact registry: %v", err)
+		}
+	}
 	hub.Stop()
 
 	log.Println("Server stopped")
@@ -0,0 +1,328 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Recorder captures live traffic or serves recorded traffic.
+type CassetteMode int
+
+const (
+	// CassetteModeReplay serves responses from the cassette file and never touches the network.
+	CassetteModeReplay CassetteMode = iota
+	// CassetteModeRecord forwards requests to the real transport and captures the exchanges.
+	CassetteModeRecord
+)
+
+// cassetteVersion is the on-disk format version written to cassette files.
+const cassetteVersion = 1
+
+// redactedValue replaces scrubbed secrets in recorded headers and bodies.
+const redactedValue = "[REDACTED]"
+
+// sensitiveHeaders lists headers that are always scrubbed before a cassette is written.
+// Header names are compared in canonical form.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"X-Api-Key":           true,
+	"Api-Key":             true,
+	"Openai-Organization": true,
+	"Openai-Project":      true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// Cassette is the serialized form of a set of recorded HTTP exchanges.
+type Cassette struct {
+	Version      int                   `json:"version"`
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction is a single recorded request/response pair.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest captures the parts of a request used for matching and debugging.
+type CassetteRequest struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body,omitempty"`
+}
+
+// CassetteResponse captures a response body as the sequence of chunks read from the wire,
+// so that SSE and NDJSON framing is reproduced exactly on replay.
+type CassetteResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Chunks  []string            `json:"chunks"`
+}
+
+// Recorder is an http.RoundTripper that records exchanges to, or replays them from, a cassette file.
+type Recorder struct {
+	mu        sync.Mutex
+	path      string
+	mode      CassetteMode
+	transport http.RoundTripper
+	secrets   []string
+	cassette  *Cassette
+	used      []bool
+}
+
+// NewRecorder creates a Recorder for the cassette at path.
+// In replay mode the cassette must already exist. In record mode any existing cassette is
+// replaced when Save is called. Every string in secrets is scrubbed from recorded bodies.
+func NewRecorder(path string, mode CassetteMode, secrets ...string) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		cassette:  &Cassette{Version: cassetteVersion},
+	}
+	for _, s := range secrets {
+		if s != "" {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+
+	if mode == CassetteModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: failed to read %s: %w", path, err)
+		}
+		var c Cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("cassette: failed to parse %s: %w", path, err)
+		}
+		r.cassette = &c
+		r.used = make([]bool, len(c.Interactions))
+	}
+
+	return r, nil
+}
+
+// Client returns an *http.Client that routes all traffic through the recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Mode returns the recorder's mode.
+func (r *Recorder) Mode() CassetteMode {
+	return r.mode
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == CassetteModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// replay finds the next unused interaction matching the request method and path.
+// Once all matching interactions are used, the last one is served again so that
+// SDK retries against a recorded error response stay deterministic.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, in := range r.cassette.Interactions {
+		if !matchesRequest(in.Request, req) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match == -1 {
+		return nil, fmt.Errorf("cassette: no recorded interaction for %s %s in %s", req.Method, req.URL.Path, filepath.Base(r.path))
+	}
+	r.used[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	header := make(http.Header, len(recorded.Headers))
+	for k, v := range recorded.Headers {
+		header[k] = append([]string(nil), v...)
+	}
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode: recorded.Status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Body:       &chunkReader{chunks: recorded.Chunks},
+		Request:    req,
+	}, nil
+}
+
+// matchesRequest reports whether a recorded request corresponds to an outgoing one.
+func matchesRequest(recorded CassetteRequest, req *http.Request) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	path := recorded.URL
+	if i := strings.Index(path, "://"); i != -1 {
+		path = path[i+3:]
+		if j := strings.IndexByte(path, '/'); j != -1 {
+			path = path[j:]
+		} else {
+			path = "/"
+		}
+	}
+	if i := strings.IndexByte(path, '?'); i != -1 {
+		path = path[:i]
+	}
+	return path == req.URL.Path
+}
+
+// record forwards the request and captures the response body as it is read.
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, CassetteInteraction{
+		Request: CassetteRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: r.scrubHeaders(req.Header),
+			Body:    r.scrub(string(body)),
+		},
+		Response: CassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: r.scrubHeaders(resp.Header),
+			Chunks:  []string{},
+		},
+	})
+	index := len(r.cassette.Interactions) - 1
+	r.mu.Unlock()
+
+	resp.Body = &recordingBody{
+		ReadCloser: resp.Body,
+		onChunk: func(chunk []byte) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			in := &r.cassette.Interactions[index]
+			in.Response.Chunks = append(in.Response.Chunks, r.scrub(string(chunk)))
+		},
+	}
+	return resp, nil
+}
+
+// scrub replaces every configured secret in s.
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redactedValue)
+	}
+	return s
+}
+
+// scrubHeaders copies h, redacting sensitive headers and configured secrets.
+func (r *Recorder) scrubHeaders(h http.Header) map[string][]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(h))
+	for k, values := range h {
+		canonical := http.CanonicalHeaderKey(k)
+		scrubbed := make([]string, len(values))
+		for i, v := range values {
+			if sensitiveHeaders[canonical] {
+				scrubbed[i] = redactedValue
+			} else {
+				scrubbed[i] = r.scrub(v)
+			}
+		}
+		out[canonical] = scrubbed
+	}
+	return out
+}
+
+// Save writes the recorded cassette to disk. It is a no-op in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != CassetteModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("cassette: failed to marshal: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("cassette: failed to create directory: %w", err)
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// recordingBody reports every non-empty read to onChunk, preserving wire chunk boundaries.
+type recordingBody struct {
+	io.ReadCloser
+	onChunk func([]byte)
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.onChunk(p[:n])
+	}
+	return n, err
+}
+
+// chunkReader returns recorded chunks one Read at a time. A chunk larger than the
+// caller's buffer is split across consecutive reads but never merged with the next one.
+type chunkReader struct {
+	chunks []string
+	offset int
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for len(c.chunks) > 0 && c.offset >= len(c.chunks[0]) {
+		c.chunks = c.chunks[1:]
+		c.offset = 0
+	}
+	if len(c.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, c.chunks[0][c.offset:])
+	c.offset += n
+	return n, nil
+}
+
+func (c *chunkReader) Close() error {
+	return nil
+}
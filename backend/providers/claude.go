@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
@@ -12,25 +13,31 @@ import (
 // claudeModels is the hardcoded list of available Claude models.
 var claudeModels = []Model{
 	{
-		ID:        string(anthropic.ModelClaudeOpus4_5_20251101),
-		Name:      "Claude Opus 4.5",
-		Provider:  "claude",
-		MaxTokens: 32768,
+		ID:         string(anthropic.ModelClaudeOpus4_5_20251101),
+		Name:       "Claude Opus 4.5",
+		Provider:   "claude",
+		MaxTokens:  32768,
+		Parameters: claudeParameterCapabilities,
 	},
 	{
-		ID:        string(anthropic.ModelClaudeSonnet4_5_20250929),
-		Name:      "Claude Sonnet 4.5",
-		Provider:  "claude",
-		MaxTokens: 16384,
+		ID:         string(anthropic.ModelClaudeSonnet4_5_20250929),
+		Name:       "Claude Sonnet 4.5",
+		Provider:   "claude",
+		MaxTokens:  16384,
+		Parameters: claudeParameterCapabilities,
 	},
 	{
-		ID:        string(anthropic.ModelClaudeHaiku4_5_20251001),
-		Name:      "Claude Haiku 4.5",
-		Provider:  "claude",
-		MaxTokens: 8192,
+		ID:         string(anthropic.ModelClaudeHaiku4_5_20251001),
+		Name:       "Claude Haiku 4.5",
+		Provider:   "claude",
+		MaxTokens:  8192,
+		Parameters: claudeParameterCapabilities,
 	},
 }
 
+// maxClaudeCacheBreakpoints is the number of cache_control blocks the API accepts per request.
+const maxClaudeCacheBreakpoints = 4
+
 // ClaudeProvider implements the Provider interface for the Anthropic Claude API.
 type ClaudeProvider struct {
 	client *anthropic.Client
@@ -44,6 +51,16 @@ func NewClaudeProvider(apiKey string) *ClaudeProvider {
 	return &ClaudeProvider{client: &client}
 }
 
+// NewClaudeProviderWithClient creates a ClaudeProvider whose SDK client sends requests through httpClient.
+// Used to route traffic through a cassette Recorder in tests.
+func NewClaudeProviderWithClient(apiKey string, httpClient *http.Client) *ClaudeProvider {
+	client := anthropic.NewClient(
+		option.WithAPIKey(apiKey),
+		option.WithHTTPClient(httpClient),
+	)
+	return &ClaudeProvider{client: &client}
+}
+
 // ValidateCredentials checks if the API key is valid by sending a minimal request.
 func (p *ClaudeProvider) ValidateCredentials(ctx context.Context) error {
 	_, err := p.client.Messages.New(ctx, anthropic.MessageNewParams{
@@ -68,13 +85,25 @@ func (p *ClaudeProvider) ListModels() ([]Model, error) {
 
 // SendMessage sends a chat request and returns a channel streaming response chunks.
 func (p *ClaudeProvider) SendMessage(ctx context.Context, req ChatRequest) (<-chan StreamChunk, error) {
+	breakpoints := 0
+	if req.CachePrompt && req.SystemPrompt != "" {
+		breakpoints++
+	}
+
 	messages := make([]anthropic.MessageParam, 0, len(req.Messages))
-	for _, msg := range req.Messages {
+	for i, msg := range req.Messages {
+		block := anthropic.TextBlockParam{Text: msg.Content}
+		if msg.CacheBreakpoint || (req.CachePrompt && i == len(req.Messages)-1) {
+			block.CacheControl = anthropic.NewCacheControlEphemeralParam()
+			breakpoints++
+		}
+		content := anthropic.ContentBlockParamUnion{OfText: &block}
+
 		switch msg.Role {
 		case "user":
-			messages = append(messages, anthropic.NewUserMessage(anthropic.NewTextBlock(msg.Content)))
+			messages = append(messages, anthropic.NewUserMessage(content))
 		case "assistant":
-			messages = append(messages, anthropic.NewAssistantMessage(anthropic.NewTextBlock(msg.Content)))
+			messages = append(messages, anthropic.NewAssistantMessage(content))
 		default:
 			return nil, &ProviderError{
 				Code:        "invalid_role",
@@ -84,16 +113,27 @@ func (p *ClaudeProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 		}
 	}
 
+	if breakpoints > maxClaudeCacheBreakpoints {
+		return nil, invalidParams("at most %d cache breakpoints are allowed, got %d", maxClaudeCacheBreakpoints, breakpoints)
+	}
+
+	if err := req.GenerationParams.Validate(capabilitiesFor(claudeModels, req.Model, claudeParameterCapabilities), req.Model); err != nil {
+		return nil, err
+	}
+
 	params := anthropic.MessageNewParams{
 		Model:     anthropic.Model(req.Model),
 		MaxTokens: int64(req.MaxTokens),
 		Messages:  messages,
 	}
+	applyClaudeGenerationParams(&params, req.GenerationParams)
 
 	if req.SystemPrompt != "" {
-		params.System = []anthropic.TextBlockParam{
-			{Text: req.SystemPrompt},
+		system := anthropic.TextBlockParam{Text: req.SystemPrompt}
+		if req.CachePrompt {
+			system.CacheControl = anthropic.NewCacheControlEphemeralParam()
 		}
+		params.System = []anthropic.TextBlockParam{system}
 	}
 
 	stream := p.client.Messages.NewStreaming(ctx, params)
@@ -150,8 +190,10 @@ func (p *ClaudeProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 					Type:      "end",
 					MessageID: messageID,
 					Usage: &UsageStats{
-						InputTokens:  int(acc.Usage.InputTokens),
-						OutputTokens: int(acc.Usage.OutputTokens),
+						InputTokens:      int(acc.Usage.InputTokens),
+						OutputTokens:     int(acc.Usage.OutputTokens),
+						CacheReadTokens:  int(acc.Usage.CacheReadInputTokens),
+						CacheWriteTokens: int(acc.Usage.CacheCreationInputTokens),
 					},
 				}) {
 					return
@@ -172,6 +214,22 @@ func (p *ClaudeProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 	return ch, nil
 }
 
+// applyClaudeGenerationParams copies the set generation parameters onto the SDK request.
+func applyClaudeGenerationParams(params *anthropic.MessageNewParams, g GenerationParams) {
+	if g.Temperature != nil {
+		params.Temperature = anthropic.Float(*g.Temperature)
+	}
+	if g.TopP != nil {
+		params.TopP = anthropic.Float(*g.TopP)
+	}
+	if g.TopK != nil {
+		params.TopK = anthropic.Int(int64(*g.TopK))
+	}
+	if len(g.StopSequences) > 0 {
+		params.StopSequences = g.StopSequences
+	}
+}
+
 // mapProviderError converts SDK errors to user-friendly ProviderError values.
 // API keys must never appear in the returned error messages (NFR6).
 func mapProviderError(err error) *ProviderError {
@@ -442,3 +442,173 @@ func TestMapProviderError_AllStatusCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestClaudeProvider_SendMessage_GenerationParams(t *testing.T) {
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, r.ContentLength)
+		r.Body.Read(buf)
+		receivedBody = string(buf)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `event: message_start
+data: {"type":"message_start","message":{"id":"msg_gen","type":"message","role":"assistant","content":[],"model":"claude-sonnet-4-5-20250929","stop_reason":null,"usage":{"input_tokens":5,"output_tokens":0}}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`)
+	}))
+	defer server.Close()
+
+	p := newTestClaudeProvider(server.URL)
+	ch, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages:  []Message{{Role: "user", Content: "Hello"}},
+		Model:     "claude-sonnet-4-5-20250929",
+		MaxTokens: 100,
+		GenerationParams: GenerationParams{
+			Temperature:   float64Ptr(0.3),
+			TopK:          intPtr(20),
+			StopSequences: []string{"END"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for range ch {
+	}
+
+	for _, want := range []string{`"temperature":0.3`, `"top_k":20`, `"stop_sequences":["END"]`} {
+		if !strings.Contains(receivedBody, want) {
+			t.Errorf("Expected %s in request body, got: %s", want, receivedBody)
+		}
+	}
+}
+
+func TestClaudeProvider_SendMessage_RejectsUnsupportedParams(t *testing.T) {
+	p := newTestClaudeProvider("http://127.0.0.1:1")
+	_, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages:  []Message{{Role: "user", Content: "Hello"}},
+		Model:     "claude-sonnet-4-5-20250929",
+		MaxTokens: 100,
+		GenerationParams: GenerationParams{
+			Temperature: float64Ptr(0.5),
+			TopP:        float64Ptr(0.9),
+		},
+	})
+	pErr, ok := err.(*ProviderError)
+	if !ok {
+		t.Fatalf("Expected *ProviderError, got %T (%v)", err, err)
+	}
+	if pErr.Code != "invalid_parameters" {
+		t.Errorf("Expected code 'invalid_parameters', got %q", pErr.Code)
+	}
+}
+
+func TestClaudeProvider_SendMessage_PromptCaching(t *testing.T) {
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, r.ContentLength)
+		r.Body.Read(buf)
+		receivedBody = string(buf)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `event: message_start
+data: {"type":"message_start","message":{"id":"msg_cache","type":"message","role":"assistant","content":[],"model":"claude-sonnet-4-5-20250929","stop_reason":null,"usage":{"input_tokens":12,"output_tokens":0,"cache_creation_input_tokens":300,"cache_read_input_tokens":2048}}}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":4}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`)
+	}))
+	defer server.Close()
+
+	p := newTestClaudeProvider(server.URL)
+	ch, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages: []Message{
+			{Role: "user", Content: "PRD context", CacheBreakpoint: true},
+			{Role: "assistant", Content: "Understood."},
+			{Role: "user", Content: "Summarize the PRD"},
+		},
+		Model:        "claude-sonnet-4-5-20250929",
+		MaxTokens:    100,
+		SystemPrompt: "You are the architect agent.",
+		CachePrompt:  true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var usage *UsageStats
+	for chunk := range ch {
+		if chunk.Type == "end" {
+			usage = chunk.Usage
+		}
+	}
+
+	if got := strings.Count(receivedBody, `"cache_control":{"type":"ephemeral"}`); got != 3 {
+		t.Errorf("Expected 3 cache breakpoints (system, context, last message), got %d. Body: %s", got, receivedBody)
+	}
+	if usage == nil {
+		t.Fatal("Expected usage on end chunk")
+	}
+	if usage.InputTokens != 12 || usage.CacheReadTokens != 2048 || usage.CacheWriteTokens != 300 {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+}
+
+func TestClaudeProvider_SendMessage_TooManyCacheBreakpoints(t *testing.T) {
+	p := newTestClaudeProvider("http://127.0.0.1:1")
+	messages := make([]Message, 0, 5)
+	for i := 0; i < 5; i++ {
+		messages = append(messages, Message{Role: "user", Content: "context", CacheBreakpoint: true})
+	}
+	_, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages:  messages,
+		Model:     "claude-sonnet-4-5-20250929",
+		MaxTokens: 100,
+	})
+	pErr, ok := err.(*ProviderError)
+	if !ok {
+		t.Fatalf("Expected *ProviderError, got %T (%v)", err, err)
+	}
+	if pErr.Code != "invalid_parameters" {
+		t.Errorf("Expected code 'invalid_parameters', got %q", pErr.Code)
+	}
+}
+
+func TestClaudeProvider_SendMessage_NoCacheControlByDefault(t *testing.T) {
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, r.ContentLength)
+		r.Body.Read(buf)
+		receivedBody = string(buf)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+	}))
+	defer server.Close()
+
+	p := newTestClaudeProvider(server.URL)
+	ch, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages:     []Message{{Role: "user", Content: "Hello"}},
+		Model:        "claude-sonnet-4-5-20250929",
+		MaxTokens:    100,
+		SystemPrompt: "You are a helpful assistant.",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for range ch {
+	}
+
+	if strings.Contains(receivedBody, "cache_control") {
+		t.Errorf("Expected no cache_control without CachePrompt, got: %s", receivedBody)
+	}
+}
@@ -0,0 +1,34 @@
+package providers
+
+import "context"
+
+// Embedder is implemented by providers that can turn text into embedding vectors.
+// It is an optional capability: callers type-assert a Provider to Embedder.
+type Embedder interface {
+	// Embed returns one vector per input, in input order.
+	Embed(ctx context.Context, req EmbedRequest) (*EmbedResponse, error)
+}
+
+// EmbedRequest contains the parameters for an embedding request.
+type EmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// EmbedResponse contains the embedding vectors for an EmbedRequest.
+type EmbedResponse struct {
+	Model       string      `json:"model"`
+	Embeddings  [][]float32 `json:"embeddings"`
+	InputTokens int         `json:"input_tokens"`
+}
+
+// DefaultEmbeddingModels maps each provider with embedding support to its default model.
+var DefaultEmbeddingModels = map[string]string{
+	"openai": "text-embedding-3-small",
+	"ollama": "nomic-embed-text",
+}
+
+// emptyEmbedResponse is returned for requests without input, which providers reject.
+func emptyEmbedResponse(model string) *EmbedResponse {
+	return &EmbedResponse{Model: model, Embeddings: [][]float32{}}
+}
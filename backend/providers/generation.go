@@ -0,0 +1,145 @@
+package providers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerationParams contains optional sampling parameters for a chat request.
+// Nil fields are left to the provider's defaults.
+type GenerationParams struct {
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"top_p,omitempty"`
+	TopK          *int     `json:"top_k,omitempty"`
+	StopSequences []string `json:"stop_sequences,omitempty"`
+	Seed          *int64   `json:"seed,omitempty"`
+}
+
+// IsZero reports whether no generation parameters are set.
+func (g GenerationParams) IsZero() bool {
+	return g.Temperature == nil && g.TopP == nil && g.TopK == nil && len(g.StopSequences) == 0 && g.Seed == nil
+}
+
+// Merge returns g with every parameter set in overlay taking precedence.
+func (g GenerationParams) Merge(overlay GenerationParams) GenerationParams {
+	merged := g
+	if overlay.Temperature != nil {
+		merged.Temperature = overlay.Temperature
+	}
+	if overlay.TopP != nil {
+		merged.TopP = overlay.TopP
+	}
+	if overlay.TopK != nil {
+		merged.TopK = overlay.TopK
+	}
+	if overlay.StopSequences != nil {
+		merged.StopSequences = overlay.StopSequences
+	}
+	if overlay.Seed != nil {
+		merged.Seed = overlay.Seed
+	}
+	return merged
+}
+
+// ParameterCapabilities describes which generation parameters a model accepts.
+type ParameterCapabilities struct {
+	MaxTemperature   float64 `json:"max_temperature"`
+	SupportsTopP     bool    `json:"supports_top_p"`
+	SupportsTopK     bool    `json:"supports_top_k"`
+	SupportsSeed     bool    `json:"supports_seed"`
+	MaxStopSequences int     `json:"max_stop_sequences"` // 0 means stop sequences are not supported
+	// TemperatureTopPExclusive is set for models that reject requests specifying both temperature and top_p.
+	TemperatureTopPExclusive bool `json:"temperature_top_p_exclusive,omitempty"`
+}
+
+// claudeParameterCapabilities applies to all current Claude models.
+var claudeParameterCapabilities = ParameterCapabilities{
+	MaxTemperature:           1.0,
+	SupportsTopP:             true,
+	SupportsTopK:             true,
+	SupportsSeed:             false,
+	MaxStopSequences:         16,
+	TemperatureTopPExclusive: true,
+}
+
+// openaiParameterCapabilities applies to the GPT-4o and GPT-4.1 families.
+var openaiParameterCapabilities = ParameterCapabilities{
+	MaxTemperature:   2.0,
+	SupportsTopP:     true,
+	SupportsTopK:     false,
+	SupportsSeed:     true,
+	MaxStopSequences: 4,
+}
+
+// ollamaParameterCapabilities applies to all local Ollama models.
+var ollamaParameterCapabilities = ParameterCapabilities{
+	MaxTemperature:   2.0,
+	SupportsTopP:     true,
+	SupportsTopK:     true,
+	SupportsSeed:     true,
+	MaxStopSequences: 16,
+}
+
+// invalidParams builds the ProviderError returned for rejected generation parameters.
+func invalidParams(format string, args ...interface{}) *ProviderError {
+	msg := fmt.Sprintf(format, args...)
+	return &ProviderError{
+		Code:        "invalid_parameters",
+		Message:     msg,
+		UserMessage: strings.ToUpper(msg[:1]) + msg[1:] + ".",
+	}
+}
+
+// Validate checks g against the capabilities of the target model.
+func (g GenerationParams) Validate(caps ParameterCapabilities, model string) error {
+	if g.Temperature != nil && (*g.Temperature < 0 || *g.Temperature > caps.MaxTemperature) {
+		return invalidParams("temperature must be between 0 and %g for %s", caps.MaxTemperature, model)
+	}
+	if g.TopP != nil {
+		if !caps.SupportsTopP {
+			return invalidParams("top_p is not supported by %s", model)
+		}
+		if *g.TopP < 0 || *g.TopP > 1 {
+			return invalidParams("top_p must be between 0 and 1")
+		}
+	}
+	if caps.TemperatureTopPExclusive && g.Temperature != nil && g.TopP != nil {
+		return invalidParams("temperature and top_p cannot both be set for %s", model)
+	}
+	if g.TopK != nil {
+		if !caps.SupportsTopK {
+			return invalidParams("top_k is not supported by %s", model)
+		}
+		if *g.TopK < 1 {
+			return invalidParams("top_k must be at least 1")
+		}
+	}
+	if g.Seed != nil && !caps.SupportsSeed {
+		return invalidParams("seed is not supported by %s", model)
+	}
+	if len(g.StopSequences) > 0 {
+		if caps.MaxStopSequences == 0 {
+			return invalidParams("stop sequences are not supported by %s", model)
+		}
+		if len(g.StopSequences) > caps.MaxStopSequences {
+			return invalidParams("%s accepts at most %d stop sequences", model, caps.MaxStopSequences)
+		}
+		for _, stop := range g.StopSequences {
+			if stop == "" {
+				return invalidParams("stop sequences must not be empty")
+			}
+		}
+	}
+	return nil
+}
+
+// capabilitiesFor returns the parameter capabilities of model from a hardcoded model list,
+// falling back to the provider-wide defaults for unlisted models.
+func capabilitiesFor(models []Model, model string, fallback ParameterCapabilities) ParameterCapabilities {
+	for _, m := range models {
+		if m.ID == model {
+			return m.Parameters
+		}
+	}
+	return fallback
+}
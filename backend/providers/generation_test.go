@@ -0,0 +1,103 @@
+package providers
+
+import (
+	"errors"
+	"testing"
+)
+
+func float64Ptr(v float64) *float64 { return &v }
+func intPtr(v int) *int             { return &v }
+func int64Ptr(v int64) *int64       { return &v }
+
+func TestGenerationParams_IsZero(t *testing.T) {
+	if !(GenerationParams{}).IsZero() {
+		t.Error("Expected empty params to be zero")
+	}
+	if (GenerationParams{Temperature: float64Ptr(0)}).IsZero() {
+		t.Error("Expected params with explicit zero temperature to be non-zero")
+	}
+}
+
+func TestGenerationParams_Merge(t *testing.T) {
+	base := GenerationParams{
+		Temperature:   float64Ptr(0.2),
+		TopK:          intPtr(40),
+		StopSequences: []string{"END"},
+	}
+	overlay := GenerationParams{
+		Temperature: float64Ptr(0.9),
+		Seed:        int64Ptr(7),
+	}
+
+	merged := base.Merge(overlay)
+
+	if *merged.Temperature != 0.9 {
+		t.Errorf("Temperature = %v, want 0.9", *merged.Temperature)
+	}
+	if merged.TopK == nil || *merged.TopK != 40 {
+		t.Errorf("TopK = %v, want 40 (inherited)", merged.TopK)
+	}
+	if len(merged.StopSequences) != 1 || merged.StopSequences[0] != "END" {
+		t.Errorf("StopSequences = %v, want [END] (inherited)", merged.StopSequences)
+	}
+	if merged.Seed == nil || *merged.Seed != 7 {
+		t.Errorf("Seed = %v, want 7", merged.Seed)
+	}
+	if *base.Temperature != 0.2 {
+		t.Error("Merge should not modify the receiver")
+	}
+}
+
+func TestGenerationParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  GenerationParams
+		caps    ParameterCapabilities
+		wantErr bool
+	}{
+		{"empty", GenerationParams{}, claudeParameterCapabilities, false},
+		{"claude temperature in range", GenerationParams{Temperature: float64Ptr(1.0)}, claudeParameterCapabilities, false},
+		{"claude temperature too high", GenerationParams{Temperature: float64Ptr(1.5)}, claudeParameterCapabilities, true},
+		{"openai temperature 1.5", GenerationParams{Temperature: float64Ptr(1.5)}, openaiParameterCapabilities, false},
+		{"negative temperature", GenerationParams{Temperature: float64Ptr(-0.1)}, ollamaParameterCapabilities, true},
+		{"claude temperature and top_p", GenerationParams{Temperature: float64Ptr(0.5), TopP: float64Ptr(0.9)}, claudeParameterCapabilities, true},
+		{"openai temperature and top_p", GenerationParams{Temperature: float64Ptr(0.5), TopP: float64Ptr(0.9)}, openaiParameterCapabilities, false},
+		{"top_p out of range", GenerationParams{TopP: float64Ptr(1.2)}, ollamaParameterCapabilities, true},
+		{"openai top_k", GenerationParams{TopK: intPtr(40)}, openaiParameterCapabilities, true},
+		{"ollama top_k", GenerationParams{TopK: intPtr(40)}, ollamaParameterCapabilities, false},
+		{"top_k zero", GenerationParams{TopK: intPtr(0)}, claudeParameterCapabilities, true},
+		{"claude seed", GenerationParams{Seed: int64Ptr(42)}, claudeParameterCapabilities, true},
+		{"openai seed", GenerationParams{Seed: int64Ptr(42)}, openaiParameterCapabilities, false},
+		{"openai too many stops", GenerationParams{StopSequences: []string{"a", "b", "c", "d", "e"}}, openaiParameterCapabilities, true},
+		{"empty stop", GenerationParams{StopSequences: []string{""}}, claudeParameterCapabilities, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate(tt.caps, "test-model")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var pErr *ProviderError
+			if !errors.As(err, &pErr) {
+				t.Fatalf("Expected *ProviderError, got %T", err)
+			}
+			if pErr.Code != "invalid_parameters" {
+				t.Errorf("Code = %q, want invalid_parameters", pErr.Code)
+			}
+			if pErr.UserMessage == "" {
+				t.Error("Expected a user message")
+			}
+		})
+	}
+}
+
+func TestCapabilitiesFor_FallsBackForUnknownModel(t *testing.T) {
+	caps := capabilitiesFor(claudeModels, "claude-unknown", claudeParameterCapabilities)
+	if caps != claudeParameterCapabilities {
+		t.Errorf("Expected fallback capabilities, got %+v", caps)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -32,6 +33,14 @@ func NewOllamaProvider(endpoint string) *OllamaProvider {
 	}
 }
 
+// NewOllamaProviderWithClient creates an OllamaProvider that sends requests through httpClient.
+// Used to route traffic through a cassette Recorder in tests.
+func NewOllamaProviderWithClient(endpoint string, httpClient *http.Client) *OllamaProvider {
+	p := NewOllamaProvider(endpoint)
+	p.httpClient = httpClient
+	return p
+}
+
 // ollamaTagsResponse is the response from GET /api/tags.
 type ollamaTagsResponse struct {
 	Models []ollamaModelInfo `json:"models"`
@@ -78,6 +87,12 @@ func (p *OllamaProvider) ValidateCredentials(ctx context.Context) error {
 	return err
 }
 
+// ollamaShowConcurrency bounds the /api/show requests ListModels has in flight at once.
+const ollamaShowConcurrency = 4
+
+// ollamaShowTimeout bounds each /api/show request made by ListModels.
+const ollamaShowTimeout = 5 * time.Second
+
 // ListModels returns models dynamically fetched from the local Ollama instance.
 func (p *OllamaProvider) ListModels() ([]Model, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -88,22 +103,287 @@ func (p *OllamaProvider) ListModels() ([]Model, error) {
 		return nil, err
 	}
 
-	models := make([]Model, 0, len(tagsResp.Models))
-	for _, m := range tagsResp.Models {
-		models = append(models, Model{
-			ID:       m.Name,
-			Name:     m.Name,
-			Provider: "ollama",
-		})
+	models := make([]Model, len(tagsResp.Models))
+	for i, m := range tagsResp.Models {
+		models[i] = Model{
+			ID:         m.Name,
+			Name:       m.Name,
+			Provider:   "ollama",
+			Parameters: ollamaParameterCapabilities,
+		}
 	}
+
+	// Context length is only available from /api/show; leave zero if it cannot be fetched.
+	// Details are fetched concurrently, each with its own timeout, so a long model list
+	// neither takes one round trip per model nor runs out of time for the last ones.
+	sem := make(chan struct{}, ollamaShowConcurrency)
+	var wg sync.WaitGroup
+	for i := range models {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			showCtx, cancel := context.WithTimeout(context.Background(), ollamaShowTimeout)
+			defer cancel()
+			if details, err := p.ShowModel(showCtx, models[i].ID); err == nil {
+				models[i].MaxTokens = details.ContextLength
+			}
+		}()
+	}
+	wg.Wait()
 	return models, nil
 }
 
+// ModelDetails describes a locally installed Ollama model as reported by /api/show.
+type ModelDetails struct {
+	ID                string            `json:"id"`
+	Family            string            `json:"family"`
+	Families          []string          `json:"families,omitempty"`
+	Format            string            `json:"format"`
+	ParameterSize     string            `json:"parameter_size"`
+	QuantizationLevel string            `json:"quantization_level"`
+	ContextLength     int               `json:"context_length"`
+	Parameters        map[string]string `json:"parameters"`
+	Template          string            `json:"template,omitempty"`
+	ModifiedAt        string            `json:"modified_at,omitempty"`
+}
+
+// PullProgress is a single progress update from a model pull.
+type PullProgress struct {
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+}
+
+// ollamaModelRequest is the request body for /api/show, /api/pull and /api/delete.
+type ollamaModelRequest struct {
+	Model  string `json:"model"`
+	Stream *bool  `json:"stream,omitempty"`
+}
+
+// ollamaShowResponse is the response from POST /api/show.
+type ollamaShowResponse struct {
+	Parameters string                 `json:"parameters"`
+	Template   string                 `json:"template"`
+	ModifiedAt string                 `json:"modified_at"`
+	Details    ollamaShowDetails      `json:"details"`
+	ModelInfo  map[string]interface{} `json:"model_info"`
+}
+
+// ollamaShowDetails is the details block of the /api/show response.
+type ollamaShowDetails struct {
+	Format            string   `json:"format"`
+	Family            string   `json:"family"`
+	Families          []string `json:"families"`
+	ParameterSize     string   `json:"parameter_size"`
+	QuantizationLevel string   `json:"quantization_level"`
+}
+
+// ollamaPullResponse is a single NDJSON line from the streaming pull response.
+type ollamaPullResponse struct {
+	PullProgress
+	Error string `json:"error,omitempty"`
+}
+
+// ollamaErrorResponse is the JSON error body returned by Ollama.
+type ollamaErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// postModelRequest sends a model-scoped JSON request to the given Ollama endpoint.
+func (p *OllamaProvider) postModelRequest(ctx context.Context, method, path string, body ollamaModelRequest) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, &ProviderError{
+			Code:        "invalid_request",
+			Message:     "failed to marshal request",
+			UserMessage: "Failed to prepare the request. Please try again.",
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, mapOllamaProviderError(err, 0)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, mapOllamaProviderError(err, 0)
+	}
+	return resp, nil
+}
+
+// ShowModel returns details for a locally installed model via POST /api/show.
+func (p *OllamaProvider) ShowModel(ctx context.Context, name string) (*ModelDetails, error) {
+	resp, err := p.postModelRequest(ctx, "POST", "/api/show", ollamaModelRequest{Model: name})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, mapOllamaProviderError(nil, resp.StatusCode)
+	}
+
+	var showResp ollamaShowResponse
+	if err := json.NewDecoder(resp.Body).Decode(&showResp); err != nil {
+		return nil, &ProviderError{
+			Code:        "provider_error",
+			Message:     "failed to parse model details",
+			UserMessage: "Failed to parse Ollama model details. Please check your Ollama installation.",
+		}
+	}
+
+	parameters := parseOllamaParameters(showResp.Parameters)
+	details := &ModelDetails{
+		ID:                name,
+		Family:            showResp.Details.Family,
+		Families:          showResp.Details.Families,
+		Format:            showResp.Details.Format,
+		ParameterSize:     showResp.Details.ParameterSize,
+		QuantizationLevel: showResp.Details.QuantizationLevel,
+		ContextLength:     ollamaContextLength(showResp.ModelInfo),
+		Parameters:        parameters,
+		Template:          showResp.Template,
+		ModifiedAt:        showResp.ModifiedAt,
+	}
+	return details, nil
+}
+
+// DeleteModel removes a locally installed model via DELETE /api/delete.
+func (p *OllamaProvider) DeleteModel(ctx context.Context, name string) error {
+	resp, err := p.postModelRequest(ctx, "DELETE", "/api/delete", ollamaModelRequest{Model: name})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return mapOllamaProviderError(nil, resp.StatusCode)
+	}
+	return nil
+}
+
+// PullModel downloads a model via POST /api/pull, calling onProgress for every
+// progress line. It returns once the pull has completed or failed.
+func (p *OllamaProvider) PullModel(ctx context.Context, name string, onProgress func(PullProgress)) error {
+	stream := true
+	resp, err := p.postModelRequest(ctx, "POST", "/api/pull", ollamaModelRequest{Model: name, Stream: &stream})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ollamaErrorResponse
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		return mapOllamaPullError(errResp.Error, resp.StatusCode)
+	}
+
+	succeeded := false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var pullResp ollamaPullResponse
+		if err := json.Unmarshal(line, &pullResp); err != nil {
+			continue
+		}
+		if pullResp.Error != "" {
+			return mapOllamaPullError(pullResp.Error, 0)
+		}
+
+		if onProgress != nil {
+			onProgress(pullResp.PullProgress)
+		}
+		if pullResp.Status == "success" {
+			succeeded = true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return mapOllamaProviderError(err, 0)
+	}
+	if !succeeded {
+		return &ProviderError{
+			Code:        "provider_error",
+			Message:     "pull stream ended without success",
+			UserMessage: fmt.Sprintf("Pulling %s did not complete. Please try again.", name),
+		}
+	}
+	return nil
+}
+
+// parseOllamaParameters parses the newline-separated "key value" parameters block of /api/show.
+// Repeated keys (such as multiple stop sequences) are joined with newlines.
+func parseOllamaParameters(raw string) map[string]string {
+	params := make(map[string]string)
+	for _, line := range strings.Split(raw, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		key := fields[0]
+		value := strings.Trim(strings.Join(fields[1:], " "), `"`)
+		if existing, ok := params[key]; ok {
+			params[key] = existing + "\n" + value
+		} else {
+			params[key] = value
+		}
+	}
+	return params
+}
+
+// ollamaContextLength reads "<architecture>.context_length" from the model_info block.
+func ollamaContextLength(modelInfo map[string]interface{}) int {
+	if arch, ok := modelInfo["general.architecture"].(string); ok {
+		if v, ok := modelInfo[arch+".context_length"].(float64); ok {
+			return int(v)
+		}
+	}
+	for key, value := range modelInfo {
+		if strings.HasSuffix(key, ".context_length") {
+			if v, ok := value.(float64); ok {
+				return int(v)
+			}
+		}
+	}
+	return 0
+}
+
+// mapOllamaPullError converts an error reported by /api/pull to a ProviderError.
+func mapOllamaPullError(message string, statusCode int) *ProviderError {
+	lower := strings.ToLower(message)
+	if strings.Contains(lower, "not found") || strings.Contains(lower, "does not exist") {
+		return &ProviderError{
+			Code:        "model_not_found",
+			Message:     message,
+			UserMessage: "The requested model was not found in the Ollama library. Please check the model name.",
+		}
+	}
+	if message == "" {
+		return mapOllamaProviderError(nil, statusCode)
+	}
+	return &ProviderError{
+		Code:        "provider_error",
+		Message:     message,
+		UserMessage: "Failed to pull the model from Ollama. Please try again.",
+	}
+}
+
 // ollamaChatRequest is the request body for POST /api/chat.
 type ollamaChatRequest struct {
-	Model    string          `json:"model"`
-	Messages []ollamaMessage `json:"messages"`
-	Stream   bool            `json:"stream"`
+	Model    string                 `json:"model"`
+	Messages []ollamaMessage        `json:"messages"`
+	Stream   bool                   `json:"stream"`
+	Options  map[string]interface{} `json:"options,omitempty"`
 }
 
 // ollamaChatResponse is a single NDJSON line from the streaming chat response.
@@ -121,6 +401,73 @@ type ollamaChatResponse struct {
 	EvalDuration       int64         `json:"eval_duration,omitempty"`
 }
 
+// ollamaEmbedRequest is the request body for POST /api/embed.
+type ollamaEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// ollamaEmbedResponse is the response from POST /api/embed.
+type ollamaEmbedResponse struct {
+	Model           string      `json:"model"`
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+}
+
+// Embed returns embeddings for req.Input via POST /api/embed.
+func (p *OllamaProvider) Embed(ctx context.Context, req EmbedRequest) (*EmbedResponse, error) {
+	if len(req.Input) == 0 {
+		return emptyEmbedResponse(req.Model), nil
+	}
+
+	data, err := json.Marshal(ollamaEmbedRequest{Model: req.Model, Input: req.Input})
+	if err != nil {
+		return nil, &ProviderError{
+			Code:        "invalid_request",
+			Message:     "failed to marshal request",
+			UserMessage: "Failed to prepare the request. Please try again.",
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/embed", bytes.NewReader(data))
+	if err != nil {
+		return nil, mapOllamaProviderError(err, 0)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, mapOllamaProviderError(err, 0)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, mapOllamaProviderError(nil, resp.StatusCode)
+	}
+
+	var embedResp ollamaEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, &ProviderError{
+			Code:        "provider_error",
+			Message:     "failed to parse embedding response",
+			UserMessage: "Failed to parse Ollama embedding response. Please check your Ollama installation.",
+		}
+	}
+	if len(embedResp.Embeddings) != len(req.Input) {
+		return nil, &ProviderError{
+			Code:        "provider_error",
+			Message:     fmt.Sprintf("expected %d embeddings, got %d", len(req.Input), len(embedResp.Embeddings)),
+			UserMessage: "Ollama returned an incomplete embedding response. Please try again.",
+		}
+	}
+
+	return &EmbedResponse{
+		Model:       embedResp.Model,
+		Embeddings:  embedResp.Embeddings,
+		InputTokens: embedResp.PromptEvalCount,
+	}, nil
+}
+
 // ollamaMessage represents a message in the Ollama chat API.
 type ollamaMessage struct {
 	Role    string `json:"role"`
@@ -154,10 +501,15 @@ func (p *OllamaProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 		}
 	}
 
+	if err := req.GenerationParams.Validate(ollamaParameterCapabilities, req.Model); err != nil {
+		return nil, err
+	}
+
 	chatReq := ollamaChatRequest{
 		Model:    req.Model,
 		Messages: messages,
 		Stream:   true,
+		Options:  ollamaOptions(req.GenerationParams),
 	}
 
 	body, err := json.Marshal(chatReq)
@@ -267,6 +619,31 @@ func (p *OllamaProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 	return ch, nil
 }
 
+// ollamaOptions maps generation parameters to the Ollama "options" object.
+// Returns nil when nothing is set so the model's Modelfile defaults apply.
+func ollamaOptions(g GenerationParams) map[string]interface{} {
+	options := make(map[string]interface{})
+	if g.Temperature != nil {
+		options["temperature"] = *g.Temperature
+	}
+	if g.TopP != nil {
+		options["top_p"] = *g.TopP
+	}
+	if g.TopK != nil {
+		options["top_k"] = *g.TopK
+	}
+	if g.Seed != nil {
+		options["seed"] = *g.Seed
+	}
+	if len(g.StopSequences) > 0 {
+		options["stop"] = g.StopSequences
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return options
+}
+
 // generateOllamaMessageID generates a unique message ID for Ollama responses.
 func generateOllamaMessageID() string {
 	b := make([]byte, 16)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -132,10 +133,21 @@ func TestOllamaProvider_ValidateCredentials_ServerError(t *testing.T) {
 
 func TestOllamaProvider_ListModels(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/show" {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["model"] != "llama3.2:latest" {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":"model not found"}`)
+				return
+			}
+			fmt.Fprint(w, `{"model_info":{"general.architecture":"llama","llama.context_length":131072}}`)
+			return
+		}
 		if r.URL.Path != "/api/tags" {
 			t.Errorf("Expected path /api/tags, got %s", r.URL.Path)
 		}
-		w.Header().Set("Content-Type", "application/json")
 		fmt.Fprint(w, `{
 			"models": [
 				{
@@ -175,14 +187,60 @@ func TestOllamaProvider_ListModels(t *testing.T) {
 	if models[0].Provider != "ollama" {
 		t.Errorf("Model 0: expected Provider 'ollama', got %q", models[0].Provider)
 	}
-	if models[0].MaxTokens != 0 {
-		t.Errorf("Model 0: expected MaxTokens 0, got %d", models[0].MaxTokens)
+	if models[0].MaxTokens != 131072 {
+		t.Errorf("Model 0: expected MaxTokens 131072 from /api/show, got %d", models[0].MaxTokens)
 	}
 
-	// Second model
+	// Second model (show fails, so MaxTokens stays unknown)
 	if models[1].ID != "codellama:7b" {
 		t.Errorf("Model 1: expected ID 'codellama:7b', got %q", models[1].ID)
 	}
+	if models[1].MaxTokens != 0 {
+		t.Errorf("Model 1: expected MaxTokens 0 when /api/show fails, got %d", models[1].MaxTokens)
+	}
+}
+
+func TestOllamaProvider_ListModels_FetchesDetailsConcurrently(t *testing.T) {
+	const count = 10
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/tags" {
+			var names []string
+			for i := 0; i < count; i++ {
+				names = append(names, fmt.Sprintf(`{"name":"model-%d:latest"}`, i))
+			}
+			fmt.Fprintf(w, `{"models":[%s]}`, strings.Join(names, ","))
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"model_info":{"general.architecture":"llama","llama.context_length":8192}}`)
+	}))
+	defer server.Close()
+
+	models, err := NewOllamaProvider(server.URL).ListModels()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i, m := range models {
+		if m.ID != fmt.Sprintf("model-%d:latest", i) || m.MaxTokens != 8192 {
+			t.Errorf("Model %d: expected details in tag order, got %+v", i, m)
+		}
+	}
+	if maxInFlight < 2 || maxInFlight > ollamaShowConcurrency {
+		t.Errorf("Expected between 2 and %d concurrent /api/show requests, got %d", ollamaShowConcurrency, maxInFlight)
+	}
 }
 
 func TestOllamaProvider_ListModels_Empty(t *testing.T) {
@@ -621,3 +679,277 @@ func TestOllamaProvider_ErrorsNoSensitiveInfo(t *testing.T) {
 		t.Errorf("Error message should not expose internal endpoint URL. Got: %q", errMsg)
 	}
 }
+
+// --- Model management tests ---
+
+func TestOllamaProvider_ShowModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/show" || r.Method != "POST" {
+			t.Errorf("Expected POST /api/show, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["model"] != "llama3.2:latest" {
+			t.Errorf("Expected model 'llama3.2:latest', got %v", body["model"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"parameters": "num_ctx                        8192\nstop                           \"<|eot_id|>\"\nstop                           \"<|end_of_text|>\"\ntemperature                    0.7",
+			"template": "{{ .Prompt }}",
+			"modified_at": "2026-01-20T10:00:00Z",
+			"details": {
+				"format": "gguf",
+				"family": "llama",
+				"families": ["llama"],
+				"parameter_size": "3.2B",
+				"quantization_level": "Q4_K_M"
+			},
+			"model_info": {
+				"general.architecture": "llama",
+				"llama.context_length": 131072,
+				"llama.embedding_length": 3072
+			}
+		}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	details, err := p.ShowModel(context.Background(), "llama3.2:latest")
+	if err != nil {
+		t.Fatalf("ShowModel() error = %v", err)
+	}
+
+	if details.Family != "llama" {
+		t.Errorf("Expected family 'llama', got %q", details.Family)
+	}
+	if details.ParameterSize != "3.2B" {
+		t.Errorf("Expected parameter size '3.2B', got %q", details.ParameterSize)
+	}
+	if details.QuantizationLevel != "Q4_K_M" {
+		t.Errorf("Expected quantization 'Q4_K_M', got %q", details.QuantizationLevel)
+	}
+	if details.ContextLength != 131072 {
+		t.Errorf("Expected context length 131072, got %d", details.ContextLength)
+	}
+	if details.Parameters["num_ctx"] != "8192" {
+		t.Errorf("Expected num_ctx '8192', got %q", details.Parameters["num_ctx"])
+	}
+	if details.Parameters["stop"] != "<|eot_id|>\n<|end_of_text|>" {
+		t.Errorf("Expected joined stop sequences, got %q", details.Parameters["stop"])
+	}
+}
+
+func TestOllamaProvider_ShowModel_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"model 'missing' not found"}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	_, err := p.ShowModel(context.Background(), "missing")
+
+	pErr, ok := err.(*ProviderError)
+	if !ok {
+		t.Fatalf("Expected *ProviderError, got %T", err)
+	}
+	if pErr.Code != "model_not_found" {
+		t.Errorf("Expected error code 'model_not_found', got %q", pErr.Code)
+	}
+}
+
+func TestOllamaProvider_DeleteModel(t *testing.T) {
+	var deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/delete" || r.Method != "DELETE" {
+			t.Errorf("Expected DELETE /api/delete, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		deleted, _ = body["model"].(string)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	if err := p.DeleteModel(context.Background(), "codellama:7b"); err != nil {
+		t.Fatalf("DeleteModel() error = %v", err)
+	}
+	if deleted != "codellama:7b" {
+		t.Errorf("Expected 'codellama:7b' to be deleted, got %q", deleted)
+	}
+}
+
+func TestOllamaProvider_DeleteModel_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	err := p.DeleteModel(context.Background(), "missing")
+
+	pErr, ok := err.(*ProviderError)
+	if !ok {
+		t.Fatalf("Expected *ProviderError, got %T", err)
+	}
+	if pErr.Code != "model_not_found" {
+		t.Errorf("Expected error code 'model_not_found', got %q", pErr.Code)
+	}
+}
+
+func TestOllamaProvider_PullModel_Progress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/pull" {
+			t.Errorf("Expected path /api/pull, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		flusher := w.(http.Flusher)
+		lines := []string{
+			`{"status":"pulling manifest"}`,
+			`{"status":"pulling dde5aa3fc5ff","digest":"sha256:dde5aa3fc5ff","total":2019377376,"completed":1009688688}`,
+			`{"status":"pulling dde5aa3fc5ff","digest":"sha256:dde5aa3fc5ff","total":2019377376,"completed":2019377376}`,
+			`{"status":"verifying sha256 digest"}`,
+			`{"status":"writing manifest"}`,
+			`{"status":"success"}`,
+		}
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	var updates []PullProgress
+	err := p.PullModel(context.Background(), "llama3.2", func(pp PullProgress) {
+		updates = append(updates, pp)
+	})
+	if err != nil {
+		t.Fatalf("PullModel() error = %v", err)
+	}
+
+	if len(updates) != 6 {
+		t.Fatalf("Expected 6 progress updates, got %d", len(updates))
+	}
+	if updates[1].Total != 2019377376 || updates[1].Completed != 1009688688 {
+		t.Errorf("Unexpected download progress: %+v", updates[1])
+	}
+	if updates[5].Status != "success" {
+		t.Errorf("Expected final status 'success', got %q", updates[5].Status)
+	}
+}
+
+func TestOllamaProvider_PullModel_StreamedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+		fmt.Fprintln(w, `{"error":"pull model manifest: file does not exist"}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	err := p.PullModel(context.Background(), "no-such-model", nil)
+
+	pErr, ok := err.(*ProviderError)
+	if !ok {
+		t.Fatalf("Expected *ProviderError, got %T", err)
+	}
+	if pErr.Code != "model_not_found" {
+		t.Errorf("Expected error code 'model_not_found', got %q", pErr.Code)
+	}
+}
+
+func TestOllamaProvider_PullModel_IncompleteStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"status":"pulling manifest"}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	if err := p.PullModel(context.Background(), "llama3.2", nil); err == nil {
+		t.Fatal("Expected error when pull stream ends without success")
+	}
+}
+
+func TestOllamaProvider_SendMessage_GenerationOptions(t *testing.T) {
+	var received struct {
+		Options map[string]interface{} `json:"options"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, `{"model":"llama3.2","created_at":"2026-01-29T10:00:00Z","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":1,"eval_count":0}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	ch, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages: []Message{{Role: "user", Content: "Hello"}},
+		Model:    "llama3.2",
+		GenerationParams: GenerationParams{
+			Temperature:   float64Ptr(0.7),
+			TopK:          intPtr(30),
+			Seed:          int64Ptr(9),
+			StopSequences: []string{"###"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for range ch {
+	}
+
+	if received.Options["temperature"] != 0.7 {
+		t.Errorf("temperature = %v, want 0.7", received.Options["temperature"])
+	}
+	if received.Options["top_k"] != float64(30) {
+		t.Errorf("top_k = %v, want 30", received.Options["top_k"])
+	}
+	if received.Options["seed"] != float64(9) {
+		t.Errorf("seed = %v, want 9", received.Options["seed"])
+	}
+	if stops, ok := received.Options["stop"].([]interface{}); !ok || len(stops) != 1 || stops[0] != "###" {
+		t.Errorf("stop = %v, want [###]", received.Options["stop"])
+	}
+}
+
+func TestOllamaProvider_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embed" {
+			t.Errorf("Expected path /api/embed, got %s", r.URL.Path)
+		}
+		var body ollamaEmbedRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Model != "nomic-embed-text" || len(body.Input) != 2 {
+			t.Errorf("Unexpected request body: %+v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"model":"nomic-embed-text","embeddings":[[0.1,0.2],[0.3,0.4]],"prompt_eval_count":5}`)
+	}))
+	defer server.Close()
+
+	p := NewOllamaProvider(server.URL)
+	resp, err := p.Embed(context.Background(), EmbedRequest{Model: "nomic-embed-text", Input: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Embeddings) != 2 || resp.Embeddings[1][0] != float32(0.3) || resp.InputTokens != 5 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
+
+func TestOllamaProvider_Embed_ModelNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"model \"nomic-embed-text\" not found, try pulling it first"}`)
+	}))
+	defer server.Close()
+
+	_, err := NewOllamaProvider(server.URL).Embed(context.Background(), EmbedRequest{Model: "nomic-embed-text", Input: []string{"a"}})
+	pErr, ok := err.(*ProviderError)
+	if !ok || pErr.Code != "model_not_found" {
+		t.Errorf("Expected model_not_found error, got %v", err)
+	}
+}
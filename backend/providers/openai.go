@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/openai/openai-go/v3"
 	"github.com/openai/openai-go/v3/option"
@@ -12,28 +13,32 @@ import (
 // openaiModels is the hardcoded list of available OpenAI models.
 var openaiModels = []Model{
 	{
-		ID:        string(openai.ChatModelGPT4o),
-		Name:      "GPT-4o",
-		Provider:  "openai",
-		MaxTokens: 16384,
+		ID:         string(openai.ChatModelGPT4o),
+		Name:       "GPT-4o",
+		Provider:   "openai",
+		MaxTokens:  16384,
+		Parameters: openaiParameterCapabilities,
 	},
 	{
-		ID:        string(openai.ChatModelGPT4oMini),
-		Name:      "GPT-4o mini",
-		Provider:  "openai",
-		MaxTokens: 16384,
+		ID:         string(openai.ChatModelGPT4oMini),
+		Name:       "GPT-4o mini",
+		Provider:   "openai",
+		MaxTokens:  16384,
+		Parameters: openaiParameterCapabilities,
 	},
 	{
-		ID:        string(openai.ChatModelGPT4_1),
-		Name:      "GPT-4.1",
-		Provider:  "openai",
-		MaxTokens: 32768,
+		ID:         string(openai.ChatModelGPT4_1),
+		Name:       "GPT-4.1",
+		Provider:   "openai",
+		MaxTokens:  32768,
+		Parameters: openaiParameterCapabilities,
 	},
 	{
-		ID:        string(openai.ChatModelGPT4_1Mini),
-		Name:      "GPT-4.1 mini",
-		Provider:  "openai",
-		MaxTokens: 32768,
+		ID:         string(openai.ChatModelGPT4_1Mini),
+		Name:       "GPT-4.1 mini",
+		Provider:   "openai",
+		MaxTokens:  32768,
+		Parameters: openaiParameterCapabilities,
 	},
 }
 
@@ -50,6 +55,16 @@ func NewOpenAIProvider(apiKey string) *OpenAIProvider {
 	return &OpenAIProvider{client: &client}
 }
 
+// NewOpenAIProviderWithClient creates an OpenAIProvider whose SDK client sends requests through httpClient.
+// Used to route traffic through a cassette Recorder in tests.
+func NewOpenAIProviderWithClient(apiKey string, httpClient *http.Client) *OpenAIProvider {
+	client := openai.NewClient(
+		option.WithAPIKey(apiKey),
+		option.WithHTTPClient(httpClient),
+	)
+	return &OpenAIProvider{client: &client}
+}
+
 // ValidateCredentials checks if the API key is valid by sending a minimal request.
 func (p *OpenAIProvider) ValidateCredentials(ctx context.Context) error {
 	_, err := p.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
@@ -95,6 +110,10 @@ func (p *OpenAIProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 		}
 	}
 
+	if err := req.GenerationParams.Validate(capabilitiesFor(openaiModels, req.Model, openaiParameterCapabilities), req.Model); err != nil {
+		return nil, err
+	}
+
 	params := openai.ChatCompletionNewParams{
 		Model:     openai.ChatModel(req.Model),
 		Messages:  messages,
@@ -103,6 +122,7 @@ func (p *OpenAIProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 			IncludeUsage: openai.Bool(true),
 		},
 	}
+	applyOpenAIGenerationParams(&params, req.GenerationParams)
 
 	stream := p.client.Chat.Completions.NewStreaming(ctx, params)
 
@@ -158,10 +178,7 @@ func (p *OpenAIProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 				if !send(StreamChunk{
 					Type:      "end",
 					MessageID: messageID,
-					Usage: &UsageStats{
-						InputTokens:  int(chunk.Usage.PromptTokens),
-						OutputTokens: int(chunk.Usage.CompletionTokens),
-					},
+					Usage:     openaiUsageStats(chunk.Usage),
 				}) {
 					return
 				}
@@ -186,6 +203,76 @@ func (p *OpenAIProvider) SendMessage(ctx context.Context, req ChatRequest) (<-ch
 	return ch, nil
 }
 
+// Embed returns embeddings for req.Input via the embeddings API.
+func (p *OpenAIProvider) Embed(ctx context.Context, req EmbedRequest) (*EmbedResponse, error) {
+	if len(req.Input) == 0 {
+		return emptyEmbedResponse(req.Model), nil
+	}
+
+	resp, err := p.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Model:          openai.EmbeddingModel(req.Model),
+		Input:          openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: req.Input},
+		EncodingFormat: openai.EmbeddingNewParamsEncodingFormatFloat,
+	})
+	if err != nil {
+		return nil, mapOpenAIProviderError(err)
+	}
+
+	embeddings := make([][]float32, len(req.Input))
+	for _, data := range resp.Data {
+		if data.Index < 0 || int(data.Index) >= len(embeddings) {
+			continue
+		}
+		vector := make([]float32, len(data.Embedding))
+		for i, v := range data.Embedding {
+			vector[i] = float32(v)
+		}
+		embeddings[data.Index] = vector
+	}
+	for _, vector := range embeddings {
+		if vector == nil {
+			return nil, &ProviderError{
+				Code:        "provider_error",
+				Message:     "embedding response is missing inputs",
+				UserMessage: "OpenAI returned an incomplete embedding response. Please try again.",
+			}
+		}
+	}
+
+	return &EmbedResponse{
+		Model:       resp.Model,
+		Embeddings:  embeddings,
+		InputTokens: int(resp.Usage.PromptTokens),
+	}, nil
+}
+
+// applyOpenAIGenerationParams copies the set generation parameters onto the SDK request.
+func applyOpenAIGenerationParams(params *openai.ChatCompletionNewParams, g GenerationParams) {
+	if g.Temperature != nil {
+		params.Temperature = openai.Float(*g.Temperature)
+	}
+	if g.TopP != nil {
+		params.TopP = openai.Float(*g.TopP)
+	}
+	if g.Seed != nil {
+		params.Seed = openai.Int(*g.Seed)
+	}
+	if len(g.StopSequences) > 0 {
+		params.Stop = openai.ChatCompletionNewParamsStopUnion{OfStringArray: g.StopSequences}
+	}
+}
+
+// openaiUsageStats converts OpenAI usage, whose prompt token count includes tokens served
+// from the automatic prompt cache, into UsageStats where cached input is reported separately.
+func openaiUsageStats(usage openai.CompletionUsage) *UsageStats {
+	cached := int(usage.PromptTokensDetails.CachedTokens)
+	return &UsageStats{
+		InputTokens:     int(usage.PromptTokens) - cached,
+		OutputTokens:    int(usage.CompletionTokens),
+		CacheReadTokens: cached,
+	}
+}
+
 // mapOpenAIProviderError converts OpenAI SDK errors to user-friendly ProviderError values.
 // API keys must never appear in the returned error messages (NFR6).
 func mapOpenAIProviderError(err error) *ProviderError {
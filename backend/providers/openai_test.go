@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -512,3 +513,132 @@ func TestOpenAIProvider_MapProviderError_AllStatusCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAIProvider_SendMessage_GenerationParams(t *testing.T) {
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := io.ReadAll(r.Body)
+		receivedBody = string(buf)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	p := newTestOpenAIProvider(server.URL)
+	ch, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages:  []Message{{Role: "user", Content: "Hello"}},
+		Model:     "gpt-4o",
+		MaxTokens: 100,
+		GenerationParams: GenerationParams{
+			Temperature:   float64Ptr(1.5),
+			TopP:          float64Ptr(0.8),
+			Seed:          int64Ptr(42),
+			StopSequences: []string{"END"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for range ch {
+	}
+
+	for _, want := range []string{`"temperature":1.5`, `"top_p":0.8`, `"seed":42`, `"stop":["END"]`} {
+		if !strings.Contains(receivedBody, want) {
+			t.Errorf("Expected %s in request body, got: %s", want, receivedBody)
+		}
+	}
+}
+
+func TestOpenAIProvider_SendMessage_RejectsTopK(t *testing.T) {
+	p := newTestOpenAIProvider("http://127.0.0.1:1")
+	_, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages:         []Message{{Role: "user", Content: "Hello"}},
+		Model:            "gpt-4o",
+		MaxTokens:        100,
+		GenerationParams: GenerationParams{TopK: intPtr(40)},
+	})
+	pErr, ok := err.(*ProviderError)
+	if !ok {
+		t.Fatalf("Expected *ProviderError, got %T (%v)", err, err)
+	}
+	if pErr.Code != "invalid_parameters" {
+		t.Errorf("Expected code 'invalid_parameters', got %q", pErr.Code)
+	}
+}
+
+func TestOpenAIProvider_SendMessage_ReportsCachedTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `data: {"id":"chatcmpl-cache","object":"chat.completion.chunk","created":1234567890,"model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":"Hi"},"finish_reason":null}]}`+"\n\n")
+		fmt.Fprint(w, `data: {"id":"chatcmpl-cache","object":"chat.completion.chunk","created":1234567890,"model":"gpt-4o","choices":[],"usage":{"prompt_tokens":2100,"completion_tokens":3,"total_tokens":2103,"prompt_tokens_details":{"cached_tokens":1920}}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	p := newTestOpenAIProvider(server.URL)
+	ch, err := p.SendMessage(context.Background(), ChatRequest{
+		Messages:    []Message{{Role: "user", Content: "Hello"}},
+		Model:       "gpt-4o",
+		MaxTokens:   100,
+		CachePrompt: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var usage *UsageStats
+	for chunk := range ch {
+		if chunk.Type == "end" {
+			usage = chunk.Usage
+		}
+	}
+
+	if usage == nil {
+		t.Fatal("Expected usage on end chunk")
+	}
+	if usage.InputTokens != 180 {
+		t.Errorf("InputTokens = %d, want 180 (prompt minus cached)", usage.InputTokens)
+	}
+	if usage.CacheReadTokens != 1920 {
+		t.Errorf("CacheReadTokens = %d, want 1920", usage.CacheReadTokens)
+	}
+	if usage.CacheWriteTokens != 0 {
+		t.Errorf("CacheWriteTokens = %d, want 0", usage.CacheWriteTokens)
+	}
+}
+
+func TestOpenAIProvider_Embed(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			t.Errorf("Expected path /embeddings, got %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		// Out-of-order data must be placed by index
+		fmt.Fprint(w, `{"object":"list","model":"text-embedding-3-small","data":[
+			{"object":"embedding","index":1,"embedding":[0.0,1.0]},
+			{"object":"embedding","index":0,"embedding":[1.0,0.0]}
+		],"usage":{"prompt_tokens":6,"total_tokens":6}}`)
+	}))
+	defer server.Close()
+
+	p := newTestOpenAIProvider(server.URL)
+	resp, err := p.Embed(context.Background(), EmbedRequest{Model: "text-embedding-3-small", Input: []string{"first", "second"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if body["encoding_format"] != "float" {
+		t.Errorf("Expected float encoding, got %v", body["encoding_format"])
+	}
+	if len(resp.Embeddings) != 2 || resp.Embeddings[0][0] != 1 || resp.Embeddings[1][1] != 1 {
+		t.Errorf("Embeddings not ordered by index: %v", resp.Embeddings)
+	}
+	if resp.InputTokens != 6 {
+		t.Errorf("InputTokens = %d, want 6", resp.InputTokens)
+	}
+}
@@ -20,6 +20,11 @@ type ChatRequest struct {
 	Model        string    `json:"model"`
 	MaxTokens    int       `json:"max_tokens"`
 	SystemPrompt string    `json:"system_prompt,omitempty"`
+	// CachePrompt asks providers with explicit prompt caching to cache the system prompt
+	// and conversation prefix so the next turn can reuse them. Providers that cache
+	// automatically (OpenAI) or not at all (Ollama) ignore it.
+	CachePrompt bool `json:"cache_prompt,omitempty"`
+	GenerationParams
 }
 
 // StreamChunk represents a single chunk in a streaming response.
@@ -32,23 +37,31 @@ type StreamChunk struct {
 }
 
 // UsageStats contains token usage information.
+// InputTokens counts only uncached input; tokens served from or written to the
+// provider's prompt cache are reported separately.
 type UsageStats struct {
-	InputTokens  int `json:"input_tokens"`
-	OutputTokens int `json:"output_tokens"`
+	InputTokens      int `json:"input_tokens"`
+	OutputTokens     int `json:"output_tokens"`
+	CacheReadTokens  int `json:"cache_read_tokens,omitempty"`
+	CacheWriteTokens int `json:"cache_write_tokens,omitempty"`
 }
 
 // Model represents an available LLM model.
 type Model struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Provider  string `json:"provider"`
-	MaxTokens int    `json:"max_tokens"`
+	ID         string                `json:"id"`
+	Name       string                `json:"name"`
+	Provider   string                `json:"provider"`
+	MaxTokens  int                   `json:"max_tokens"`
+	Parameters ParameterCapabilities `json:"parameters"`
 }
 
 // Message represents a single message in a conversation.
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+	// CacheBreakpoint marks the end of a stable prefix (e.g. injected PRD or architecture
+	// context) that providers with explicit prompt caching should cache.
+	CacheBreakpoint bool `json:"cache_breakpoint,omitempty"`
 }
 
 // ProviderError is a structured error from provider operations.
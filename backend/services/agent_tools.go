@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"bmad-studio/backend/types"
+)
+
+// ToolRegistryError represents a structured error from tool lookup or input validation
+type ToolRegistryError struct {
+	Code    string
+	Message string
+}
+
+func (e *ToolRegistryError) Error() string {
+	return e.Message
+}
+
+// Error codes for tool registry
+const (
+	ErrCodeToolNotFound     = "tool_not_found"
+	ErrCodeInvalidToolInput = "invalid_tool_input"
+)
+
+// AgentTool is a capability agents can call by name with JSON input.
+type AgentTool interface {
+	Definition() types.ToolDefinition
+	// Invoke runs the tool. apiKey authenticates with any provider the tool calls.
+	Invoke(ctx context.Context, input json.RawMessage, apiKey string) (*types.ToolResult, error)
+}
+
+// ToolRegistry holds the tools available to agents, keyed by name.
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools map[string]AgentTool
+}
+
+// NewToolRegistry creates an empty ToolRegistry.
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{tools: make(map[string]AgentTool)}
+}
+
+// Register adds a tool, replacing any tool with the same name.
+func (r *ToolRegistry) Register(tool AgentTool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[tool.Definition().Name] = tool
+}
+
+// Definitions returns the definitions of all registered tools, sorted by name.
+func (r *ToolRegistry) Definitions() []types.ToolDefinition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]types.ToolDefinition, 0, len(r.tools))
+	for _, tool := range r.tools {
+		defs = append(defs, tool.Definition())
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// Invoke runs the named tool with the given input.
+func (r *ToolRegistry) Invoke(ctx context.Context, name string, input json.RawMessage, apiKey string) (*types.ToolResult, error) {
+	r.mu.RLock()
+	tool, ok := r.tools[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, &ToolRegistryError{Code: ErrCodeToolNotFound, Message: fmt.Sprintf("Tool not found: %s", name)}
+	}
+	return tool.Invoke(ctx, input, apiKey)
+}
+
+// searchProjectDocsTool exposes semantic retrieval over project artifacts to agents.
+type searchProjectDocsTool struct {
+	retrieval *RetrievalService
+}
+
+// searchProjectDocsInput is the input accepted by the search_project_docs tool.
+type searchProjectDocsInput struct {
+	Query        string `json:"query"`
+	TopK         int    `json:"top_k,omitempty"`
+	ArtifactType string `json:"artifact_type,omitempty"`
+}
+
+// NewRetrievalTool creates the search_project_docs tool backed by rs.
+func NewRetrievalTool(rs *RetrievalService) AgentTool {
+	return &searchProjectDocsTool{retrieval: rs}
+}
+
+// Definition describes the tool and its input schema.
+func (t *searchProjectDocsTool) Definition() types.ToolDefinition {
+	return types.ToolDefinition{
+		Name: "search_project_docs",
+		Description: "Find the passages of this project's planning documents (product brief, PRD, architecture, " +
+			"UX design, epics, stories) most relevant to a question. Use it before answering questions about " +
+			"decisions or requirements already written down.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "What to look for, phrased as a question or topic",
+				},
+				"top_k": map[string]interface{}{
+					"type":        "integer",
+					"description": fmt.Sprintf("Number of passages to return (default %d, max %d)", defaultTopK, maxTopK),
+				},
+				"artifact_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Restrict to one artifact type, e.g. prd or architecture",
+				},
+			},
+			"required": []string{"query"},
+		},
+	}
+}
+
+// Invoke runs a retrieval query and formats the passages with their sources.
+func (t *searchProjectDocsTool) Invoke(ctx context.Context, input json.RawMessage, apiKey string) (*types.ToolResult, error) {
+	var in searchProjectDocsInput
+	if err := json.Unmarshal(input, &in); err != nil || strings.TrimSpace(in.Query) == "" {
+		return nil, &ToolRegistryError{Code: ErrCodeInvalidToolInput, Message: "search_project_docs requires a non-empty query"}
+	}
+
+	resp, err := t.retrieval.Retrieve(ctx, RetrievalQuery{
+		Query:        in.Query,
+		TopK:         in.TopK,
+		ArtifactType: in.ArtifactType,
+		APIKey:       apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	if len(resp.Results) == 0 {
+		content.WriteString("No matching passages found in the project documents.")
+	}
+	for i, result := range resp.Results {
+		source := strings.Join(append([]string{result.ArtifactName}, result.HeadingPath...), " > ")
+		fmt.Fprintf(&content, "[%d] %s (%s)\n%s\n\n", i+1, source, result.Path, result.Text)
+	}
+
+	return &types.ToolResult{
+		Tool:    "search_project_docs",
+		Content: strings.TrimSpace(content.String()),
+		Data:    resp,
+	}, nil
+}
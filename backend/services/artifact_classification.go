@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// classificationFile is the project's custom classification rules, relative to _bmad
+const classificationFile = "artifact-types.yaml"
+
+// bmadPhaseNames are the BMAD phase names by number
+var bmadPhaseNames = map[int]string{
+	0: "Meta",
+	1: "Analysis",
+	2: "Planning",
+	3: "Solutioning",
+	4: "Implementation",
+}
+
+// builtinArtifactTypes are the types the built-in classification can produce
+var builtinArtifactTypes = map[string]bool{
+	types.ArtifactTypePRD:              true,
+	types.ArtifactTypeArchitecture:     true,
+	types.ArtifactTypeEpics:            true,
+	types.ArtifactTypeStories:          true,
+	types.ArtifactTypeUXDesign:         true,
+	types.ArtifactTypeResearch:         true,
+	types.ArtifactTypeBrainstorming:    true,
+	types.ArtifactTypeProductBrief:     true,
+	types.ArtifactTypeValidationReport: true,
+	types.ArtifactTypeProjectContext:   true,
+	types.ArtifactTypeOther:            true,
+}
+
+// artifactPhase is the phase an artifact type belongs to.
+type artifactPhase struct {
+	number int
+	name   string
+}
+
+// classificationRules are a project's custom artifact types and the rules that assign them.
+type classificationRules struct {
+	phases         map[string]artifactPhase
+	rules          []types.ArtifactClassificationRule
+	useFrontmatter bool   // Whether any rule needs the decoded frontmatter
+	hash           string // Content hash of the rules file
+}
+
+// loadClassificationRules reads _bmad/artifact-types.yaml. It returns nil without an error
+// when the project has no such file. Invalid types and rules are skipped with a warning
+// so one mistake does not disable the rest of the file.
+func loadClassificationRules(projectRoot string) (*classificationRules, error) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, "_bmad", classificationFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var config types.ArtifactClassificationConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", classificationFile, err)
+	}
+
+	r := &classificationRules{phases: make(map[string]artifactPhase), hash: contentHash(data)}
+	for _, t := range config.Types {
+		id := strings.TrimSpace(t.ID)
+		if id == "" {
+			log.Printf("Warning: Skipping artifact type without an id in %s", classificationFile)
+			continue
+		}
+		if _, ok := bmadPhaseNames[t.Phase]; !ok {
+			log.Printf("Warning: Skipping artifact type %s in %s: phase %d is not 0-4", id, classificationFile, t.Phase)
+			continue
+		}
+		name := t.PhaseName
+		if name == "" {
+			name = bmadPhaseNames[t.Phase]
+		}
+		r.phases[id] = artifactPhase{number: t.Phase, name: name}
+	}
+
+	for i, rule := range config.Rules {
+		if _, custom := r.phases[rule.Type]; !custom && !builtinArtifactTypes[rule.Type] {
+			log.Printf("Warning: Skipping rule %d in %s: unknown artifact type %q", i+1, classificationFile, rule.Type)
+			continue
+		}
+		if len(rule.Globs) == 0 && len(rule.Frontmatter) == 0 {
+			log.Printf("Warning: Skipping rule %d in %s: it needs globs or frontmatter", i+1, classificationFile)
+			continue
+		}
+		if len(rule.Frontmatter) > 0 {
+			r.useFrontmatter = true
+		}
+		r.rules = append(r.rules, rule)
+	}
+	return r, nil
+}
+
+// fingerprint identifies the rules file a classification was made with; empty when there are no rules.
+func (r *classificationRules) fingerprint() string {
+	if r == nil {
+		return ""
+	}
+	return r.hash
+}
+
+// classify returns the type of the first rule the artifact matches, or "" if none match.
+func (r *classificationRules) classify(relPath string, content []byte) string {
+	var fields map[string]interface{}
+	if r.useFrontmatter {
+		if raw, _ := splitFrontmatter(content); raw != nil {
+			_ = yaml.Unmarshal(raw, &fields) // Invalid frontmatter simply matches no conditions
+		}
+	}
+
+	for _, rule := range r.rules {
+		if len(rule.Globs) > 0 && !anyGlobMatches(rule.Globs, relPath) {
+			continue
+		}
+		if frontmatterMatches(rule.Frontmatter, fields) {
+			return rule.Type
+		}
+	}
+	return ""
+}
+
+// phaseInfo returns the phase configured for an artifact type, if any.
+func (r *classificationRules) phaseInfo(artifactType string) (int, string, bool) {
+	p, ok := r.phases[artifactType]
+	return p.number, p.name, ok
+}
+
+func anyGlobMatches(globs []string, relPath string) bool {
+	for _, g := range globs {
+		if matchGlob(g, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash-separated path against a pattern in which "**" matches any number
+// of directories and other segments use path.Match syntax. Patterns without "/" match the file name.
+func matchGlob(pattern, relPath string) bool {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "/")
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(path.Base(relPath)))
+		return ok
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+func matchGlobSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchGlobSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(strings.ToLower(pattern[0]), strings.ToLower(segments[0])); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// frontmatterMatches reports whether every condition holds for the decoded frontmatter.
+func frontmatterMatches(conditions, fields map[string]interface{}) bool {
+	for key, want := range conditions {
+		got, ok := fields[key]
+		if !ok {
+			return false
+		}
+		accepted, isList := want.([]interface{})
+		if !isList {
+			accepted = []interface{}{want}
+		}
+		matched := false
+		for _, a := range accepted {
+			if strings.EqualFold(fmt.Sprint(a), fmt.Sprint(got)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"**/adr/*.md", "_bmad-output/planning-artifacts/adr/0001-use-go.md", true},
+		{"**/adr/*.md", "adr/0001-use-go.md", true},
+		{"**/adr/*.md", "_bmad-output/adr/nested/0001.md", false},
+		{"_bmad-output/**/runbook-*.md", "_bmad-output/ops/deep/runbook-deploy.md", true},
+		{"_bmad-output/**/runbook-*.md", "docs/runbook-deploy.md", false},
+		{"threat-model*.md", "_bmad-output/planning-artifacts/Threat-Model-API.md", true},
+		{"threat-model*.md", "_bmad-output/planning-artifacts/model.md", false},
+		{"_bmad-output/*.md", "_bmad-output/notes.md", true},
+		{"_bmad-output/*.md", "_bmad-output/planning-artifacts/notes.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.path); got != tt.expected {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.expected)
+		}
+	}
+}
+
+const classificationTestRules = `types:
+  - id: adr
+    phase: 3
+  - id: runbook
+    phase: 4
+    phase_name: Operations
+  - id: threat_model
+    phase: 3
+  - id: prd
+    phase: 1
+  - id: bad_phase
+    phase: 9
+rules:
+  - type: adr
+    globs: ["**/adr/*.md"]
+  - type: runbook
+    frontmatter:
+      docType: [runbook, playbook]
+  - type: threat_model
+    globs: ["threat-model*.md"]
+    frontmatter:
+      reviewed: true
+  - type: undeclared
+    globs: ["*.md"]
+`
+
+// setupClassificationTest writes the rules file and the given artifacts, then loads them.
+func setupClassificationTest(t *testing.T, rules string, files map[string]string) *ArtifactService {
+	t.Helper()
+	configService, tmpDir := setupArtifactTestConfig(t)
+	if rules != "" {
+		if err := os.WriteFile(filepath.Join(tmpDir, "_bmad", classificationFile), []byte(rules), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for rel, content := range files {
+		path := filepath.Join(tmpDir, "_bmad-output", filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.LoadArtifacts(); err != nil {
+		t.Fatalf("LoadArtifacts error: %v", err)
+	}
+	return svc
+}
+
+func TestClassificationRules_AssignCustomTypesAndPhases(t *testing.T) {
+	svc := setupClassificationTest(t, classificationTestRules, map[string]string{
+		"planning-artifacts/adr/0001-use-go.md":         "# Use Go\n",
+		"ops/deploy.md":                                 "---\ndocType: Playbook\n---\n# Deploy\n",
+		"planning-artifacts/threat-model-api.md":        "---\nreviewed: true\n---\n# Threats\n",
+		"planning-artifacts/threat-model-draft.md":      "# Draft threats\n",
+		"planning-artifacts/prd.md":                     "# PRD\n",
+		"planning-artifacts/ux-design-specification.md": "# UX\n",
+	})
+
+	tests := []struct {
+		id        string
+		wantType  string
+		wantPhase int
+		wantName  string
+	}{
+		{"_bmad-output-planning-artifacts-adr-0001-use-go", "adr", 3, "Solutioning"},
+		{"_bmad-output-ops-deploy", "runbook", 4, "Operations"},
+		{"_bmad-output-planning-artifacts-threat-model-api", "threat_model", 3, "Solutioning"},
+		{"_bmad-output-planning-artifacts-threat-model-draft", types.ArtifactTypeOther, 0, "Meta"},
+		{"_bmad-output-planning-artifacts-prd", types.ArtifactTypePRD, 1, "Analysis"},
+		{"_bmad-output-planning-artifacts-ux-design-specification", types.ArtifactTypeUXDesign, 2, "Planning"},
+	}
+	for _, tt := range tests {
+		artifact, err := svc.GetArtifact(tt.id)
+		if err != nil {
+			t.Errorf("GetArtifact(%s) error: %v", tt.id, err)
+			continue
+		}
+		if artifact.Type != tt.wantType || artifact.Phase != tt.wantPhase || artifact.PhaseName != tt.wantName {
+			t.Errorf("%s: got %s phase %d %s, want %s phase %d %s", tt.id,
+				artifact.Type, artifact.Phase, artifact.PhaseName, tt.wantType, tt.wantPhase, tt.wantName)
+		}
+	}
+}
+
+func TestClassificationRules_SkipsInvalidEntries(t *testing.T) {
+	_, tmpDir := setupArtifactTestConfig(t)
+	if err := os.WriteFile(filepath.Join(tmpDir, "_bmad", classificationFile), []byte(classificationTestRules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := loadClassificationRules(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := rules.phaseInfo("bad_phase"); ok {
+		t.Error("Expected the type with an out-of-range phase to be skipped")
+	}
+	if len(rules.rules) != 3 {
+		t.Errorf("Expected the rule for an undeclared type to be skipped, got %d rules", len(rules.rules))
+	}
+}
+
+func TestClassificationRules_InvalidFileFallsBackToDefaults(t *testing.T) {
+	svc := setupClassificationTest(t, "rules: [type: adr", map[string]string{
+		"planning-artifacts/adr/0001-use-go.md": "# Use Go\n",
+		"planning-artifacts/prd.md":             "# PRD\n",
+	})
+
+	artifacts, err := svc.GetArtifacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range artifacts {
+		want := types.ArtifactTypeOther
+		if a.ID == "_bmad-output-planning-artifacts-prd" {
+			want = types.ArtifactTypePRD
+		}
+		if a.Type != want {
+			t.Errorf("%s: expected built-in type %s, got %s", a.ID, want, a.Type)
+		}
+	}
+}
+
+func TestClassificationRules_AbsentFile(t *testing.T) {
+	rules, err := loadClassificationRules(t.TempDir())
+	if rules != nil || err != nil {
+		t.Errorf("Expected no rules and no error without a file, got %+v, %v", rules, err)
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outlineEntry is a section of the outline with the body line range it covers.
+type outlineEntry struct {
+	section types.ArtifactSection
+	start   int // 0-based body line of the heading
+	end     int // 0-based body line after the last line of the section and its subsections
+}
+
+// GetRawContent returns the markdown file of an artifact exactly as stored on disk.
+func (s *ArtifactService) GetRawContent(id string) ([]byte, error) {
+	artifact, err := s.GetArtifact(id)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.ReadContent(artifact)
+	if err != nil {
+		if _, ok := err.(*ArtifactServiceError); ok {
+			return nil, err
+		}
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactReadFailed,
+			Message: fmt.Sprintf("Failed to read artifact %s: %v", id, err),
+		}
+	}
+	return content, nil
+}
+
+// GetFrontmatter returns an artifact's full YAML frontmatter converted to JSON-compatible values.
+// Artifacts without frontmatter return an empty map.
+func (s *ArtifactService) GetFrontmatter(id string) (*types.ArtifactFrontmatterResponse, error) {
+	content, err := s.GetRawContent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	raw, _ := splitFrontmatter(content)
+	if raw != nil {
+		if err := yaml.Unmarshal(raw, &fields); err != nil {
+			return nil, &ArtifactServiceError{
+				Code:    ErrCodeInvalidFrontmatter,
+				Message: fmt.Sprintf("Invalid frontmatter in artifact %s: %v", id, err),
+			}
+		}
+		if fields == nil {
+			fields = map[string]interface{}{}
+		}
+	}
+
+	return &types.ArtifactFrontmatterResponse{
+		ArtifactID:  id,
+		Frontmatter: jsonCompatible(fields).(map[string]interface{}),
+	}, nil
+}
+
+// GetOutline returns the heading outline of an artifact's markdown body.
+func (s *ArtifactService) GetOutline(id string) (*types.ArtifactOutlineResponse, error) {
+	content, err := s.GetRawContent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, _ := buildOutline(content)
+	sections := make([]types.ArtifactSection, 0, len(entries))
+	for _, e := range entries {
+		sections = append(sections, e.section)
+	}
+	return &types.ArtifactOutlineResponse{ArtifactID: id, Sections: sections}, nil
+}
+
+// GetSection returns one section of an artifact, including its heading line and all subsections.
+func (s *ArtifactService) GetSection(id, sectionID string) (*types.ArtifactSectionResponse, error) {
+	content, err := s.GetRawContent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, lines := buildOutline(content)
+	for _, e := range entries {
+		if e.section.ID != sectionID {
+			continue
+		}
+		text := strings.TrimRight(strings.Join(lines[e.start:e.end], "\n"), " \t\n")
+		return &types.ArtifactSectionResponse{
+			ArtifactSection: e.section,
+			ArtifactID:      id,
+			Content:         text + "\n",
+		}, nil
+	}
+
+	return nil, &ArtifactServiceError{
+		Code:    ErrCodeSectionNotFound,
+		Message: fmt.Sprintf("Section not found in artifact %s: %s", id, sectionID),
+	}
+}
+
+// buildOutline parses the headings of a markdown file into outline entries.
+// It also returns the body lines the entries' ranges index into.
+func buildOutline(content []byte) ([]outlineEntry, []string) {
+	_, body := splitFrontmatter(content)
+	lineOffset := bytes.Count(content[:len(content)-len(body)], []byte("\n"))
+	lines := strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+
+	var entries []outlineEntry
+	used := make(map[string]bool)
+	var stack []int // Indexes into entries of the enclosing headings
+
+	for _, sec := range splitMarkdownSections(string(body)) {
+		if sec.Level == 0 {
+			continue // Preamble text has no heading to address it by
+		}
+
+		for len(stack) > 0 && entries[stack[len(stack)-1]].section.Level >= sec.Level {
+			stack = stack[:len(stack)-1]
+		}
+		var parentID *string
+		if len(stack) > 0 {
+			id := entries[stack[len(stack)-1]].section.ID
+			parentID = &id
+		}
+
+		entries = append(entries, outlineEntry{
+			section: types.ArtifactSection{
+				ID:       uniqueSectionID(sectionSlug(sec.Heading), used),
+				Heading:  sec.Heading,
+				Level:    sec.Level,
+				Line:     lineOffset + sec.Line,
+				ParentID: parentID,
+			},
+			start: sec.Line - 1,
+			end:   len(lines),
+		})
+		stack = append(stack, len(entries)-1)
+	}
+
+	// A section ends where the next heading of the same or a higher level begins
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			if entries[j].section.Level <= entries[i].section.Level {
+				entries[i].end = entries[j].start
+				break
+			}
+		}
+	}
+
+	return entries, lines
+}
+
+// sectionSlug turns a heading into a URL-safe ID the way GitHub anchors headings:
+// lowercased, punctuation dropped, whitespace replaced by hyphens.
+func sectionSlug(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "section"
+	}
+	return b.String()
+}
+
+// uniqueSectionID suffixes repeated slugs with -1, -2, ... and records the result in used.
+func uniqueSectionID(slug string, used map[string]bool) string {
+	id := slug
+	for n := 1; used[id]; n++ {
+		id = slug + "-" + strconv.Itoa(n)
+	}
+	used[id] = true
+	return id
+}
+
+// jsonCompatible converts decoded YAML values so they marshal to JSON as written:
+// dates without a time stay as "2006-01-02" rather than a full timestamp.
+func jsonCompatible(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = jsonCompatible(item)
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = jsonCompatible(item)
+		}
+		return val
+	case time.Time:
+		if val.Hour() == 0 && val.Minute() == 0 && val.Second() == 0 && val.Nanosecond() == 0 {
+			return val.Format("2006-01-02")
+		}
+		return val.Format(time.RFC3339)
+	default:
+		return v
+	}
+}
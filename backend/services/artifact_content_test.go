@@ -0,0 +1,169 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const outlineTestDoc = `---
+status: complete
+completedAt: 2026-01-27
+stepsCompleted: [1, 2]
+meta:
+  owner: pm
+---
+Intro text before any heading.
+
+# Product Requirements
+
+## Goals
+
+Ship it.
+
+### Success Metrics
+
+Adoption.
+
+## Goals
+
+Second goals section.
+
+` + "```md\n# Not a heading\n```" + `
+
+# Appendix: Q&A!
+`
+
+func setupContentTest(t *testing.T, content string) (*ArtifactService, string) {
+	t.Helper()
+	configService, tmpDir := setupArtifactTestConfig(t)
+	planningDir := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts")
+	if err := os.MkdirAll(planningDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(planningDir, "prd.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.LoadArtifacts(); err != nil {
+		t.Fatalf("LoadArtifacts error: %v", err)
+	}
+	return svc, "_bmad-output-planning-artifacts-prd"
+}
+
+func TestGetRawContent_ReturnsFileUnmodified(t *testing.T) {
+	svc, id := setupContentTest(t, outlineTestDoc)
+
+	content, err := svc.GetRawContent(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != outlineTestDoc {
+		t.Errorf("Raw content differs from the file:\n%s", content)
+	}
+}
+
+func TestGetFrontmatter_ConvertsToJSONValues(t *testing.T) {
+	svc, id := setupContentTest(t, outlineTestDoc)
+
+	resp, err := svc.GetFrontmatter(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fm := resp.Frontmatter
+	if fm["status"] != "complete" {
+		t.Errorf("status = %v, want complete", fm["status"])
+	}
+	if fm["completedAt"] != "2026-01-27" {
+		t.Errorf("completedAt = %v, want the date as written", fm["completedAt"])
+	}
+	if meta, ok := fm["meta"].(map[string]interface{}); !ok || meta["owner"] != "pm" {
+		t.Errorf("Expected nested map, got %#v", fm["meta"])
+	}
+}
+
+func TestGetFrontmatter_EmptyWithoutBlock(t *testing.T) {
+	svc, id := setupContentTest(t, "# Product Requirements\n")
+
+	resp, err := svc.GetFrontmatter(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Frontmatter == nil || len(resp.Frontmatter) != 0 {
+		t.Errorf("Expected an empty map, got %#v", resp.Frontmatter)
+	}
+}
+
+func TestGetOutline_IDsLinesAndParents(t *testing.T) {
+	svc, id := setupContentTest(t, outlineTestDoc)
+
+	resp, err := svc.GetOutline(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, s := range resp.Sections {
+		ids = append(ids, s.ID)
+	}
+	want := "product-requirements,goals,success-metrics,goals-1,appendix-qa"
+	if strings.Join(ids, ",") != want {
+		t.Fatalf("Section IDs = %v, want %s", ids, want)
+	}
+
+	first := resp.Sections[0]
+	if first.Line != 10 || first.Level != 1 || first.ParentID != nil {
+		t.Errorf("Unexpected first section %+v", first)
+	}
+	metrics := resp.Sections[2]
+	if metrics.ParentID == nil || *metrics.ParentID != "goals" {
+		t.Errorf("Expected success-metrics under goals, got %v", metrics.ParentID)
+	}
+}
+
+func TestGetSection_IncludesSubsections(t *testing.T) {
+	svc, id := setupContentTest(t, outlineTestDoc)
+
+	section, err := svc.GetSection(id, "goals")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "## Goals\n\nShip it.\n\n### Success Metrics\n\nAdoption.\n"
+	if section.Content != want {
+		t.Errorf("Content = %q, want %q", section.Content, want)
+	}
+
+	second, err := svc.GetSection(id, "goals-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(second.Content, "# Not a heading") || strings.Contains(second.Content, "Appendix") {
+		t.Errorf("Expected the fenced block and no following section, got %q", second.Content)
+	}
+}
+
+func TestGetSection_NotFound(t *testing.T) {
+	svc, id := setupContentTest(t, outlineTestDoc)
+
+	_, err := svc.GetSection(id, "missing")
+	svcErr, ok := err.(*ArtifactServiceError)
+	if !ok || svcErr.Code != ErrCodeSectionNotFound {
+		t.Errorf("Expected section_not_found, got %v", err)
+	}
+}
+
+func TestSectionSlug(t *testing.T) {
+	tests := map[string]string{
+		"Non-Functional Requirements": "non-functional-requirements",
+		"1.2 Scope & Goals":           "12-scope--goals",
+		"Über Café":                   "über-café",
+		"!!!":                         "section",
+	}
+	for heading, want := range tests {
+		if got := sectionSlug(heading); got != want {
+			t.Errorf("sectionSlug(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"bmad-studio/backend/types"
+)
+
+// ArtifactGraphService builds the dependency graph between artifacts from their inputDocuments
+// frontmatter. The graph is derived from the artifact registry on every call, so it always
+// reflects the latest watcher updates without keeping state of its own.
+type ArtifactGraphService struct {
+	configService   *BMadConfigService
+	artifactService *ArtifactService
+}
+
+// NewArtifactGraphService creates a new ArtifactGraphService.
+func NewArtifactGraphService(configService *BMadConfigService, artifactService *ArtifactService) *ArtifactGraphService {
+	return &ArtifactGraphService{
+		configService:   configService,
+		artifactService: artifactService,
+	}
+}
+
+// Graph resolves every artifact's inputDocuments to artifact IDs and returns the resulting
+// nodes, edges and unresolved references. A node is potentially stale when any artifact
+// upstream of it, directly or transitively, was modified after it.
+func (s *ArtifactGraphService) Graph() (*types.ArtifactGraphResponse, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+	artifacts, err := s.artifactService.GetArtifacts()
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := newInputDocumentResolver(config, artifacts)
+	graph := &types.ArtifactGraphResponse{
+		Nodes:    make([]types.ArtifactGraphNode, 0, len(artifacts)),
+		Edges:    []types.ArtifactGraphEdge{},
+		Dangling: []types.DanglingReference{},
+	}
+
+	upstream := make(map[string][]string) // Artifact ID -> IDs of its inputs
+	for _, a := range artifacts {
+		seen := make(map[string]bool)
+		for _, ref := range a.InputDocuments {
+			if strings.TrimSpace(ref) == "" {
+				continue
+			}
+			id, exists := resolver.resolve(ref, a.Path)
+			if id == "" {
+				graph.Dangling = append(graph.Dangling, types.DanglingReference{
+					ArtifactID: a.ID,
+					Reference:  ref,
+					Exists:     exists,
+				})
+				continue
+			}
+			if id == a.ID || seen[id] {
+				continue
+			}
+			seen[id] = true
+			upstream[a.ID] = append(upstream[a.ID], id)
+			graph.Edges = append(graph.Edges, types.ArtifactGraphEdge{From: id, To: a.ID, Reference: ref})
+		}
+	}
+
+	modifiedAt := make(map[string]int64, len(artifacts))
+	for _, a := range artifacts {
+		modifiedAt[a.ID] = a.ModifiedAt
+	}
+	for _, a := range artifacts {
+		sources := newerAncestors(a.ID, a.ModifiedAt, upstream, modifiedAt)
+		graph.Nodes = append(graph.Nodes, types.ArtifactGraphNode{
+			ID:           a.ID,
+			Name:         a.Name,
+			Type:         a.Type,
+			Path:         a.Path,
+			Phase:        a.Phase,
+			ModifiedAt:   a.ModifiedAt,
+			Stale:        len(sources) > 0,
+			StaleSources: sources,
+		})
+	}
+
+	return graph, nil
+}
+
+// newerAncestors walks the inputs of id transitively and returns, sorted, the ancestors
+// modified after since. Cycles in inputDocuments are tolerated.
+func newerAncestors(id string, since int64, upstream map[string][]string, modifiedAt map[string]int64) []string {
+	sources := []string{}
+	visited := map[string]bool{id: true}
+	queue := append([]string(nil), upstream[id]...)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if visited[next] {
+			continue
+		}
+		visited[next] = true
+		if modifiedAt[next] > since {
+			sources = append(sources, next)
+		}
+		queue = append(queue, upstream[next]...)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// inputDocumentResolver maps inputDocuments entries to artifact IDs.
+type inputDocumentResolver struct {
+	projectRoot string
+	variables   *strings.Replacer
+	byPath      map[string]string   // Project-relative path -> artifact ID
+	byBase      map[string][]string // File name -> artifact IDs with that name
+}
+
+func newInputDocumentResolver(config *types.BMadConfig, artifacts []types.ArtifactResponse) *inputDocumentResolver {
+	r := &inputDocumentResolver{
+		projectRoot: config.ProjectRoot,
+		// Workflows write input paths with the same placeholders the BMAD config uses
+		variables: strings.NewReplacer(
+			"{project-root}", config.ProjectRoot,
+			"{planning_artifacts}", config.PlanningArtifacts,
+			"{implementation_artifacts}", config.ImplementationArtifacts,
+			"{project_knowledge}", config.ProjectKnowledge,
+			"{output_folder}", config.OutputFolder,
+		),
+		byPath: make(map[string]string, len(artifacts)),
+		byBase: make(map[string][]string),
+	}
+	for _, a := range artifacts {
+		r.byPath[a.Path] = a.ID
+		base := path.Base(a.Path)
+		r.byBase[base] = append(r.byBase[base], a.ID)
+	}
+	return r
+}
+
+// resolve returns the artifact ID ref points to, trying in order: the path relative to the
+// project root, the path relative to the referencing artifact's folder, a sharded artifact's
+// folder, and finally, for a bare file name, the one artifact with that name. When nothing matches,
+// exists reports whether the reference names a file on disk.
+func (r *inputDocumentResolver) resolve(ref, fromPath string) (id string, exists bool) {
+	expanded := filepath.ToSlash(r.variables.Replace(strings.TrimSpace(ref)))
+
+	var candidates []string
+	if filepath.IsAbs(filepath.FromSlash(expanded)) {
+		rel, err := filepath.Rel(r.projectRoot, filepath.FromSlash(expanded))
+		if err != nil {
+			return "", false
+		}
+		candidates = append(candidates, filepath.ToSlash(rel))
+	} else {
+		candidates = append(candidates, path.Clean(strings.TrimPrefix(expanded, "./")), path.Join(path.Dir(fromPath), expanded))
+	}
+
+	for _, c := range candidates {
+		if id, ok := r.byPath[c]; ok {
+			return id, true
+		}
+		if id, ok := r.byPath[path.Join(c, "index.md")]; ok {
+			return id, true
+		}
+	}
+	if !strings.Contains(expanded, "/") {
+		if ids := r.byBase[expanded]; len(ids) == 1 {
+			return ids[0], true
+		}
+	}
+
+	for _, c := range candidates {
+		if strings.HasPrefix(c, "../") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(r.projectRoot, filepath.FromSlash(c))); err == nil {
+			return "", true
+		}
+	}
+	return "", false
+}
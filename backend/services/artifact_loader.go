@@ -0,0 +1,207 @@
+package services
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"bmad-studio/backend/types"
+)
+
+// artifactLoadWorkers bounds how many files are read and parsed at once while loading artifacts
+var artifactLoadWorkers = min(runtime.NumCPU(), 8)
+
+// artifactSource is a markdown file, or a sharded artifact directory, found in the output folder.
+type artifactSource struct {
+	path    string
+	sharded bool // path is a directory with an index.md
+}
+
+// scanOutputFolder lists the artifact sources under outputFolder without reading them.
+// Sharded directories are returned as a single source and not descended into.
+func scanOutputFolder(outputFolder string) ([]artifactSource, error) {
+	var sources []artifactSource
+	err := filepath.Walk(outputFolder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Printf("Warning: Error accessing %s: %v", path, err)
+			return nil // Continue walking
+		}
+
+		// Skip status files and the registry itself
+		if strings.HasSuffix(info.Name(), "status.yaml") || info.Name() == "artifact-registry.json" {
+			return nil
+		}
+
+		if info.IsDir() {
+			if _, err := os.Stat(filepath.Join(path, "index.md")); err == nil {
+				sources = append(sources, artifactSource{path: path, sharded: true})
+				return filepath.SkipDir // Don't recurse into sharded directories
+			}
+			return nil
+		}
+
+		if strings.HasSuffix(info.Name(), ".md") {
+			sources = append(sources, artifactSource{path: path})
+		}
+		return nil
+	})
+	return sources, err
+}
+
+// processSources builds the artifacts for sources with a bounded pool of workers.
+// A file whose content hash matches its cached record (keyed by relative path) reuses
+// that record rather than being parsed again.
+func (s *ArtifactService) processSources(sources []artifactSource, projectRoot string, cached map[string]*types.Artifact) map[string]*types.Artifact {
+	var reused, parsed atomic.Int64
+
+	process := func(path string) (*types.Artifact, error) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if artifact := s.reuseCachedArtifact(path, projectRoot, info, content, cached); artifact != nil {
+			reused.Add(1)
+			return artifact, nil
+		}
+		parsed.Add(1)
+		return s.processArtifactContent(path, projectRoot, info, content), nil
+	}
+
+	jobs := make(chan artifactSource)
+	results := make(chan []*types.Artifact)
+	var wg sync.WaitGroup
+	for i := 0; i < artifactLoadWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for src := range jobs {
+				if src.sharded {
+					shards, err := s.processShardedArtifact(src.path, process)
+					if err != nil {
+						log.Printf("Warning: Failed to process sharded artifact %s: %v", src.path, err)
+						continue
+					}
+					results <- shards
+					continue
+				}
+				artifact, err := process(src.path)
+				if err != nil {
+					log.Printf("Warning: Failed to process artifact %s: %v", src.path, err)
+					continue
+				}
+				results <- []*types.Artifact{artifact}
+			}
+		}()
+	}
+	go func() {
+		for _, src := range sources {
+			jobs <- src
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	artifacts := make(map[string]*types.Artifact)
+	found := make(map[string]bool)
+	for batch := range results {
+		for _, artifact := range batch {
+			artifacts[artifact.ID] = artifact
+			found[artifact.Path] = true
+		}
+	}
+
+	if cached != nil {
+		dropped := 0
+		for path := range cached {
+			if !found[path] {
+				dropped++
+			}
+		}
+		log.Printf("Artifact warm start: %d unchanged, %d parsed, %d dropped", reused.Load(), parsed.Load(), dropped)
+	}
+	return artifacts
+}
+
+// reuseCachedArtifact returns a copy of the cached record for path if its content is unchanged,
+// with the file's current size and time. Relationships are cleared for the caller to rebuild.
+func (s *ArtifactService) reuseCachedArtifact(path, projectRoot string, info os.FileInfo, content []byte, cached map[string]*types.Artifact) *types.Artifact {
+	if cached == nil {
+		return nil
+	}
+	rel, err := filepath.Rel(projectRoot, path)
+	if err != nil {
+		return nil
+	}
+	entry, ok := cached[filepath.ToSlash(rel)]
+	if !ok || entry.ContentHash == "" || entry.ContentHash != contentHash(content) {
+		return nil
+	}
+
+	artifact := *entry
+	artifact.AbsolutePath = path
+	artifact.ModifiedAt = info.ModTime().Unix()
+	artifact.FileSize = info.Size()
+	artifact.IsSharded = false
+	artifact.Children = nil
+	artifact.ParentID = nil
+	artifact.WorkflowID = nil
+	return &artifact
+}
+
+// WarmStart indexes the output folder starting from the persisted artifact-registry.json:
+// files whose content hash is unchanged keep their registry entry, new and changed files
+// are parsed, and entries for deleted files are dropped. Without a usable registry this
+// is the same as LoadArtifacts.
+func (s *ArtifactService) WarmStart() error {
+	registry, err := s.readRegistry()
+	if err != nil {
+		log.Printf("Warning: Ignoring artifact registry: %v", err)
+		registry = nil
+	}
+	return s.loadArtifacts(registry)
+}
+
+// registryArtifacts converts registry entries into records keyed by project-relative path,
+// skipping any whose path escapes the project root.
+func registryArtifacts(registry *types.ArtifactRegistry, projectRoot string) map[string]*types.Artifact {
+	root := filepath.Clean(projectRoot)
+	artifacts := make(map[string]*types.Artifact, len(registry.Artifacts))
+	for _, entry := range registry.Artifacts {
+		absPath := filepath.Clean(filepath.Join(root, filepath.FromSlash(entry.Path)))
+
+		// Security: Ensure path doesn't escape project root (path traversal protection)
+		if !strings.HasPrefix(absPath, root+string(filepath.Separator)) {
+			log.Printf("Warning: Skipping artifact with path outside project root: %s", entry.Path)
+			continue
+		}
+
+		resp := entry.ArtifactResponse
+		artifacts[resp.Path] = &types.Artifact{
+			ID:             resp.ID,
+			Name:           resp.Name,
+			Type:           resp.Type,
+			Path:           resp.Path,
+			AbsolutePath:   absPath,
+			Status:         resp.Status,
+			CompletedAt:    resp.CompletedAt,
+			Phase:          resp.Phase,
+			PhaseName:      resp.PhaseName,
+			StepsCompleted: resp.StepsCompleted,
+			InputDocuments: resp.InputDocuments,
+			ModifiedAt:     resp.ModifiedAt,
+			FileSize:       resp.FileSize,
+			ContentHash:    entry.ContentHash,
+		}
+	}
+	return artifacts
+}
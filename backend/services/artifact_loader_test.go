@@ -0,0 +1,322 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bmad-studio/backend/types"
+)
+
+// rewriteRegistry applies edit to the persisted registry, so tests can tell reused entries from re-parsed ones.
+func rewriteRegistry(t *testing.T, outputDir string, edit func(*types.ArtifactRegistry)) {
+	t.Helper()
+	path := filepath.Join(outputDir, "artifact-registry.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var registry types.ArtifactRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		t.Fatal(err)
+	}
+	edit(&registry)
+	data, err = json.Marshal(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// markRegistryNames prefixes every registry entry's name with "cached:".
+func markRegistryNames(t *testing.T, outputDir string) {
+	t.Helper()
+	rewriteRegistry(t, outputDir, func(r *types.ArtifactRegistry) {
+		for i := range r.Artifacts {
+			r.Artifacts[i].Name = "cached:" + r.Artifacts[i].Name
+		}
+	})
+}
+
+func TestWarmStart_ReusesUnchangedAndReparsesChanged(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	outputDir := filepath.Join(tmpDir, "_bmad-output")
+	files := map[string]string{
+		"prd.md":          "# PRD\n",
+		"architecture.md": "# Architecture\n",
+		"old-notes.md":    "# Old Notes\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := NewArtifactService(configService, nil).LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	markRegistryNames(t, outputDir)
+
+	// Change the architecture without changing its size or modification time
+	archPath := filepath.Join(outputDir, "architecture.md")
+	info, err := os.Stat(archPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(archPath, []byte("# Systemdesign\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(archPath, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(outputDir, "old-notes.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "epics.md"), []byte("# Epics\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.WarmStart(); err != nil {
+		t.Fatalf("WarmStart error: %v", err)
+	}
+
+	names := make(map[string]string)
+	artifacts, _ := svc.GetArtifacts()
+	for _, a := range artifacts {
+		names[a.ID] = a.Name
+	}
+	want := map[string]string{
+		"_bmad-output-prd":          "cached:PRD",
+		"_bmad-output-architecture": "Systemdesign",
+		"_bmad-output-epics":        "Epics",
+	}
+	if len(names) != len(want) {
+		t.Errorf("Expected %d artifacts, got %v", len(want), names)
+	}
+	for id, name := range want {
+		if names[id] != name {
+			t.Errorf("%s: expected name %q, got %q", id, name, names[id])
+		}
+	}
+}
+
+func TestWarmStart_RebuildsShardRelationships(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	shardDir := filepath.Join(tmpDir, "_bmad-output", "architecture")
+	if err := os.MkdirAll(shardDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"index.md", "data.md", "api.md"} {
+		if err := os.WriteFile(filepath.Join(shardDir, name), []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := NewArtifactService(configService, nil).LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(shardDir, "api.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.WarmStart(); err != nil {
+		t.Fatal(err)
+	}
+	parent, err := svc.GetArtifact("_bmad-output-architecture-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parent.IsSharded || len(parent.Children) != 1 || parent.Children[0] != "_bmad-output-architecture-data" {
+		t.Errorf("Expected the index to have only the remaining shard, got %+v", parent)
+	}
+	child, err := svc.GetArtifact("_bmad-output-architecture-data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.ParentID == nil || *child.ParentID != parent.ID {
+		t.Errorf("Expected the shard's parent to be restored, got %v", child.ParentID)
+	}
+}
+
+func TestWarmStart_ClassificationChangeInvalidatesRegistry(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	outputDir := filepath.Join(tmpDir, "_bmad-output")
+	if err := os.WriteFile(filepath.Join(outputDir, "adr-0001.md"), []byte("# Use Go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewArtifactService(configService, nil).LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	markRegistryNames(t, outputDir)
+
+	rules := "types:\n  - id: adr\n    phase: 3\nrules:\n  - type: adr\n    globs: [\"adr-*.md\"]\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "_bmad", classificationFile), []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.WarmStart(); err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := svc.GetArtifact("_bmad-output-adr-0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifact.Type != "adr" || artifact.Name != "Use Go" {
+		t.Errorf("Expected the artifact re-classified from disk, got %s %q", artifact.Type, artifact.Name)
+	}
+}
+
+func TestWarmStart_WithoutRegistryLoadsEverything(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	if err := os.WriteFile(filepath.Join(tmpDir, "_bmad-output", "prd.md"), []byte("# PRD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.WarmStart(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.GetArtifact("_bmad-output-prd"); err != nil {
+		t.Errorf("Expected the PRD loaded, got %v", err)
+	}
+}
+
+func TestWarmStart_IgnoresCorruptRegistry(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	outputDir := filepath.Join(tmpDir, "_bmad-output")
+	if err := os.WriteFile(filepath.Join(outputDir, "prd.md"), []byte("# PRD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "artifact-registry.json"), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.WarmStart(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.GetArtifact("_bmad-output-prd"); err != nil {
+		t.Errorf("Expected the PRD loaded despite the corrupt registry, got %v", err)
+	}
+}
+
+func TestWarmStart_RegistryPathsCannotEscapeProject(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	outputDir := filepath.Join(tmpDir, "_bmad-output")
+	if err := os.WriteFile(filepath.Join(outputDir, "prd.md"), []byte("# PRD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewArtifactService(configService, nil).LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	rewriteRegistry(t, outputDir, func(r *types.ArtifactRegistry) {
+		r.Artifacts[0].Path = "../../etc/passwd"
+	})
+
+	cached := registryArtifacts(mustReadRegistry(t, configService), tmpDir)
+	if len(cached) != 0 {
+		t.Errorf("Expected the escaping entry to be skipped, got %+v", cached)
+	}
+}
+
+func TestWarmStart_MigratesUnversionedRegistry(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	outputDir := filepath.Join(tmpDir, "_bmad-output")
+	if err := os.WriteFile(filepath.Join(outputDir, "prd.md"), []byte("# PRD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewArtifactService(configService, nil).LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustReadRegistry(t, configService).SchemaVersion; got != registrySchemaVersion {
+		t.Fatalf("Expected schema version %d written, got %d", registrySchemaVersion, got)
+	}
+	markRegistryNames(t, outputDir)
+	rewriteRegistry(t, outputDir, func(r *types.ArtifactRegistry) { r.SchemaVersion = 0 })
+
+	svc := NewArtifactService(configService, nil)
+	if err := svc.WarmStart(); err != nil {
+		t.Fatal(err)
+	}
+	prd, err := svc.GetArtifact("_bmad-output-prd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prd.Name != "cached:PRD" {
+		t.Errorf("Expected the unversioned entry reused, got name %q", prd.Name)
+	}
+}
+
+func TestWarmStart_IgnoresNewerRegistrySchema(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	outputDir := filepath.Join(tmpDir, "_bmad-output")
+	if err := os.WriteFile(filepath.Join(outputDir, "prd.md"), []byte("# PRD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewArtifactService(configService, nil).LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	markRegistryNames(t, outputDir)
+	rewriteRegistry(t, outputDir, func(r *types.ArtifactRegistry) { r.SchemaVersion = registrySchemaVersion + 1 })
+
+	svc := NewArtifactService(configService, nil)
+	if _, err := svc.readRegistry(); !isArtifactErr(err, ErrCodeRegistryLoadFailed) {
+		t.Errorf("Expected %s for a newer schema, got %v", ErrCodeRegistryLoadFailed, err)
+	}
+	rewriteRegistry(t, outputDir, func(r *types.ArtifactRegistry) { r.SchemaVersion = -1 })
+	if _, err := svc.readRegistry(); !isArtifactErr(err, ErrCodeRegistryLoadFailed) {
+		t.Errorf("Expected %s for a negative schema, got %v", ErrCodeRegistryLoadFailed, err)
+	}
+	if err := svc.WarmStart(); err != nil {
+		t.Fatal(err)
+	}
+	prd, err := svc.GetArtifact("_bmad-output-prd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prd.Name != "PRD" {
+		t.Errorf("Expected the PRD re-parsed, got name %q", prd.Name)
+	}
+}
+
+func TestProcessSingleArtifact_DefersRegistryWriteUntilFlush(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	outputDir := filepath.Join(tmpDir, "_bmad-output")
+	svc := NewArtifactService(configService, nil)
+	svc.persister = newRegistryPersister(time.Hour, time.Hour, svc.SaveRegistry)
+	if err := svc.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+
+	prdPath := filepath.Join(outputDir, "prd.md")
+	if err := os.WriteFile(prdPath, []byte("# PRD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ProcessSingleArtifact(prdPath); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(mustReadRegistry(t, configService).Artifacts); n != 0 {
+		t.Fatalf("Expected the registry write deferred, found %d entries", n)
+	}
+
+	if err := svc.FlushRegistry(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(mustReadRegistry(t, configService).Artifacts); n != 1 {
+		t.Errorf("Expected 1 entry after flush, found %d", n)
+	}
+}
+
+func mustReadRegistry(t *testing.T, configService *BMadConfigService) *types.ArtifactRegistry {
+	t.Helper()
+	registry, err := NewArtifactService(configService, nil).readRegistry()
+	if err != nil || registry == nil {
+		t.Fatalf("readRegistry: %v", err)
+	}
+	return registry
+}
@@ -34,8 +34,25 @@ const (
 	ErrCodeArtifactNotFound         = "artifact_not_found"
 	ErrCodeRegistryLoadFailed       = "registry_load_failed"
 	ErrCodeRegistrySaveFailed       = "registry_save_failed"
+	ErrCodeArtifactReadFailed       = "artifact_read_failed"
+	ErrCodeInvalidFrontmatter       = "invalid_frontmatter"
+	ErrCodeSectionNotFound          = "section_not_found"
+	ErrCodeETagRequired             = "etag_required"
+	ErrCodeETagMismatch             = "etag_mismatch"
+	ErrCodeInvalidArtifact          = "invalid_artifact"
+	ErrCodeArtifactExists           = "artifact_exists"
+	ErrCodeArtifactWriteFailed      = "artifact_write_failed"
 )
 
+// registrySchemaVersion is the version of artifact-registry.json this build writes
+const registrySchemaVersion = 1
+
+// registryMigrations upgrade a registry from the schema version at their index to the next one
+var registryMigrations = []func(*types.ArtifactRegistry){
+	// 0 -> 1: unversioned registries need no changes; entries without content hashes are re-parsed
+	func(*types.ArtifactRegistry) {},
+}
+
 // Story filename pattern: digit-digit-name.md (e.g., 0-1-parse-config.md)
 var storyFilenameRegex = regexp.MustCompile(`^\d+-\d+-.+\.md$`)
 
@@ -59,19 +76,32 @@ type ArtifactService struct {
 	configService         *BMadConfigService
 	workflowStatusService *WorkflowStatusService
 	artifacts             map[string]*types.Artifact
+	writeMu               sync.Mutex           // Serializes artifact writes so ETag checks and writes are atomic
+	ownWrites             map[string]string    // Absolute path -> content hash of the last write made by this service
+	classification        *classificationRules // Project rules from _bmad/artifact-types.yaml; nil if none
+	persister             *registryPersister   // Batches registry writes for single-file changes
 }
 
 // NewArtifactService creates a new ArtifactService instance
 func NewArtifactService(configService *BMadConfigService, workflowStatusService *WorkflowStatusService) *ArtifactService {
-	return &ArtifactService{
+	s := &ArtifactService{
 		configService:         configService,
 		workflowStatusService: workflowStatusService,
 		artifacts:             make(map[string]*types.Artifact),
+		ownWrites:             make(map[string]string),
 	}
+	s.persister = newRegistryPersister(registryFlushDelay, registryMaxFlushDelay, s.SaveRegistry)
+	return s
 }
 
 // LoadArtifacts scans the output folder and indexes all artifacts
 func (s *ArtifactService) LoadArtifacts() error {
+	return s.loadArtifacts(nil)
+}
+
+// loadArtifacts indexes the output folder. Files whose content hash matches an entry in
+// registry are taken from it instead of being parsed again; registry may be nil.
+func (s *ArtifactService) loadArtifacts(registry *types.ArtifactRegistry) error {
 	config := s.configService.GetConfig()
 	if config == nil {
 		return &ArtifactServiceError{
@@ -83,6 +113,15 @@ func (s *ArtifactService) LoadArtifacts() error {
 	outputFolder := config.OutputFolder
 	projectRoot := config.ProjectRoot
 
+	// Custom classification rules are re-read on every full load
+	rules, err := loadClassificationRules(projectRoot)
+	if err != nil {
+		log.Printf("Warning: Failed to load artifact classification rules: %v", err)
+	}
+	s.mu.Lock()
+	s.classification = rules
+	s.mu.Unlock()
+
 	// Check if output folder exists
 	if _, err := os.Stat(outputFolder); os.IsNotExist(err) {
 		// No output folder yet - return empty artifacts
@@ -92,58 +131,7 @@ func (s *ArtifactService) LoadArtifacts() error {
 		return nil
 	}
 
-	artifacts := make(map[string]*types.Artifact)
-
-	err := filepath.Walk(outputFolder, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			log.Printf("Warning: Error accessing %s: %v", path, err)
-			return nil // Continue walking
-		}
-
-		// Skip status files
-		if strings.HasSuffix(info.Name(), "status.yaml") {
-			return nil
-		}
-
-		// Skip the registry file itself
-		if info.Name() == "artifact-registry.json" {
-			return nil
-		}
-
-		// Handle directories - check for sharded artifacts
-		if info.IsDir() {
-			indexPath := filepath.Join(path, "index.md")
-			if _, err := os.Stat(indexPath); err == nil {
-				// This is a sharded artifact directory
-				shardedArtifacts, err := s.processShardedArtifact(path, projectRoot)
-				if err != nil {
-					log.Printf("Warning: Failed to process sharded artifact %s: %v", path, err)
-					return nil
-				}
-				// Add parent and all children to artifacts map
-				for _, artifact := range shardedArtifacts {
-					artifacts[artifact.ID] = artifact
-				}
-				return filepath.SkipDir // Don't recurse into sharded directories
-			}
-			return nil // Continue into other directories
-		}
-
-		// Process .md files
-		if strings.HasSuffix(info.Name(), ".md") {
-			artifact, err := s.processArtifact(path, projectRoot)
-			if err != nil {
-				log.Printf("Warning: Failed to process artifact %s: %v", path, err)
-				return nil
-			}
-			if artifact != nil {
-				artifacts[artifact.ID] = artifact
-			}
-		}
-
-		return nil
-	})
-
+	sources, err := scanOutputFolder(outputFolder)
 	if err != nil {
 		return &ArtifactServiceError{
 			Code:    ErrCodeArtifactsNotLoaded,
@@ -151,6 +139,13 @@ func (s *ArtifactService) LoadArtifacts() error {
 		}
 	}
 
+	// Cached types and phases are only valid under the classification rules they were made with
+	var cached map[string]*types.Artifact
+	if registry != nil && registry.ClassificationHash == rules.fingerprint() {
+		cached = registryArtifacts(registry, projectRoot)
+	}
+	artifacts := s.processSources(sources, projectRoot, cached)
+
 	// Cross-reference with workflow status
 	s.crossReferenceWithWorkflowStatus(artifacts)
 
@@ -179,8 +174,16 @@ func (s *ArtifactService) processArtifact(path, projectRoot string) (*types.Arti
 		return nil, err
 	}
 
-	// Parse frontmatter (may be nil)
-	frontmatter, _ := s.parseFrontmatter(content)
+	return s.processArtifactContent(path, projectRoot, info, content), nil
+}
+
+// processArtifactContent builds the artifact record for a file from its already-read content
+func (s *ArtifactService) processArtifactContent(path, projectRoot string, info os.FileInfo, content []byte) *types.Artifact {
+	// Parse frontmatter (may be nil); invalid frontmatter is reported by the validation service
+	frontmatter, fmErr := s.parseFrontmatter(content)
+	if fmErr != nil {
+		log.Printf("Warning: Invalid frontmatter in %s: %v", path, fmErr)
+	}
 
 	// Calculate relative path
 	relativePath, err := filepath.Rel(projectRoot, path)
@@ -190,7 +193,7 @@ func (s *ArtifactService) processArtifact(path, projectRoot string) (*types.Arti
 	relativePath = filepath.ToSlash(relativePath) // Normalize to forward slashes
 
 	// Classify artifact
-	artifactType := s.classifyArtifact(relativePath, frontmatter)
+	artifactType := s.classifyArtifact(relativePath, frontmatter, content)
 
 	// Extract name
 	name := s.extractName(relativePath, frontmatter, content)
@@ -222,6 +225,7 @@ func (s *ArtifactService) processArtifact(path, projectRoot string) (*types.Arti
 		PhaseName:    phaseName,
 		ModifiedAt:   info.ModTime().Unix(),
 		FileSize:     info.Size(),
+		ContentHash:  contentHash(content),
 		IsSharded:    false,
 	}
 
@@ -234,16 +238,16 @@ func (s *ArtifactService) processArtifact(path, projectRoot string) (*types.Arti
 		artifact.InputDocuments = frontmatter.InputDocuments
 	}
 
-	return artifact, nil
+	return artifact
 }
 
-// processShardedArtifact processes a sharded artifact directory
+// processShardedArtifact processes a sharded artifact directory, using process for each file
 // Returns all artifacts: parent (index.md) + all children with ParentID set
-func (s *ArtifactService) processShardedArtifact(dirPath, projectRoot string) ([]*types.Artifact, error) {
+func (s *ArtifactService) processShardedArtifact(dirPath string, process func(path string) (*types.Artifact, error)) ([]*types.Artifact, error) {
 	indexPath := filepath.Join(dirPath, "index.md")
 
 	// Process index.md as primary (parent)
-	parent, err := s.processArtifact(indexPath, projectRoot)
+	parent, err := process(indexPath)
 	if err != nil {
 		return nil, err
 	}
@@ -273,7 +277,7 @@ func (s *ArtifactService) processShardedArtifact(dirPath, projectRoot string) ([
 
 		// Process child as full artifact
 		childPath := filepath.Join(dirPath, entry.Name())
-		child, err := s.processArtifact(childPath, projectRoot)
+		child, err := process(childPath)
 		if err != nil {
 			log.Printf("Warning: Failed to process sharded child %s: %v", childPath, err)
 			continue
@@ -294,14 +298,30 @@ func (s *ArtifactService) processShardedArtifact(dirPath, projectRoot string) ([
 // parseFrontmatter extracts YAML frontmatter from markdown content.
 // Handles both LF and CRLF line endings.
 func (s *ArtifactService) parseFrontmatter(content []byte) (*types.ArtifactFrontmatter, error) {
-	if len(content) < 3 || !bytes.HasPrefix(content, []byte("---")) {
+	raw, _ := splitFrontmatter(content)
+	if raw == nil {
 		return nil, nil
 	}
 
+	var fm types.ArtifactFrontmatter
+	if err := yaml.Unmarshal(raw, &fm); err != nil {
+		return nil, err
+	}
+
+	return &fm, nil
+}
+
+// splitFrontmatter separates the YAML frontmatter block from the markdown body.
+// Returns nil frontmatter and the full content when no well-formed block is present.
+func splitFrontmatter(content []byte) (frontmatter []byte, body []byte) {
+	if len(content) < 3 || !bytes.HasPrefix(content, []byte("---")) {
+		return nil, content
+	}
+
 	// Find end of the opening "---" line
 	firstNewline := bytes.IndexByte(content[3:], '\n')
 	if firstNewline == -1 {
-		return nil, nil
+		return nil, content
 	}
 	startIdx := 3 + firstNewline + 1 // skip past "---\n"
 
@@ -309,15 +329,18 @@ func (s *ArtifactService) parseFrontmatter(content []byte) (*types.ArtifactFront
 	rest := content[startIdx:]
 	endIdx := bytes.Index(rest, []byte("\n---"))
 	if endIdx == -1 {
-		return nil, nil
+		return nil, content
 	}
 
-	var fm types.ArtifactFrontmatter
-	if err := yaml.Unmarshal(rest[:endIdx], &fm); err != nil {
-		return nil, err
+	// Body starts on the line after the closing delimiter
+	body = rest[endIdx+len("\n---"):]
+	if nl := bytes.IndexByte(body, '\n'); nl != -1 {
+		body = body[nl+1:]
+	} else {
+		body = nil
 	}
 
-	return &fm, nil
+	return rest[:endIdx], body
 }
 
 // normalizeStepsCompleted converts various formats to []string
@@ -387,7 +410,14 @@ func (s *ArtifactService) generateArtifactID(relativePath string) string {
 }
 
 // classifyArtifact determines the artifact type using multi-layer strategy
-func (s *ArtifactService) classifyArtifact(path string, fm *types.ArtifactFrontmatter) string {
+func (s *ArtifactService) classifyArtifact(path string, fm *types.ArtifactFrontmatter, content []byte) string {
+	// Priority 0: Project classification rules
+	if rules := s.classificationRules(); rules != nil {
+		if artifactType := rules.classify(path, content); artifactType != "" {
+			return artifactType
+		}
+	}
+
 	// Priority 1: Frontmatter workflowType
 	if fm != nil && fm.WorkflowType != "" {
 		return s.classifyByFrontmatter(fm)
@@ -483,8 +513,21 @@ func (s *ArtifactService) classifyByFilename(path string) string {
 	return types.ArtifactTypeOther
 }
 
+// classificationRules returns the project's classification rules, or nil if it has none.
+func (s *ArtifactService) classificationRules() *classificationRules {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.classification
+}
+
 // getPhaseInfo returns the BMAD phase number and name for an artifact type
 func (s *ArtifactService) getPhaseInfo(artifactType string) (int, string) {
+	if rules := s.classificationRules(); rules != nil {
+		if phase, name, ok := rules.phaseInfo(artifactType); ok {
+			return phase, name
+		}
+	}
+
 	switch artifactType {
 	case types.ArtifactTypeResearch, types.ArtifactTypeBrainstorming, types.ArtifactTypeProductBrief:
 		return 1, "Analysis"
@@ -512,17 +555,14 @@ func (s *ArtifactService) determineStatus(fm *types.ArtifactFrontmatter, content
 		}
 	}
 
-	// Check content for status line (stories often have "Status: xxx")
-	contentStr := string(content)
-	if strings.Contains(contentStr, "Status: done") || strings.Contains(contentStr, "Status: complete") {
+	// Check the body for a status line (stories have "Status: xxx")
+	_, body := splitFrontmatter(content)
+	switch storyStatusLine(body) {
+	case types.StoryDone, "complete", "completed":
 		return types.ArtifactStatusComplete
-	}
-	if strings.Contains(contentStr, "Status: in-progress") || strings.Contains(contentStr, "Status: review") {
+	case types.StoryInProgress, "in_progress", "inprogress", types.StoryReview:
 		return types.ArtifactStatusInProgress
 	}
-	if strings.Contains(contentStr, "Status: ready-for-dev") || strings.Contains(contentStr, "Status: backlog") {
-		return types.ArtifactStatusNotStarted
-	}
 
 	return types.ArtifactStatusNotStarted
 }
@@ -595,6 +635,18 @@ func (s *ArtifactService) GetArtifact(id string) (*types.ArtifactResponse, error
 	return &resp, nil
 }
 
+// ReadContent returns the raw markdown of an artifact from disk.
+func (s *ArtifactService) ReadContent(artifact *types.ArtifactResponse) ([]byte, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+	return os.ReadFile(filepath.Join(config.ProjectRoot, filepath.FromSlash(artifact.Path)))
+}
+
 // toResponse converts internal Artifact to API response
 func (s *ArtifactService) toResponse(artifact *types.Artifact) types.ArtifactResponse {
 	return types.ArtifactResponse{
@@ -636,12 +688,25 @@ func (s *ArtifactService) SaveRegistry() error {
 		return err
 	}
 
-	responses, err := s.GetArtifacts()
-	if err != nil {
-		return err
+	s.mu.RLock()
+	ids := make([]string, 0, len(s.artifacts))
+	for id := range s.artifacts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	wrapper := types.ArtifactRegistry{
+		SchemaVersion:      registrySchemaVersion,
+		ClassificationHash: s.classification.fingerprint(),
+		Artifacts:          make([]types.ArtifactRegistryEntry, 0, len(ids)),
 	}
+	for _, id := range ids {
+		wrapper.Artifacts = append(wrapper.Artifacts, types.ArtifactRegistryEntry{
+			ArtifactResponse: s.toResponse(s.artifacts[id]),
+			ContentHash:      s.artifacts[id].ContentHash,
+		})
+	}
+	s.mu.RUnlock()
 
-	wrapper := types.ArtifactsResponse{Artifacts: responses}
 	data, err := json.MarshalIndent(wrapper, "", "  ")
 	if err != nil {
 		return &ArtifactServiceError{
@@ -699,15 +764,19 @@ func (s *ArtifactService) ProcessSingleArtifact(path string) (*types.ArtifactRes
 		return nil, nil
 	}
 
-	// Update registry
+	// Update registry. A single file cannot reveal its shard directory or workflow,
+	// so those are carried over from the existing record.
 	s.mu.Lock()
+	if existing, ok := s.artifacts[artifact.ID]; ok {
+		artifact.IsSharded = existing.IsSharded
+		artifact.Children = existing.Children
+		artifact.ParentID = existing.ParentID
+		artifact.WorkflowID = existing.WorkflowID
+	}
 	s.artifacts[artifact.ID] = artifact
 	s.mu.Unlock()
 
-	// Save registry
-	if err := s.SaveRegistry(); err != nil {
-		log.Printf("Warning: Failed to save artifact registry: %v", err)
-	}
+	s.persister.markDirty()
 
 	resp := s.toResponse(artifact)
 	return &resp, nil
@@ -752,9 +821,7 @@ func (s *ArtifactService) RemoveArtifact(path string) (*types.ArtifactResponse,
 		return nil, nil
 	}
 
-	if err := s.SaveRegistry(); err != nil {
-		log.Printf("Warning: Failed to save artifact registry: %v", err)
-	}
+	s.persister.markDirty()
 
 	resp := s.toResponse(artifact)
 	return &resp, nil
@@ -770,89 +837,53 @@ func (s *ArtifactService) GetArtifactByPath(path string) (*types.ArtifactRespons
 	return s.GetArtifact(id)
 }
 
-// LoadRegistry loads the artifact registry from disk (for cache validation)
-func (s *ArtifactService) LoadRegistry() error {
+// FlushRegistry writes pending registry changes immediately. Call it on shutdown so
+// changes still inside the batching window are not lost.
+func (s *ArtifactService) FlushRegistry() error {
+	return s.persister.flush()
+}
+
+// readRegistry reads the persisted artifact registry. It returns nil without an error if none exists.
+func (s *ArtifactService) readRegistry() (*types.ArtifactRegistry, error) {
 	registryPath, err := s.getRegistryPath()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	data, err := os.ReadFile(registryPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil // No registry yet - not an error
+			return nil, nil // No registry yet - not an error
 		}
-		return &ArtifactServiceError{
+		return nil, &ArtifactServiceError{
 			Code:    ErrCodeRegistryLoadFailed,
 			Message: fmt.Sprintf("Failed to read registry file: %v", err),
 		}
 	}
 
-	var wrapper types.ArtifactsResponse
-	if err := json.Unmarshal(data, &wrapper); err != nil {
-		return &ArtifactServiceError{
+	var registry types.ArtifactRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, &ArtifactServiceError{
 			Code:    ErrCodeRegistryLoadFailed,
 			Message: fmt.Sprintf("Failed to parse registry file: %v", err),
 		}
 	}
 
-	// Convert responses to internal artifacts and validate
-	config := s.configService.GetConfig()
-	if config == nil {
-		return nil
-	}
-
-	artifacts := make(map[string]*types.Artifact)
-	for _, resp := range wrapper.Artifacts {
-		// Construct and validate path
-		absPath := filepath.Clean(filepath.Join(config.ProjectRoot, resp.Path))
-
-		// Security: Ensure path doesn't escape project root (path traversal protection)
-		if !strings.HasPrefix(absPath, filepath.Clean(config.ProjectRoot)+string(filepath.Separator)) &&
-			absPath != filepath.Clean(config.ProjectRoot) {
-			log.Printf("Warning: Skipping artifact with path outside project root: %s", resp.Path)
-			continue
-		}
-
-		// Validate file still exists
-		info, err := os.Stat(absPath)
-		if err != nil {
-			// File no longer exists - skip
-			continue
-		}
-
-		// Check if modified since registry was written
-		if info.ModTime().Unix() != resp.ModifiedAt {
-			// File changed - will be rescanned
-			continue
+	if registry.SchemaVersion > registrySchemaVersion {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeRegistryLoadFailed,
+			Message: fmt.Sprintf("Registry schema version %d is newer than supported version %d", registry.SchemaVersion, registrySchemaVersion),
 		}
-
-		artifact := &types.Artifact{
-			ID:             resp.ID,
-			Name:           resp.Name,
-			Type:           resp.Type,
-			Path:           resp.Path,
-			AbsolutePath:   absPath,
-			Status:         resp.Status,
-			CompletedAt:    resp.CompletedAt,
-			Phase:          resp.Phase,
-			PhaseName:      resp.PhaseName,
-			WorkflowID:     resp.WorkflowID,
-			StepsCompleted: resp.StepsCompleted,
-			InputDocuments: resp.InputDocuments,
-			IsSharded:      resp.IsSharded,
-			Children:       resp.Children,
-			ParentID:       resp.ParentID,
-			ModifiedAt:     resp.ModifiedAt,
-			FileSize:       resp.FileSize,
+	}
+	if registry.SchemaVersion < 0 {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeRegistryLoadFailed,
+			Message: fmt.Sprintf("Registry schema version %d is invalid", registry.SchemaVersion),
 		}
-		artifacts[artifact.ID] = artifact
 	}
-
-	s.mu.Lock()
-	s.artifacts = artifacts
-	s.mu.Unlock()
-
-	return nil
+	for registry.SchemaVersion < registrySchemaVersion {
+		registryMigrations[registry.SchemaVersion](&registry)
+		registry.SchemaVersion++
+	}
+	return &registry, nil
 }
-
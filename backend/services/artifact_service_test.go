@@ -620,6 +620,18 @@ func TestDetermineStatus(t *testing.T) {
 			content:        "Status: done\n",
 			expectedStatus: types.ArtifactStatusComplete,
 		},
+		{
+			name:           "Content Status: completed",
+			frontmatter:    nil,
+			content:        "# Story\n\nStatus: completed\n",
+			expectedStatus: types.ArtifactStatusComplete,
+		},
+		{
+			name:           "Content Status: in_progress",
+			frontmatter:    nil,
+			content:        "Status: in_progress\n",
+			expectedStatus: types.ArtifactStatusInProgress,
+		},
 		{
 			name:           "Content Status: ready-for-dev",
 			frontmatter:    nil,
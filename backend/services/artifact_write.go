@@ -0,0 +1,528 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// artifactWorkflowTypes is the frontmatter workflowType written into new artifacts that lack one,
+// so they classify as the requested type regardless of file name.
+var artifactWorkflowTypes = map[string]string{
+	types.ArtifactTypePRD:              "prd",
+	types.ArtifactTypeArchitecture:     "architecture",
+	types.ArtifactTypeEpics:            "epics",
+	types.ArtifactTypeStories:          "create-story",
+	types.ArtifactTypeUXDesign:         "ux-design",
+	types.ArtifactTypeResearch:         "research",
+	types.ArtifactTypeBrainstorming:    "brainstorming",
+	types.ArtifactTypeProductBrief:     "product-brief",
+	types.ArtifactTypeValidationReport: "validation",
+	types.ArtifactTypeProjectContext:   "project-context",
+}
+
+// frontmatterField is a top-level frontmatter key to set. A nil Value removes the key.
+type frontmatterField struct {
+	Key   string
+	Value interface{}
+}
+
+// ArtifactETag returns the entity tag of an artifact, derived from its modification time and size.
+func ArtifactETag(artifact *types.ArtifactResponse) string {
+	return formatETag(artifact.ModifiedAt, artifact.FileSize)
+}
+
+func formatETag(modifiedAt, size int64) string {
+	return fmt.Sprintf(`"%d-%d"`, modifiedAt, size)
+}
+
+// fileETag computes the entity tag of a file from its current state on disk.
+func fileETag(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	return formatETag(info.ModTime().Unix(), info.Size()), nil
+}
+
+// GetRawContentWithETag returns an artifact's markdown together with the ETag of the file it was read from.
+// The file is stat'ed before it is read, so a concurrent change yields an older ETag and a later write fails safely.
+func (s *ArtifactService) GetRawContentWithETag(id string) ([]byte, string, error) {
+	artifact, err := s.GetArtifact(id)
+	if err != nil {
+		return nil, "", err
+	}
+	absPath, err := s.absArtifactPath(artifact)
+	if err != nil {
+		return nil, "", err
+	}
+
+	etag, err := fileETag(absPath)
+	if err != nil {
+		return nil, "", &ArtifactServiceError{
+			Code:    ErrCodeArtifactReadFailed,
+			Message: fmt.Sprintf("Failed to read artifact %s: %v", id, err),
+		}
+	}
+	content, err := s.GetRawContent(id)
+	if err != nil {
+		return nil, "", err
+	}
+	return content, etag, nil
+}
+
+// WriteContent replaces the markdown of an existing artifact.
+// ifMatch must be the artifact's current ETag (or "*"); a stale ETag is rejected.
+func (s *ArtifactService) WriteContent(id string, content []byte, ifMatch string) (*types.ArtifactResponse, error) {
+	return s.modifyArtifact(id, ifMatch, func([]byte) ([]byte, error) {
+		return content, nil
+	})
+}
+
+// UpdateFrontmatter sets status, stepsCompleted and completedAt in an artifact's frontmatter.
+// Lines of other keys, comments and the markdown body are left byte-for-byte unchanged.
+func (s *ArtifactService) UpdateFrontmatter(id string, update types.ArtifactFrontmatterUpdate, ifMatch string) (*types.ArtifactResponse, error) {
+	var fields []frontmatterField
+	if update.Status != nil {
+		switch *update.Status {
+		case types.ArtifactStatusComplete, types.ArtifactStatusInProgress, types.ArtifactStatusNotStarted:
+		default:
+			return nil, &ArtifactServiceError{
+				Code:    ErrCodeInvalidArtifact,
+				Message: fmt.Sprintf("Invalid status %q: must be complete, in-progress or not-started", *update.Status),
+			}
+		}
+		fields = append(fields, frontmatterField{Key: "status", Value: *update.Status})
+	}
+	if update.StepsCompleted != nil {
+		fields = append(fields, frontmatterField{Key: "stepsCompleted", Value: update.StepsCompleted})
+	}
+	if update.CompletedAt != nil {
+		var value interface{}
+		if *update.CompletedAt != "" {
+			value = *update.CompletedAt
+		}
+		fields = append(fields, frontmatterField{Key: "completedAt", Value: value})
+	}
+	if len(fields) == 0 {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeInvalidArtifact,
+			Message: "No frontmatter fields to update",
+		}
+	}
+
+	return s.modifyArtifact(id, ifMatch, func(current []byte) ([]byte, error) {
+		updated, err := setFrontmatterFields(current, fields)
+		if err != nil {
+			return nil, &ArtifactServiceError{
+				Code:    ErrCodeInvalidFrontmatter,
+				Message: fmt.Sprintf("Invalid frontmatter in artifact %s: %v", id, err),
+			}
+		}
+		return updated, nil
+	})
+}
+
+// CreateArtifact writes a new artifact into the folder for its type and adds it to the registry.
+func (s *ArtifactService) CreateArtifact(req types.CreateArtifactRequest) (*types.ArtifactResponse, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+
+	workflowType, ok := artifactWorkflowTypes[req.Type]
+	if !ok {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeInvalidArtifact,
+			Message: fmt.Sprintf("Unsupported artifact type: %s", req.Type),
+		}
+	}
+	filename, err := artifactFilename(req.Type, req.Filename)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(artifactFolder(config, req.Type), filename)
+
+	content := []byte(req.Content)
+	if fm, _ := s.parseFrontmatter(content); fm == nil || fm.WorkflowType == "" {
+		if content, err = setFrontmatterFields(content, []frontmatterField{{Key: "workflowType", Value: workflowType}}); err != nil {
+			return nil, &ArtifactServiceError{
+				Code:    ErrCodeInvalidFrontmatter,
+				Message: fmt.Sprintf("Invalid frontmatter: %v", err),
+			}
+		}
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if _, err := os.Stat(path); err == nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactExists,
+			Message: fmt.Sprintf("Artifact file already exists: %s", filename),
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactWriteFailed,
+			Message: fmt.Sprintf("Failed to create artifact folder: %v", err),
+		}
+	}
+	return s.writeArtifactFile(path, content)
+}
+
+// ConsumeOwnWrite reports whether the file at path still holds exactly what this service last wrote to it.
+// A match is consumed, so only the first watcher event for a write is recognized as an echo.
+func (s *ArtifactService) ConsumeOwnWrite(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.ownWrites[path] != contentHash(content) {
+		return false
+	}
+	delete(s.ownWrites, path)
+	return true
+}
+
+// modifyArtifact applies edit to an artifact's current content after checking ifMatch against the file on disk.
+func (s *ArtifactService) modifyArtifact(id, ifMatch string, edit func(current []byte) ([]byte, error)) (*types.ArtifactResponse, error) {
+	if ifMatch == "" {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeETagRequired,
+			Message: "Writes require an If-Match header with the artifact's ETag",
+		}
+	}
+
+	artifact, err := s.GetArtifact(id)
+	if err != nil {
+		return nil, err
+	}
+	path, err := s.absArtifactPath(artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	current, err := fileETag(path)
+	if err != nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactReadFailed,
+			Message: fmt.Sprintf("Failed to read artifact %s: %v", id, err),
+		}
+	}
+	if ifMatch != "*" && strings.TrimPrefix(ifMatch, "W/") != current {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeETagMismatch,
+			Message: fmt.Sprintf("Artifact %s changed since it was read (current ETag %s)", id, current),
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactReadFailed,
+			Message: fmt.Sprintf("Failed to read artifact %s: %v", id, err),
+		}
+	}
+	updated, err := edit(content)
+	if err != nil {
+		return nil, err
+	}
+	return s.writeArtifactFile(path, updated)
+}
+
+// writeArtifactFile atomically writes content to path, records it as this service's own write
+// and refreshes the registry entry. Callers must hold writeMu.
+func (s *ArtifactService) writeArtifactFile(path string, content []byte) (*types.ArtifactResponse, error) {
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, content, 0644); err != nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactWriteFailed,
+			Message: fmt.Sprintf("Failed to write artifact: %v", err),
+		}
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactWriteFailed,
+			Message: fmt.Sprintf("Failed to write artifact: %v", err),
+		}
+	}
+
+	if s.ownWrites == nil {
+		s.ownWrites = make(map[string]string)
+	}
+	s.ownWrites[path] = contentHash(content)
+
+	artifact, err := s.ProcessSingleArtifact(path)
+	if err != nil {
+		return nil, &ArtifactServiceError{
+			Code:    ErrCodeArtifactWriteFailed,
+			Message: fmt.Sprintf("Artifact written but could not be re-indexed: %v", err),
+		}
+	}
+	return artifact, nil
+}
+
+// absArtifactPath resolves an artifact's project-relative path to an absolute path.
+func (s *ArtifactService) absArtifactPath(artifact *types.ArtifactResponse) (string, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return "", &ArtifactServiceError{
+			Code:    ErrCodeArtifactConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+	return filepath.Join(config.ProjectRoot, filepath.FromSlash(artifact.Path)), nil
+}
+
+// artifactFolder returns the folder new artifacts of a type are created in, following the BMAD layout:
+// stories under implementation artifacts, project context at the output root, research and
+// brainstorming in their own planning subfolders, and everything else in planning artifacts.
+func artifactFolder(config *types.BMadConfig, artifactType string) string {
+	planning := config.PlanningArtifacts
+	if planning == "" {
+		planning = filepath.Join(config.OutputFolder, "planning-artifacts")
+	}
+
+	switch artifactType {
+	case types.ArtifactTypeStories:
+		if config.ImplementationArtifacts != "" {
+			return config.ImplementationArtifacts
+		}
+		return filepath.Join(config.OutputFolder, "implementation-artifacts")
+	case types.ArtifactTypeProjectContext:
+		return config.OutputFolder
+	case types.ArtifactTypeResearch:
+		return filepath.Join(planning, "research")
+	case types.ArtifactTypeBrainstorming:
+		return filepath.Join(planning, "brainstorming")
+	default:
+		return planning
+	}
+}
+
+// artifactFilename validates a requested file name, or picks the conventional one for the type.
+// Stories have no default because their names carry the epic and story numbers.
+func artifactFilename(artifactType, requested string) (string, error) {
+	name := strings.TrimSpace(requested)
+	if name == "" {
+		if artifactType == types.ArtifactTypeStories {
+			return "", &ArtifactServiceError{
+				Code:    ErrCodeInvalidArtifact,
+				Message: "Stories require a filename like 1-2-story-name.md",
+			}
+		}
+		name = strings.ReplaceAll(artifactType, "_", "-")
+	}
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+
+	if name != filepath.Base(name) || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") || name == "index.md" {
+		return "", &ArtifactServiceError{
+			Code:    ErrCodeInvalidArtifact,
+			Message: fmt.Sprintf("Invalid artifact filename: %s", requested),
+		}
+	}
+	if artifactType == types.ArtifactTypeStories && !storyFilenameRegex.MatchString(name) {
+		return "", &ArtifactServiceError{
+			Code:    ErrCodeInvalidArtifact,
+			Message: fmt.Sprintf("Story filenames must look like 1-2-story-name.md, got %s", name),
+		}
+	}
+	return name, nil
+}
+
+// setFrontmatterFields sets top-level keys in a markdown file's frontmatter, adding a block if there is none.
+// Only the lines of the keys being set change: scalars are replaced in place, other values
+// re-encode just their own entry, and new keys are appended. Every other line of the
+// frontmatter, and the body, is copied byte-for-byte.
+func setFrontmatterFields(content []byte, fields []frontmatterField) ([]byte, error) {
+	raw, body := splitFrontmatter(content)
+
+	newline := "\n"
+	if bytes.HasPrefix(content, []byte("---\r\n")) {
+		newline = "\r\n"
+	}
+
+	for _, field := range fields {
+		var err error
+		if raw, err = setFrontmatterField(raw, field, strings.TrimSuffix(newline, "\n")); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("---" + newline)
+	if len(raw) > 0 {
+		out.Write(raw)
+		out.WriteString("\n")
+	}
+	out.WriteString("---" + newline)
+	out.Write(body)
+	return out.Bytes(), nil
+}
+
+// setFrontmatterField applies one field to raw frontmatter (without its delimiters or final
+// newline) and returns the new frontmatter. cr is appended to inserted lines of CRLF files.
+func setFrontmatterField(raw []byte, field frontmatterField, cr string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	var root *yaml.Node
+	if doc.Kind != 0 {
+		root = doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("frontmatter is not a mapping")
+		}
+		if root.Style&yaml.FlowStyle != 0 {
+			// A one-line {key: value} block has no lines to edit separately
+			if err := setMappingValue(root, field.Key, field.Value); err != nil {
+				return nil, err
+			}
+			encoded, err := encodeYAMLDocument(&doc)
+			if err != nil {
+				return nil, err
+			}
+			return frontmatterLines(encoded, cr), nil
+		}
+	}
+
+	index := -1
+	if root != nil {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == field.Key {
+				index = i
+				break
+			}
+		}
+	}
+
+	// A string replacing a scalar keeps its quoting and line comment
+	if value, ok := field.Value.(string); ok && index >= 0 && root.Content[index+1].Kind == yaml.ScalarNode {
+		if out, ok := replaceScalarInPlace(raw, root.Content[index+1], value); ok {
+			return out, nil
+		}
+	}
+
+	var lines []string
+	if len(raw) > 0 {
+		lines = strings.Split(string(raw), "\n")
+	}
+	var entry []string
+	if field.Value != nil {
+		mapping := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if index >= 0 {
+			// Comments above the key stay where they are, outside the replaced lines
+			key := *root.Content[index]
+			key.HeadComment = ""
+			mapping.Content = []*yaml.Node{&key, root.Content[index+1]}
+		}
+		if err := setMappingValue(mapping, field.Key, field.Value); err != nil {
+			return nil, err
+		}
+		encoded, err := encodeYAMLDocument(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{mapping}})
+		if err != nil {
+			return nil, err
+		}
+		entry = strings.Split(string(frontmatterLines(encoded, cr)), "\n")
+	}
+
+	if index < 0 {
+		if entry == nil {
+			return raw, nil
+		}
+		return []byte(strings.Join(append(lines, entry...), "\n")), nil
+	}
+
+	// The entry runs from its key to the next key, less any blank or comment lines
+	// above that key, which belong to it
+	first := root.Content[index].Line - 1
+	last := len(lines)
+	if index+2 < len(root.Content) {
+		last = root.Content[index+2].Line - 1
+	}
+	for last > first+1 {
+		line := strings.TrimSpace(lines[last-1])
+		if line != "" && !strings.HasPrefix(lines[last-1], "#") {
+			break
+		}
+		last--
+	}
+	if first < 0 || last > len(lines) {
+		return nil, fmt.Errorf("frontmatter key %s not found", field.Key)
+	}
+
+	updated := append(append(append([]string{}, lines[:first]...), entry...), lines[last:]...)
+	return []byte(strings.Join(updated, "\n")), nil
+}
+
+// frontmatterLines trims the final newline from encoded YAML and converts it to the file's
+// line endings. An empty mapping encodes to no lines at all.
+func frontmatterLines(encoded []byte, cr string) []byte {
+	text := strings.TrimSuffix(string(encoded), "\n")
+	if strings.TrimSpace(text) == "{}" {
+		return nil
+	}
+	return []byte(strings.ReplaceAll(text, "\n", cr+"\n") + cr)
+}
+
+// setMappingValue replaces, adds or (for a nil value) removes key in a YAML mapping node.
+// A replaced sequence keeps its flow or block style.
+func setMappingValue(mapping *yaml.Node, key string, value interface{}) error {
+	index := -1
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			index = i
+			break
+		}
+	}
+
+	if value == nil {
+		if index >= 0 {
+			mapping.Content = append(mapping.Content[:index], mapping.Content[index+2:]...)
+		}
+		return nil
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return err
+	}
+	if index >= 0 {
+		old := mapping.Content[index+1]
+		if old.Kind == node.Kind {
+			node.Style = old.Style
+		}
+		node.LineComment = old.LineComment
+		mapping.Content[index+1] = &node
+		return nil
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, &node)
+	return nil
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
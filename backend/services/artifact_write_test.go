@@ -0,0 +1,269 @@
+package services
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+func TestSetFrontmatterFields_KeepsBodyAndComments(t *testing.T) {
+	body := "# Title\n\n  Indented   text,  *kept*  as is.\n\n---\n\nTrailing rule above.\n"
+	content := "---\n# planning notes\nstatus: in-progress # updated by pm\nstepsCompleted: [1, 2]\nowner: pm\n---\n" + body
+
+	updated, err := setFrontmatterFields([]byte(content), []frontmatterField{
+		{Key: "status", Value: "complete"},
+		{Key: "stepsCompleted", Value: []interface{}{1, 2, 3}},
+		{Key: "completedAt", Value: "2026-01-27"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, gotBody := splitFrontmatter(updated)
+	if string(gotBody) != body {
+		t.Errorf("Body changed:\n%q\nwant\n%q", gotBody, body)
+	}
+	fm := string(raw)
+	for _, want := range []string{"# planning notes", "status: complete # updated by pm", "stepsCompleted: [1, 2, 3]", "owner: pm", `completedAt: "2026-01-27"`} {
+		if !strings.Contains(fm, want) {
+			t.Errorf("Frontmatter missing %q:\n%s", want, fm)
+		}
+	}
+}
+
+func TestSetFrontmatterFields_AddsBlockAndRemovesKeys(t *testing.T) {
+	updated, err := setFrontmatterFields([]byte("# Title\n"), []frontmatterField{{Key: "status", Value: "complete"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(updated) != "---\nstatus: complete\n---\n# Title\n" {
+		t.Errorf("Unexpected content %q", updated)
+	}
+
+	removed, err := setFrontmatterFields(updated, []frontmatterField{{Key: "status", Value: nil}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(removed) != "---\n---\n# Title\n" {
+		t.Errorf("Unexpected content after removal %q", removed)
+	}
+}
+
+func TestSetFrontmatterFields_LeavesOtherLinesUntouched(t *testing.T) {
+	content := "---\n" +
+		"inputDocuments:\n" +
+		"-   a.md\n" +
+		"-   b.md\n" +
+		"nested:\n" +
+		"    deep:\n" +
+		"        x: 1\n" +
+		"# steps so far\n" +
+		"stepsCompleted:\n" +
+		"    - 1\n" +
+		"\n" +
+		"# lifecycle\n" +
+		"status: 'draft'\n" +
+		"owner:   pm\n" +
+		"---\n# Title\n"
+
+	updated, err := setFrontmatterFields([]byte(content), []frontmatterField{
+		{Key: "status", Value: "complete"},
+		{Key: "stepsCompleted", Value: []interface{}{1, 2}},
+		{Key: "completedAt", Value: "2026-01-27"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "---\n" +
+		"inputDocuments:\n" +
+		"-   a.md\n" +
+		"-   b.md\n" +
+		"nested:\n" +
+		"    deep:\n" +
+		"        x: 1\n" +
+		"# steps so far\n" +
+		"stepsCompleted:\n" +
+		"  - 1\n" +
+		"  - 2\n" +
+		"\n" +
+		"# lifecycle\n" +
+		"status: 'complete'\n" +
+		"owner:   pm\n" +
+		"completedAt: \"2026-01-27\"\n" +
+		"---\n# Title\n"
+	if string(updated) != want {
+		t.Errorf("Unexpected content:\n%s\nwant\n%s", updated, want)
+	}
+
+	removed, err := setFrontmatterFields(updated, []frontmatterField{{Key: "stepsCompleted", Value: nil}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(removed), "- 2") || !strings.Contains(string(removed), "        x: 1\n# steps so far\n\n# lifecycle\n") {
+		t.Errorf("Expected only the stepsCompleted lines removed, got:\n%s", removed)
+	}
+}
+
+func TestSetFrontmatterFields_KeepsCRLF(t *testing.T) {
+	content := "---\r\nstatus: draft\r\nowner: pm\r\n---\r\n# Title\r\n"
+	updated, err := setFrontmatterFields([]byte(content), []frontmatterField{
+		{Key: "status", Value: "complete"},
+		{Key: "completedAt", Value: "2026-01-27"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "---\r\nstatus: complete\r\nowner: pm\r\ncompletedAt: \"2026-01-27\"\r\n---\r\n# Title\r\n"
+	if string(updated) != want {
+		t.Errorf("Unexpected content %q, want %q", updated, want)
+	}
+}
+
+func setupWriteTest(t *testing.T) (*ArtifactService, string, string) {
+	t.Helper()
+	svc, id := setupContentTest(t, "---\nstatus: in-progress\nworkflowType: prd\n---\n# Product Requirements\n\nDraft.\n")
+	artifact, err := svc.GetArtifact(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc, id, ArtifactETag(artifact)
+}
+
+func TestWriteContent_RequiresCurrentETag(t *testing.T) {
+	svc, id, etag := setupWriteTest(t)
+
+	if _, err := svc.WriteContent(id, []byte("# New\n"), ""); !isArtifactErr(err, ErrCodeETagRequired) {
+		t.Errorf("Expected etag_required, got %v", err)
+	}
+	if _, err := svc.WriteContent(id, []byte("# New\n"), `"1-1"`); !isArtifactErr(err, ErrCodeETagMismatch) {
+		t.Errorf("Expected etag_mismatch, got %v", err)
+	}
+
+	artifact, err := svc.WriteContent(id, []byte("# Product Requirements\n\nFinal text.\n"), etag)
+	if err != nil {
+		t.Fatalf("WriteContent error: %v", err)
+	}
+	if ArtifactETag(artifact) == etag {
+		t.Errorf("Expected a new ETag after a write of a different size")
+	}
+	content, _ := svc.GetRawContent(id)
+	if string(content) != "# Product Requirements\n\nFinal text.\n" {
+		t.Errorf("Unexpected content %q", content)
+	}
+	if _, err := svc.WriteContent(id, []byte("# Stale\n"), etag); !isArtifactErr(err, ErrCodeETagMismatch) {
+		t.Errorf("Expected the old ETag to be rejected, got %v", err)
+	}
+}
+
+func TestUpdateFrontmatter_UpdatesRegistry(t *testing.T) {
+	svc, id, etag := setupWriteTest(t)
+
+	status := types.ArtifactStatusComplete
+	completedAt := "2026-02-01"
+	artifact, err := svc.UpdateFrontmatter(id, types.ArtifactFrontmatterUpdate{
+		Status:         &status,
+		StepsCompleted: []interface{}{"1", "2"},
+		CompletedAt:    &completedAt,
+	}, etag)
+	if err != nil {
+		t.Fatalf("UpdateFrontmatter error: %v", err)
+	}
+	if artifact.Status != types.ArtifactStatusComplete || artifact.CompletedAt == nil || *artifact.CompletedAt != completedAt {
+		t.Errorf("Registry not refreshed: %+v", artifact)
+	}
+	if len(artifact.StepsCompleted) != 2 {
+		t.Errorf("StepsCompleted = %v", artifact.StepsCompleted)
+	}
+
+	content, _ := svc.GetRawContent(id)
+	if !strings.HasSuffix(string(content), "---\n# Product Requirements\n\nDraft.\n") {
+		t.Errorf("Body changed: %q", content)
+	}
+}
+
+func TestUpdateFrontmatter_RejectsInvalidStatus(t *testing.T) {
+	svc, id, etag := setupWriteTest(t)
+
+	status := "finished"
+	if _, err := svc.UpdateFrontmatter(id, types.ArtifactFrontmatterUpdate{Status: &status}, etag); !isArtifactErr(err, ErrCodeInvalidArtifact) {
+		t.Errorf("Expected invalid_artifact, got %v", err)
+	}
+	if _, err := svc.UpdateFrontmatter(id, types.ArtifactFrontmatterUpdate{}, etag); !isArtifactErr(err, ErrCodeInvalidArtifact) {
+		t.Errorf("Expected invalid_artifact for an empty update, got %v", err)
+	}
+}
+
+func TestCreateArtifact_PlacesByType(t *testing.T) {
+	svc, _, _ := setupWriteTest(t)
+
+	tests := []struct {
+		req      types.CreateArtifactRequest
+		wantPath string
+	}{
+		{types.CreateArtifactRequest{Type: types.ArtifactTypeArchitecture, Content: "# Architecture\n"}, "_bmad-output/planning-artifacts/architecture.md"},
+		{types.CreateArtifactRequest{Type: types.ArtifactTypeStories, Filename: "1-2-login", Content: "# Story\n"}, "_bmad-output/implementation-artifacts/1-2-login.md"},
+		{types.CreateArtifactRequest{Type: types.ArtifactTypeResearch, Filename: "market.md", Content: "# Market\n"}, "_bmad-output/planning-artifacts/research/market.md"},
+		{types.CreateArtifactRequest{Type: types.ArtifactTypeProjectContext, Content: "# Context\n"}, "_bmad-output/project-context.md"},
+	}
+	for _, tt := range tests {
+		artifact, err := svc.CreateArtifact(tt.req)
+		if err != nil {
+			t.Fatalf("CreateArtifact(%s) error: %v", tt.req.Type, err)
+		}
+		if artifact.Path != tt.wantPath {
+			t.Errorf("Path = %s, want %s", artifact.Path, tt.wantPath)
+		}
+		if artifact.Type != tt.req.Type {
+			t.Errorf("Type = %s, want %s", artifact.Type, tt.req.Type)
+		}
+	}
+}
+
+func TestCreateArtifact_Rejections(t *testing.T) {
+	svc, _, _ := setupWriteTest(t)
+
+	if _, err := svc.CreateArtifact(types.CreateArtifactRequest{Type: types.ArtifactTypePRD}); !isArtifactErr(err, ErrCodeArtifactExists) {
+		t.Errorf("Expected artifact_exists, got %v", err)
+	}
+	if _, err := svc.CreateArtifact(types.CreateArtifactRequest{Type: types.ArtifactTypeStories, Filename: "login.md"}); !isArtifactErr(err, ErrCodeInvalidArtifact) {
+		t.Errorf("Expected invalid_artifact for a story name, got %v", err)
+	}
+	if _, err := svc.CreateArtifact(types.CreateArtifactRequest{Type: types.ArtifactTypePRD, Filename: "../escape.md"}); !isArtifactErr(err, ErrCodeInvalidArtifact) {
+		t.Errorf("Expected invalid_artifact for a path, got %v", err)
+	}
+	if _, err := svc.CreateArtifact(types.CreateArtifactRequest{Type: "novel"}); !isArtifactErr(err, ErrCodeInvalidArtifact) {
+		t.Errorf("Expected invalid_artifact for an unknown type, got %v", err)
+	}
+}
+
+func TestConsumeOwnWrite(t *testing.T) {
+	svc, id, etag := setupWriteTest(t)
+	artifact, err := svc.WriteContent(id, []byte("# Mine\n"), etag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, _ := svc.absArtifactPath(artifact)
+
+	if !svc.ConsumeOwnWrite(path) {
+		t.Error("Expected the service's own write to be recognized")
+	}
+	if svc.ConsumeOwnWrite(path) {
+		t.Error("Expected the match to be consumed")
+	}
+
+	artifact, _ = svc.WriteContent(id, []byte("# Mine again\n"), ArtifactETag(artifact))
+	if err := os.WriteFile(path, []byte("# Edited elsewhere\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if svc.ConsumeOwnWrite(path) {
+		t.Error("Expected an external edit not to be treated as an echo")
+	}
+}
+
+func isArtifactErr(err error, code string) bool {
+	svcErr, ok := err.(*ArtifactServiceError)
+	return ok && svcErr.Code == code
+}
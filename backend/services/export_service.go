@@ -0,0 +1,404 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"bmad-studio/backend/types"
+)
+
+// ExportServiceError represents a structured error from the export service
+type ExportServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *ExportServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for export service
+const (
+	ErrCodeExportConfigNotLoaded = "config_not_loaded"
+	ErrCodeInvalidExportRequest  = "invalid_export_request"
+	ErrCodeExportFailed          = "export_failed"
+)
+
+var (
+	// inlineLinkDestRegex matches the "](destination" of an inline link or image
+	inlineLinkDestRegex = regexp.MustCompile(`\]\(\s*(<[^>\n]*>|[^)\s]+)`)
+)
+
+// ExportService combines artifacts into one document for stakeholders: markdown, a standalone
+// HTML page with a table of contents and, optionally, a PDF, delivered together as a zip.
+type ExportService struct {
+	configService   *BMadConfigService
+	artifactService *ArtifactService
+	now             func() time.Time
+}
+
+// NewExportService creates a new ExportService
+func NewExportService(configService *BMadConfigService, artifactService *ArtifactService) *ExportService {
+	return &ExportService{
+		configService:   configService,
+		artifactService: artifactService,
+		now:             time.Now,
+	}
+}
+
+// exportDocument is one artifact of an export
+type exportDocument struct {
+	artifact types.ArtifactResponse
+	body     string            // Markdown without frontmatter
+	anchors  map[string]string // Heading anchors of the artifact on its own -> anchors in the combined document
+	anchor   string            // Anchor of the artifact's first heading
+}
+
+// exportFile is a file of the export zip
+type exportFile struct {
+	name    string
+	content []byte
+}
+
+// Export combines the requested artifacts, sharded ones as their index followed by their
+// shards in Children order. Links between exported artifacts become links within the
+// document; other relative links are made relative to the project root.
+func (s *ExportService) Export(req types.ExportRequest) (*types.ExportBundle, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &ExportServiceError{Code: ErrCodeExportConfigNotLoaded, Message: "BMadConfigService has no config loaded"}
+	}
+	artifacts, err := s.resolve(req)
+	if err != nil {
+		return nil, err
+	}
+	title := exportTitle(req, artifacts, config)
+
+	// Anchors are numbered in document order, so find them before rewriting any link
+	used := map[string]bool{}
+	uniqueSectionID(sectionSlug(title), used)
+	docs := make([]*exportDocument, 0, len(artifacts))
+	byPath := make(map[string]*exportDocument)
+	for _, artifact := range artifacts {
+		content, err := s.artifactService.ReadContent(&artifact)
+		if err != nil {
+			return nil, &ExportServiceError{Code: ErrCodeExportFailed, Message: fmt.Sprintf("Failed to read artifact %s: %v", artifact.ID, err)}
+		}
+		_, body := splitFrontmatter(content)
+		doc := &exportDocument{
+			artifact: artifact,
+			body:     strings.TrimSpace(strings.ReplaceAll(string(body), "\r\n", "\n")),
+			anchors:  make(map[string]string),
+		}
+		local := headingIDs(parseMarkdownBlocks(doc.body, map[string]bool{}))
+		if len(local) == 0 {
+			// Give every artifact a heading to link to
+			doc.body = "# " + artifact.Name + "\n\n" + doc.body
+			local = headingIDs(parseMarkdownBlocks(doc.body, map[string]bool{}))
+		}
+		global := headingIDs(parseMarkdownBlocks(doc.body, used))
+		for i, id := range local {
+			doc.anchors[id] = global[i]
+		}
+		doc.anchor = global[0]
+
+		docs = append(docs, doc)
+		byPath[artifact.Path] = doc
+		if artifact.IsSharded {
+			byPath[path.Dir(artifact.Path)] = doc // Links to the shard folder
+		}
+	}
+
+	var combined strings.Builder
+	combined.WriteString("# " + title + "\n")
+	for _, doc := range docs {
+		combined.WriteString("\n")
+		combined.WriteString(rewriteMarkdownLinks(doc.body, func(dest string) string {
+			return exportLink(doc, dest, byPath)
+		}))
+		combined.WriteString("\n")
+	}
+	markdown := combined.String()
+	blocks := parseMarkdownBlocks(markdown, map[string]bool{})
+
+	base := sectionSlug(title)
+	files := []exportFile{
+		{base + ".md", []byte(markdown)},
+		{base + ".html", []byte(renderExportHTML(title, blocks))},
+	}
+	if req.IncludePDF {
+		pdf := newPDFDocument()
+		pdf.addBlocks(blocks, pdfMargin, pdfPageWidth-2*pdfMargin)
+		content, err := pdf.bytes(title)
+		if err != nil {
+			return nil, &ExportServiceError{Code: ErrCodeExportFailed, Message: fmt.Sprintf("Failed to render PDF: %v", err)}
+		}
+		files = append(files, exportFile{base + ".pdf", content})
+	}
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, file := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: s.now()})
+		if err == nil {
+			_, err = w.Write(file.content)
+		}
+		if err != nil {
+			return nil, &ExportServiceError{Code: ErrCodeExportFailed, Message: fmt.Sprintf("Failed to write %s: %v", file.name, err)}
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, &ExportServiceError{Code: ErrCodeExportFailed, Message: fmt.Sprintf("Failed to write zip: %v", err)}
+	}
+
+	ids := make([]string, len(artifacts))
+	for i, artifact := range artifacts {
+		ids[i] = artifact.ID
+	}
+	return &types.ExportBundle{Filename: base + ".zip", Content: archive.Bytes(), ArtifactIDs: ids}, nil
+}
+
+// resolve returns the artifacts of an export in document order, each sharded artifact followed by its shards.
+func (s *ExportService) resolve(req types.ExportRequest) ([]types.ArtifactResponse, error) {
+	if (len(req.ArtifactIDs) == 0) == (req.Phase == nil) {
+		return nil, &ExportServiceError{Code: ErrCodeInvalidExportRequest, Message: "Exactly one of artifact_ids and phase must be set"}
+	}
+
+	var roots []types.ArtifactResponse
+	if req.Phase != nil {
+		all, err := s.artifactService.GetArtifacts()
+		if err != nil {
+			return nil, err
+		}
+		for _, artifact := range all {
+			if artifact.Phase == *req.Phase && artifact.ParentID == nil {
+				roots = append(roots, artifact)
+			}
+		}
+		if len(roots) == 0 {
+			return nil, &ExportServiceError{Code: ErrCodeInvalidExportRequest, Message: fmt.Sprintf("Phase %d has no artifacts", *req.Phase)}
+		}
+		sort.SliceStable(roots, func(i, j int) bool { return roots[i].Path < roots[j].Path })
+	} else {
+		for _, id := range req.ArtifactIDs {
+			artifact, err := s.artifactService.GetArtifact(id)
+			if err != nil {
+				return nil, err
+			}
+			roots = append(roots, *artifact)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var artifacts []types.ArtifactResponse
+	add := func(artifact types.ArtifactResponse) {
+		if !seen[artifact.ID] {
+			seen[artifact.ID] = true
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	for _, root := range roots {
+		add(root)
+		for _, childID := range root.Children {
+			child, err := s.artifactService.GetArtifact(childID)
+			if err != nil {
+				log.Printf("Warning: Skipping missing shard %s of %s: %v", childID, root.ID, err)
+				continue
+			}
+			add(*child)
+		}
+	}
+	return artifacts, nil
+}
+
+// exportTitle returns the requested title, else the phase name, the name of a single
+// artifact or the project name.
+func exportTitle(req types.ExportRequest, artifacts []types.ArtifactResponse, config *types.BMadConfig) string {
+	switch {
+	case strings.TrimSpace(req.Title) != "":
+		return strings.TrimSpace(req.Title)
+	case req.Phase != nil && artifacts[0].PhaseName != "":
+		return artifacts[0].PhaseName
+	case req.Phase != nil:
+		return fmt.Sprintf("Phase %d", *req.Phase)
+	case len(req.ArtifactIDs) == 1:
+		return artifacts[0].Name
+	case config.ProjectName != "":
+		return config.ProjectName
+	}
+	return "BMAD Export"
+}
+
+// headingIDs returns the anchors of the headings of blocks in document order
+func headingIDs(blocks []mdBlock) []string {
+	var ids []string
+	for _, block := range blocks {
+		switch block.kind {
+		case mdHeading:
+			ids = append(ids, block.id)
+		case mdQuote:
+			ids = append(ids, headingIDs(block.blocks)...)
+		case mdList:
+			for _, item := range block.items {
+				ids = append(ids, headingIDs(item.blocks)...)
+			}
+		}
+	}
+	return ids
+}
+
+// exportLink rewrites a link destination of doc for the combined document.
+func exportLink(doc *exportDocument, dest string, byPath map[string]*exportDocument) string {
+	if fragment, ok := strings.CutPrefix(dest, "#"); ok {
+		if anchor, found := doc.anchors[fragment]; found {
+			return "#" + anchor
+		}
+		return dest
+	}
+	if dest == "" || strings.HasPrefix(dest, "/") || hasURLScheme(dest) {
+		return dest
+	}
+
+	target, fragment, hasFragment := strings.Cut(dest, "#")
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	resolved := path.Clean(path.Join(path.Dir(doc.artifact.Path), target))
+	if other := byPath[resolved]; other != nil {
+		if anchor, found := other.anchors[fragment]; found && hasFragment {
+			return "#" + anchor
+		}
+		return "#" + other.anchor
+	}
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return dest // Outside the project: leave as written
+	}
+
+	link := (&url.URL{Path: resolved}).EscapedPath()
+	if hasFragment {
+		link += "#" + fragment
+	}
+	return link
+}
+
+// rewriteMarkdownLinks passes the destination of every inline link, image and link
+// definition of a markdown body through rewrite. Code blocks and code spans are left alone.
+func rewriteMarkdownLinks(body string, rewrite func(dest string) string) string {
+	replace := func(dest string) string {
+		if strings.HasPrefix(dest, "<") && strings.HasSuffix(dest, ">") {
+			return "<" + rewrite(dest[1:len(dest)-1]) + ">"
+		}
+		return rewrite(dest)
+	}
+
+	lines := strings.Split(body, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if isCodeFence(trimmed) {
+			fence = trimmed[:3]
+			continue
+		}
+		if m := linkDefinitionRegex.FindStringSubmatchIndex(line); m != nil {
+			lines[i] = line[:m[4]] + replace(line[m[4]:m[5]]) + line[m[5]:]
+			continue
+		}
+
+		// Even segments are outside code spans
+		segments := strings.Split(line, "`")
+		for j := 0; j < len(segments); j += 2 {
+			segments[j] = inlineLinkDestRegex.ReplaceAllStringFunc(segments[j], func(match string) string {
+				m := inlineLinkDestRegex.FindStringSubmatchIndex(match)
+				return match[:m[2]] + replace(match[m[2]:m[3]])
+			})
+		}
+		lines[i] = strings.Join(segments, "`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// exportStyle is the stylesheet of exported HTML
+const exportStyle = `body { margin: 0; font: 16px/1.6 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; }
+nav.toc { position: fixed; top: 0; bottom: 0; left: 0; width: 280px; overflow-y: auto; padding: 24px; box-sizing: border-box; background: #f6f8fa; border-right: 1px solid #d0d7de; font-size: 14px; }
+nav.toc h2 { margin-top: 0; font-size: 16px; }
+nav.toc ul { list-style: none; padding-left: 14px; }
+nav.toc > ul { padding-left: 0; }
+nav.toc a { color: #1f2328; text-decoration: none; }
+nav.toc a:hover { text-decoration: underline; }
+main { max-width: 860px; margin-left: 280px; padding: 32px 48px; }
+h1, h2 { border-bottom: 1px solid #d8dee4; padding-bottom: .3em; }
+a { color: #0969da; }
+code { background: #eff1f3; padding: .2em .4em; border-radius: 6px; font-size: 85%; }
+pre { background: #f6f8fa; padding: 16px; border-radius: 6px; overflow: auto; }
+pre code { background: none; padding: 0; }
+blockquote { margin: 0; padding: 0 1em; color: #59636e; border-left: .25em solid #d0d7de; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #d0d7de; padding: 6px 13px; }
+li.task { list-style: none; }
+img { max-width: 100%; }
+@media print { nav.toc { display: none; } main { margin-left: 0; max-width: none; } }
+`
+
+// renderExportHTML renders a combined document as a standalone HTML page with a table of contents.
+func renderExportHTML(title string, blocks []mdBlock) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	b.WriteString("<title>" + html.EscapeString(title) + "</title>\n<style>\n" + exportStyle + "</style>\n</head>\n<body>\n")
+
+	b.WriteString("<nav class=\"toc\">\n<h2>Contents</h2>\n")
+	var headings []mdBlock
+	for i, block := range blocks {
+		if block.kind == mdHeading && block.level <= 3 && i > 0 { // The first heading is the title
+			headings = append(headings, block)
+		}
+	}
+	writeTableOfContents(&b, headings)
+	b.WriteString("</nav>\n<main>\n")
+	renderMarkdownHTML(&b, blocks)
+	b.WriteString("</main>\n</body>\n</html>\n")
+	return b.String()
+}
+
+// writeTableOfContents writes headings as nested lists of links. A heading more than one
+// level deeper than the one before it is nested one level only.
+func writeTableOfContents(b *strings.Builder, headings []mdBlock) {
+	var levels []int // Heading level of each open list
+	for _, heading := range headings {
+		if len(levels) == 0 || heading.level > levels[len(levels)-1] {
+			b.WriteString("<ul>\n")
+			levels = append(levels, heading.level)
+		} else {
+			b.WriteString("</li>\n")
+			for len(levels) > 1 && heading.level <= levels[len(levels)-2] {
+				b.WriteString("</ul>\n</li>\n")
+				levels = levels[:len(levels)-1]
+			}
+			levels[len(levels)-1] = min(levels[len(levels)-1], heading.level)
+		}
+		b.WriteString(`<li><a href="#` + html.EscapeString(heading.id) + `">` + html.EscapeString(inlinePlainText(heading.text)) + "</a>")
+	}
+	if len(levels) == 0 {
+		return
+	}
+	b.WriteString("</li>\n")
+	for range levels[1:] {
+		b.WriteString("</ul>\n</li>\n")
+	}
+	b.WriteString("</ul>\n")
+}
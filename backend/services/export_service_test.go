@@ -0,0 +1,174 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+// setupExportTest writes a PRD and a sharded architecture document and returns the
+// export service and the artifact IDs by project-relative path.
+func setupExportTest(t *testing.T) (*ExportService, map[string]string) {
+	t.Helper()
+	configService, tmpDir := setupArtifactTestConfig(t)
+	planningDir := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts")
+	files := map[string]string{
+		"prd.md": "---\nstatus: complete\n---\n# PRD\n\n## Goals\n\nSee the [architecture](architecture/index.md), the [database](architecture/decisions.md#database) " +
+			"choice and [goals](#goals). ![flow](diagrams/flow%20chart.png) [Site](https://example.com)\n\n```\n[kept](architecture/index.md)\n```\n",
+		"architecture/index.md":     "# Architecture\n\n- [Decisions](decisions.md)\n- [Overview](./overview.md)\n",
+		"architecture/decisions.md": "## Database\n\nPostgres, per the [PRD](../prd.md). `[code](../prd.md)`\n\n## Goals\n\nBack to [goals](#goals).\n\n[ref]: ../prd.md#goals\n",
+		"architecture/overview.md":  "Plain text without a heading.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(planningDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	artifactService := NewArtifactService(configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	artifacts, err := artifactService.GetArtifacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]string)
+	for _, artifact := range artifacts {
+		ids[strings.TrimPrefix(artifact.Path, "_bmad-output/planning-artifacts/")] = artifact.ID
+	}
+	return NewExportService(configService, artifactService), ids
+}
+
+// unzipExport returns the files of an export bundle by name
+func unzipExport(t *testing.T, bundle *types.ExportBundle) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(bundle.Content), int64(len(bundle.Content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(data)
+	}
+	return files
+}
+
+func TestExport_CombinesArtifactsAndRewritesLinks(t *testing.T) {
+	svc, ids := setupExportTest(t)
+
+	bundle, err := svc.Export(types.ExportRequest{ArtifactIDs: []string{ids["prd.md"], ids["architecture/index.md"]}, IncludePDF: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantOrder := []string{ids["prd.md"], ids["architecture/index.md"], ids["architecture/decisions.md"], ids["architecture/overview.md"]}
+	if strings.Join(bundle.ArtifactIDs, ",") != strings.Join(wantOrder, ",") {
+		t.Errorf("expected shards after their index in Children order, got %v", bundle.ArtifactIDs)
+	}
+	if bundle.Filename != "test-project.zip" {
+		t.Errorf("expected the project name as file name, got %q", bundle.Filename)
+	}
+
+	files := unzipExport(t, bundle)
+	markdown := files["test-project.md"]
+	for _, want := range []string{
+		"# test-project\n\n# PRD\n",
+		"[architecture](#architecture)",
+		"[database](#database)",
+		"[goals](#goals)",
+		"![flow](_bmad-output/planning-artifacts/diagrams/flow%20chart.png)",
+		"[Site](https://example.com)",
+		"[kept](architecture/index.md)", // Code blocks are left alone
+		"[PRD](#prd)",
+		"`[code](../prd.md)`",
+		"Back to [goals](#goals-1)",
+		"[ref]: #goals\n",
+		"[Overview](#overview)",
+		"# Overview\n\nPlain text without a heading.",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected combined markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "status: complete") {
+		t.Error("expected frontmatter to be dropped")
+	}
+
+	html := files["test-project.html"]
+	for _, want := range []string{
+		"<title>test-project</title>",
+		`<nav class="toc">`,
+		`<li><a href="#prd">PRD</a>`,
+		`<li><a href="#goals-1">Goals</a></li>`,
+		`<h2 id="goals-1">Goals</h2>`,
+		`<a href="#database">database</a>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected HTML to contain %q", want)
+		}
+	}
+	if !strings.HasPrefix(files["test-project.pdf"], "%PDF-") {
+		t.Error("expected a PDF in the bundle")
+	}
+}
+
+func TestExport_Phase(t *testing.T) {
+	svc, ids := setupExportTest(t)
+	architecture, err := svc.artifactService.GetArtifact(ids["architecture/index.md"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := svc.Export(types.ExportRequest{Phase: &architecture.Phase, Title: "Solution Design"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundle.ArtifactIDs) != 3 || bundle.ArtifactIDs[0] != ids["architecture/index.md"] {
+		t.Errorf("expected only the architecture and its shards, got %v", bundle.ArtifactIDs)
+	}
+	files := unzipExport(t, bundle)
+	if _, ok := files["solution-design.pdf"]; ok || len(files) != 2 {
+		t.Errorf("expected markdown and HTML only, got %d files", len(files))
+	}
+	// The PRD is not part of this export, so links to it point into the project
+	if !strings.Contains(files["solution-design.md"], "[PRD](_bmad-output/planning-artifacts/prd.md)") {
+		t.Errorf("expected a project-relative link to the PRD, got:\n%s", files["solution-design.md"])
+	}
+}
+
+func TestExport_Errors(t *testing.T) {
+	svc, _ := setupExportTest(t)
+
+	var exportErr *ExportServiceError
+	if _, err := svc.Export(types.ExportRequest{}); !errors.As(err, &exportErr) || exportErr.Code != ErrCodeInvalidExportRequest {
+		t.Errorf("expected invalid_export_request without artifacts, got %v", err)
+	}
+	phase := 9
+	if _, err := svc.Export(types.ExportRequest{Phase: &phase}); !errors.As(err, &exportErr) || exportErr.Code != ErrCodeInvalidExportRequest {
+		t.Errorf("expected invalid_export_request for an empty phase, got %v", err)
+	}
+	var artifactErr *ArtifactServiceError
+	if _, err := svc.Export(types.ExportRequest{ArtifactIDs: []string{"missing"}}); !errors.As(err, &artifactErr) || artifactErr.Code != ErrCodeArtifactNotFound {
+		t.Errorf("expected artifact_not_found, got %v", err)
+	}
+}
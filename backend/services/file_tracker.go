@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"bmad-studio/backend/types"
+)
+
+// FileTrackerSystem is the tracking_system that selects the FileTracker
+const FileTrackerSystem = "file-tracker"
+
+// projectKeyRegex matches the project keys FileTracker accepts as file names
+var projectKeyRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// FileTracker is an IssueTracker kept in one JSON file per project key. It stands in for a
+// hosted tracker locally and in tests: editing an issue's status in the file is a change
+// made in the tracker.
+type FileTracker struct {
+	mu  sync.Mutex
+	dir string
+	now func() time.Time
+}
+
+// fileTrackerProject is the content of a FileTracker project file
+type fileTrackerProject struct {
+	Issues []types.TrackerIssue `json:"issues"`
+}
+
+// NewFileTracker creates a FileTracker that persists to ~/bmad-studio/tracker.
+func NewFileTracker() (*FileTracker, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, "bmad-studio", "tracker")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &FileTracker{dir: dir, now: time.Now}, nil
+}
+
+// NewFileTrackerWithDir creates a FileTracker with a custom directory (used for testing).
+func NewFileTrackerWithDir(dir string) *FileTracker {
+	return &FileTracker{dir: dir, now: time.Now}
+}
+
+// ListIssues implements IssueTracker.
+func (t *FileTracker) ListIssues(ctx context.Context, projectKey string) ([]types.TrackerIssue, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	project, err := t.load(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	return project.Issues, nil
+}
+
+// CreateIssue implements IssueTracker. Issues are numbered "<project key>-<n>".
+func (t *FileTracker) CreateIssue(ctx context.Context, projectKey string, issue types.TrackerIssue) (*types.TrackerIssue, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	project, err := t.load(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(project.Issues))
+	for _, existing := range project.Issues {
+		taken[existing.ID] = true
+	}
+	for n := len(project.Issues) + 1; ; n++ {
+		if issue.ID = fmt.Sprintf("%s-%d", projectKey, n); !taken[issue.ID] {
+			break
+		}
+	}
+	issue.UpdatedAt = types.Timestamp(t.now())
+
+	project.Issues = append(project.Issues, issue)
+	if err := t.save(projectKey, project); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// UpdateIssueStatus implements IssueTracker.
+func (t *FileTracker) UpdateIssueStatus(ctx context.Context, projectKey, issueID, status string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	project, err := t.load(projectKey)
+	if err != nil {
+		return err
+	}
+	for i := range project.Issues {
+		if project.Issues[i].ID == issueID {
+			project.Issues[i].Status = status
+			project.Issues[i].UpdatedAt = types.Timestamp(t.now())
+			return t.save(projectKey, project)
+		}
+	}
+	return fmt.Errorf("issue %s not found", issueID)
+}
+
+// projectPath returns the file of a project key
+func (t *FileTracker) projectPath(projectKey string) (string, error) {
+	if !projectKeyRegex.MatchString(projectKey) {
+		return "", fmt.Errorf("invalid project key %q", projectKey)
+	}
+	return filepath.Join(t.dir, projectKey+".json"), nil
+}
+
+func (t *FileTracker) load(projectKey string) (*fileTrackerProject, error) {
+	path, err := t.projectPath(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &fileTrackerProject{Issues: []types.TrackerIssue{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var project fileTrackerProject
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return &project, nil
+}
+
+func (t *FileTracker) save(projectKey string, project *fileTrackerProject) error {
+	path, err := t.projectPath(projectKey)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(project, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
@@ -43,6 +43,13 @@ type debounceEntry struct {
 	hadDelete bool // tracks if a delete occurred in this debounce window
 }
 
+// ArtifactChangeListener is notified after the watcher has applied an artifact change to the
+// registry. Callbacks run on the watcher's debounce goroutines and must not block for long.
+type ArtifactChangeListener interface {
+	ArtifactChanged(artifact *types.ArtifactResponse)
+	ArtifactRemoved(artifact *types.ArtifactResponse)
+}
+
 // FileWatcherService watches the output folder for file changes
 type FileWatcherService struct {
 	mu                    sync.RWMutex
@@ -56,6 +63,7 @@ type FileWatcherService struct {
 	done                  chan struct{}
 	running               bool
 	watchedDirs           map[string]bool
+	listeners             []ArtifactChangeListener
 }
 
 // NewFileWatcherService creates a new FileWatcherService instance
@@ -76,6 +84,33 @@ func NewFileWatcherService(
 	}
 }
 
+// AddListener registers a listener for artifact changes detected by the watcher.
+func (s *FileWatcherService) AddListener(l ArtifactChangeListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, l)
+}
+
+// notifyChanged calls ArtifactChanged on every registered listener.
+func (s *FileWatcherService) notifyChanged(artifact *types.ArtifactResponse) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, l := range listeners {
+		l.ArtifactChanged(artifact)
+	}
+}
+
+// notifyRemoved calls ArtifactRemoved on every registered listener.
+func (s *FileWatcherService) notifyRemoved(artifact *types.ArtifactResponse) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, l := range listeners {
+		l.ArtifactRemoved(artifact)
+	}
+}
+
 // Start initializes the file watcher and begins watching the output folder
 func (s *FileWatcherService) Start() error {
 	s.mu.Lock()
@@ -366,6 +401,11 @@ func (s *FileWatcherService) handleCreate(path string) {
 	}
 
 	if artifact != nil {
+		s.notifyChanged(artifact)
+		if s.artifactService.ConsumeOwnWrite(path) {
+			log.Printf("Skipping artifact:created echo for studio write to %s", artifact.ID)
+			return
+		}
 		s.hub.BroadcastEvent(types.NewArtifactCreatedEvent(artifact))
 		log.Printf("Broadcast artifact:created for %s", artifact.ID)
 	}
@@ -380,6 +420,11 @@ func (s *FileWatcherService) handleModify(path string) {
 	}
 
 	if artifact != nil {
+		s.notifyChanged(artifact)
+		if s.artifactService.ConsumeOwnWrite(path) {
+			log.Printf("Skipping artifact:updated echo for studio write to %s", artifact.ID)
+			return
+		}
 		s.hub.BroadcastEvent(types.NewArtifactUpdatedEvent(artifact))
 		log.Printf("Broadcast artifact:updated for %s", artifact.ID)
 	}
@@ -394,6 +439,7 @@ func (s *FileWatcherService) handleDelete(path string) {
 	}
 
 	if artifact != nil {
+		s.notifyRemoved(artifact)
 		s.hub.BroadcastEvent(types.NewArtifactDeletedEvent(artifact.ID, artifact.Path))
 		log.Printf("Broadcast artifact:deleted for %s", artifact.ID)
 	}
@@ -428,6 +474,7 @@ func (s *FileWatcherService) handleDirectoryDelete(dirPath string) {
 				continue
 			}
 			if removed != nil {
+				s.notifyRemoved(removed)
 				event := types.NewArtifactDeletedEvent(removed.ID, removed.Path)
 				s.hub.BroadcastEvent(event)
 				log.Printf("Broadcast artifact:deleted for %s (directory removed)", removed.ID)
@@ -4,10 +4,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"bmad-studio/backend/api/websocket"
+	"bmad-studio/backend/types"
 )
 
 func setupFileWatcherTest(t *testing.T) (*FileWatcherService, *BMadConfigService, *websocket.Hub, string) {
@@ -446,3 +448,43 @@ func TestFileWatcherGracefulShutdown(t *testing.T) {
 		t.Error("expected file watcher to not be running after stop")
 	}
 }
+
+// recordingListener records artifact change notifications.
+type recordingListener struct {
+	mu      sync.Mutex
+	changed []string
+	removed []string
+}
+
+func (l *recordingListener) ArtifactChanged(artifact *types.ArtifactResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.changed = append(l.changed, artifact.ID)
+}
+
+func (l *recordingListener) ArtifactRemoved(artifact *types.ArtifactResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.removed = append(l.removed, artifact.ID)
+}
+
+func TestFileWatcherNotifiesListeners(t *testing.T) {
+	fileWatcher, configService, hub, _ := setupFileWatcherTest(t)
+	defer hub.Stop()
+
+	listener := &recordingListener{}
+	fileWatcher.AddListener(listener)
+
+	testFile := filepath.Join(configService.GetConfig().OutputFolder, "planning-artifacts", "prd.md")
+	if err := os.WriteFile(testFile, []byte("# PRD\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fileWatcher.handleCreate(testFile)
+	fileWatcher.handleModify(testFile)
+	fileWatcher.handleDelete(testFile)
+
+	if len(listener.changed) != 2 || len(listener.removed) != 1 {
+		t.Errorf("Expected 2 change and 1 removal notifications, got %v / %v", listener.changed, listener.removed)
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"errors"
+
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/types"
+)
+
+// presetCapabilities is the widest parameter range accepted by any provider.
+// Presets are provider-agnostic; per-model limits are enforced when a request is sent.
+var presetCapabilities = providers.ParameterCapabilities{
+	MaxTemperature:   2.0,
+	SupportsTopP:     true,
+	SupportsTopK:     true,
+	SupportsSeed:     true,
+	MaxStopSequences: 16,
+}
+
+// GenerationParamsFromPreset converts a stored preset to provider request parameters.
+func GenerationParamsFromPreset(p *types.GenerationPreset) providers.GenerationParams {
+	if p == nil {
+		return providers.GenerationParams{}
+	}
+	return providers.GenerationParams{
+		Temperature:   p.Temperature,
+		TopP:          p.TopP,
+		TopK:          p.TopK,
+		StopSequences: p.StopSequences,
+		Seed:          p.Seed,
+	}
+}
+
+// ResolveGenerationParams layers generation parameters from least to most specific:
+// global defaults, the agent's defaults, the session preset, then per-request overrides.
+func ResolveGenerationParams(settings types.Settings, agentID string, session *types.Session, override providers.GenerationParams) providers.GenerationParams {
+	params := GenerationParamsFromPreset(settings.GenerationDefaults)
+
+	if agentID != "" {
+		if agentPreset, ok := settings.AgentGenerationDefaults[agentID]; ok {
+			params = layerGenerationParams(params, GenerationParamsFromPreset(&agentPreset))
+		}
+	}
+
+	if session != nil {
+		params = layerGenerationParams(params, GenerationParamsFromPreset(session.Generation))
+	}
+
+	return layerGenerationParams(params, override)
+}
+
+// layerGenerationParams merges overlay onto inherited. Temperature and top_p are treated as
+// one sampling choice: when overlay sets either, the other is not inherited, so layers never
+// combine into a pair that providers such as Claude reject.
+func layerGenerationParams(inherited, overlay providers.GenerationParams) providers.GenerationParams {
+	if overlay.Temperature != nil || overlay.TopP != nil {
+		inherited.Temperature = nil
+		inherited.TopP = nil
+	}
+	return inherited.Merge(overlay)
+}
+
+// ValidateGenerationPreset checks that a preset is within the range accepted by at least one provider.
+func ValidateGenerationPreset(p types.GenerationPreset) error {
+	if err := GenerationParamsFromPreset(&p).Validate(presetCapabilities, "a generation preset"); err != nil {
+		var providerErr *providers.ProviderError
+		if errors.As(err, &providerErr) {
+			return errors.New(providerErr.Message)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/types"
+)
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func TestResolveGenerationParams_Precedence(t *testing.T) {
+	settings := types.Settings{
+		GenerationDefaults: &types.GenerationPreset{
+			Temperature:   float64Ptr(0.7),
+			StopSequences: []string{"GLOBAL"},
+		},
+		AgentGenerationDefaults: map[string]types.GenerationPreset{
+			"architect": {Temperature: float64Ptr(0.2)},
+		},
+	}
+	topK := 40
+	session := &types.Session{
+		Generation: &types.GenerationPreset{TopK: &topK},
+	}
+
+	got := ResolveGenerationParams(settings, "architect", session, providers.GenerationParams{})
+	if *got.Temperature != 0.2 {
+		t.Errorf("Temperature = %v, want agent default 0.2", *got.Temperature)
+	}
+	if got.TopK == nil || *got.TopK != 40 {
+		t.Errorf("TopK = %v, want session value 40", got.TopK)
+	}
+	if len(got.StopSequences) != 1 || got.StopSequences[0] != "GLOBAL" {
+		t.Errorf("StopSequences = %v, want global [GLOBAL]", got.StopSequences)
+	}
+
+	got = ResolveGenerationParams(settings, "architect", session, providers.GenerationParams{Temperature: float64Ptr(1.0)})
+	if *got.Temperature != 1.0 {
+		t.Errorf("Temperature = %v, want override 1.0", *got.Temperature)
+	}
+
+	got = ResolveGenerationParams(settings, "pm", nil, providers.GenerationParams{})
+	if *got.Temperature != 0.7 {
+		t.Errorf("Temperature = %v, want global default 0.7 for agent without preset", *got.Temperature)
+	}
+}
+
+func TestResolveGenerationParams_TemperatureAndTopPFromDifferentLayers(t *testing.T) {
+	settings := types.Settings{
+		GenerationDefaults: &types.GenerationPreset{Temperature: float64Ptr(0.7)},
+		AgentGenerationDefaults: map[string]types.GenerationPreset{
+			"architect": {TopP: float64Ptr(0.9)},
+		},
+	}
+
+	got := ResolveGenerationParams(settings, "architect", nil, providers.GenerationParams{})
+	if got.Temperature != nil || got.TopP == nil || *got.TopP != 0.9 {
+		t.Errorf("Expected agent top_p to replace the global temperature, got temperature=%v top_p=%v", got.Temperature, got.TopP)
+	}
+	caps := providers.ParameterCapabilities{MaxTemperature: 1.0, SupportsTopP: true, TemperatureTopPExclusive: true}
+	if err := got.Validate(caps, "claude-sonnet-4-5"); err != nil {
+		t.Errorf("Expected resolved params to be valid for Claude, got %v", err)
+	}
+
+	session := &types.Session{Generation: &types.GenerationPreset{Temperature: float64Ptr(0.3)}}
+	got = ResolveGenerationParams(settings, "architect", session, providers.GenerationParams{})
+	if got.TopP != nil || got.Temperature == nil || *got.Temperature != 0.3 {
+		t.Errorf("Expected session temperature to replace the agent top_p, got temperature=%v top_p=%v", got.Temperature, got.TopP)
+	}
+
+	got = ResolveGenerationParams(settings, "architect", session, providers.GenerationParams{TopP: float64Ptr(0.5), Temperature: float64Ptr(0.4)})
+	if got.Temperature == nil || *got.Temperature != 0.4 || got.TopP == nil || *got.TopP != 0.5 {
+		t.Errorf("Expected both values from a single layer kept, got temperature=%v top_p=%v", got.Temperature, got.TopP)
+	}
+}
+
+func TestResolveGenerationParams_Empty(t *testing.T) {
+	got := ResolveGenerationParams(types.Settings{}, "", nil, providers.GenerationParams{})
+	if !got.IsZero() {
+		t.Errorf("Expected zero params, got %+v", got)
+	}
+}
+
+func TestValidateGenerationPreset(t *testing.T) {
+	topK := 0
+	tests := []struct {
+		name    string
+		preset  types.GenerationPreset
+		wantErr bool
+	}{
+		{"empty", types.GenerationPreset{}, false},
+		{"valid", types.GenerationPreset{Temperature: float64Ptr(1.2), TopP: float64Ptr(0.9)}, false},
+		{"temperature too high", types.GenerationPreset{Temperature: float64Ptr(2.5)}, true},
+		{"top_p too high", types.GenerationPreset{TopP: float64Ptr(1.1)}, true},
+		{"top_k zero", types.GenerationPreset{TopK: &topK}, true},
+		{"empty stop", types.GenerationPreset{StopSequences: []string{""}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateGenerationPreset(tt.preset); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGenerationPreset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
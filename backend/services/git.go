@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gitTimeout bounds every git invocation so a hung repository cannot stall a request
+const gitTimeout = 10 * time.Second
+
+// gitCommit is a commit that touched a file, as reported by git log.
+type gitCommit struct {
+	Hash    string
+	Author  string
+	Email   string
+	Time    time.Time
+	Subject string
+	Path    string   // Repository-relative path of the file in this commit
+	Files   []string // Repository-relative paths of all files the commit changed within the pathspec
+}
+
+// runGit runs git with args in dir and returns its standard output.
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// gitTopLevel returns the root of the git work tree containing dir,
+// or an empty string if dir is not in a repository or git is not installed.
+func gitTopLevel(ctx context.Context, dir string) string {
+	out, err := runGit(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// gitRepoPath resolves the repository root for projectRoot and converts a project-relative
+// path into a repository-relative one. root is empty if the project is not in a repository.
+func gitRepoPath(ctx context.Context, projectRoot, relPath string) (root, repoPath string) {
+	root = gitTopLevel(ctx, projectRoot)
+	if root == "" {
+		return "", ""
+	}
+	return root, repoRelativePath(root, projectRoot, relPath)
+}
+
+// repoRelativePath converts a project-relative path into one relative to the repository root.
+func repoRelativePath(root, projectRoot, relPath string) string {
+	// git reports the top level with symlinks resolved, so resolve the project root the same way
+	base := projectRoot
+	if resolved, err := filepath.EvalSymlinks(projectRoot); err == nil {
+		base = resolved
+	}
+	prefix, err := filepath.Rel(root, base)
+	if err != nil {
+		return relPath
+	}
+	return filepath.ToSlash(filepath.Join(prefix, filepath.FromSlash(relPath)))
+}
+
+// gitLogFormat separates commits with RS and fields with US so subjects cannot break parsing
+const gitLogFormat = "--format=%x1e%H%x1f%an%x1f%ae%x1f%at%x1f%s"
+
+// gitFileLog lists the commits that touched path (relative to root), newest first, following renames.
+func gitFileLog(ctx context.Context, root, path string, limit int) ([]gitCommit, error) {
+	commits, err := gitLog(ctx, root, limit, true, path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range commits {
+		if commits[i].Path == "" {
+			commits[i].Path = path
+		}
+	}
+	return commits, nil
+}
+
+// gitLog lists commits touching pathspec, newest first, with the files each one changed.
+func gitLog(ctx context.Context, root string, limit int, follow bool, pathspec string) ([]gitCommit, error) {
+	args := []string{"-c", "core.quotePath=false", "log", "--name-only", gitLogFormat}
+	if follow {
+		args = append(args, "--follow")
+	}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	out, err := runGit(ctx, root, append(args, "--", pathspec)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseGitLog(out), nil
+}
+
+// parseGitLog parses output produced with gitLogFormat and --name-only.
+// Path is set to the last file listed, which for a single-file log is that file's name at the commit.
+func parseGitLog(out []byte) []gitCommit {
+	var commits []gitCommit
+	for _, record := range strings.Split(string(out), "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		fields := strings.Split(lines[0], "\x1f")
+		if len(fields) != 5 {
+			continue
+		}
+		seconds, _ := strconv.ParseInt(fields[3], 10, 64)
+		commit := gitCommit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Email:   fields[2],
+			Time:    time.Unix(seconds, 0),
+			Subject: fields[4],
+		}
+		for _, line := range lines[1:] {
+			if name := strings.TrimSpace(line); name != "" {
+				commit.Files = append(commit.Files, name)
+				commit.Path = name
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// gitShowFile returns the content of path (relative to root) as of commit.
+func gitShowFile(ctx context.Context, root, commit, path string) ([]byte, error) {
+	return runGit(ctx, root, "show", commit+":"+path)
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"bmad-studio/backend/types"
+)
+
+// GitServiceError represents a structured error from the git service
+type GitServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *GitServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for git service
+const (
+	ErrCodeGitConfigNotLoaded = "config_not_loaded"
+	ErrCodeNotGitRepo         = "not_a_git_repo"
+	ErrCodeInvalidCommit      = "invalid_commit"
+	ErrCodeNothingToCommit    = "nothing_to_commit"
+	ErrCodeGitFailed          = "git_failed"
+)
+
+const (
+	// gitStatusTTL is how long a status snapshot is reused; watcher changes and commits invalidate it sooner.
+	gitStatusTTL = 5 * time.Second
+
+	// gitLastCommitScanLimit caps the commits scanned to find each artifact's last commit.
+	gitLastCommitScanLimit = 1000
+)
+
+// gitFileState is the porcelain status of one file.
+type gitFileState struct {
+	staged    bool
+	dirty     bool
+	untracked bool
+}
+
+// gitSnapshot is the repository state of the output folder at one point in time.
+type gitSnapshot struct {
+	takenAt     time.Time
+	root        string                          // Repository root; empty if not a repository
+	files       map[string]gitFileState         // Repository-relative path -> status, changed files only
+	tracked     map[string]bool                 // Repository-relative paths in the index
+	lastCommits map[string]*types.GitCommitInfo // Repository-relative path -> newest commit touching it
+}
+
+// GitService reports the git state of artifacts and commits them, by shelling out to the git binary.
+// Status is read for the whole output folder at once and cached briefly, so listing artifacts
+// costs three git invocations regardless of how many there are.
+type GitService struct {
+	mu                  sync.Mutex
+	configService       *BMadConfigService
+	artifactService     *ArtifactService
+	snapshot            *gitSnapshot
+	lastCommitScanLimit int
+}
+
+// NewGitService creates a new GitService.
+func NewGitService(configService *BMadConfigService, artifactService *ArtifactService) *GitService {
+	return &GitService{
+		configService:       configService,
+		artifactService:     artifactService,
+		lastCommitScanLimit: gitLastCommitScanLimit,
+	}
+}
+
+// ArtifactChanged drops the cached status so the next read sees the change.
+func (s *GitService) ArtifactChanged(artifact *types.ArtifactResponse) {
+	s.invalidate()
+}
+
+// ArtifactRemoved drops the cached status so the next read sees the deletion.
+func (s *GitService) ArtifactRemoved(artifact *types.ArtifactResponse) {
+	s.invalidate()
+}
+
+func (s *GitService) invalidate() {
+	s.mu.Lock()
+	s.snapshot = nil
+	s.mu.Unlock()
+}
+
+// Annotate sets the Git field of each artifact. Artifacts are left unchanged if the
+// project is not a git repository or git cannot be run.
+func (s *GitService) Annotate(ctx context.Context, artifacts []types.ArtifactResponse) {
+	snap, err := s.currentSnapshot(ctx)
+	if err != nil {
+		log.Printf("Warning: Failed to read git status: %v", err)
+		return
+	}
+	if snap.root == "" {
+		return
+	}
+
+	config := s.configService.GetConfig()
+	for i := range artifacts {
+		repoPath := repoRelativePath(snap.root, config.ProjectRoot, artifacts[i].Path)
+		state := snap.files[repoPath]
+		lastCommit := snap.lastCommits[repoPath]
+		artifacts[i].Git = &types.ArtifactGitStatus{
+			Tracked:    snap.tracked[repoPath],
+			Dirty:      state.dirty,
+			Staged:     state.staged,
+			Untracked:  state.untracked,
+			LastCommit: lastCommit,
+		}
+	}
+}
+
+// RepoStatus returns the current branch, HEAD commit and number of uncommitted files in the output folder.
+func (s *GitService) RepoStatus(ctx context.Context) (*types.GitRepoStatus, error) {
+	snap, err := s.currentSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if snap.root == "" {
+		return &types.GitRepoStatus{IsRepo: false}, nil
+	}
+
+	status := &types.GitRepoStatus{IsRepo: true, Uncommitted: len(snap.files)}
+	if out, err := runGit(ctx, snap.root, "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+		status.Branch = strings.TrimSpace(string(out))
+	}
+	if head, err := s.headCommit(ctx, snap.root); err == nil {
+		status.Head = head
+	}
+	return status, nil
+}
+
+// Commit stages the given artifacts and commits only those files with message,
+// leaving anything else already staged untouched.
+func (s *GitService) Commit(ctx context.Context, req types.GitCommitRequest) (*types.GitCommitResponse, error) {
+	message := strings.TrimSpace(req.Message)
+	if message == "" || len(req.ArtifactIDs) == 0 {
+		return nil, &GitServiceError{
+			Code:    ErrCodeInvalidCommit,
+			Message: "A commit needs a message and at least one artifact",
+		}
+	}
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &GitServiceError{
+			Code:    ErrCodeGitConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+	root := gitTopLevel(ctx, config.ProjectRoot)
+	if root == "" {
+		return nil, &GitServiceError{
+			Code:    ErrCodeNotGitRepo,
+			Message: "The project is not a git repository",
+		}
+	}
+
+	var paths []string
+	for _, id := range req.ArtifactIDs {
+		artifact, err := s.artifactService.GetArtifact(id)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, repoRelativePath(root, config.ProjectRoot, artifact.Path))
+	}
+
+	defer s.invalidate()
+
+	if _, err := runGit(ctx, root, append([]string{"add", "-A", "--"}, paths...)...); err != nil {
+		return nil, &GitServiceError{Code: ErrCodeGitFailed, Message: fmt.Sprintf("Failed to stage artifacts: %v", err)}
+	}
+	staged, err := runGit(ctx, root, append([]string{"diff", "--cached", "--name-only", "--"}, paths...)...)
+	if err != nil {
+		return nil, &GitServiceError{Code: ErrCodeGitFailed, Message: fmt.Sprintf("Failed to read staged changes: %v", err)}
+	}
+	if strings.TrimSpace(string(staged)) == "" {
+		return nil, &GitServiceError{
+			Code:    ErrCodeNothingToCommit,
+			Message: "The selected artifacts have no changes to commit",
+		}
+	}
+
+	if _, err := runGit(ctx, root, append([]string{"commit", "--only", "-m", message, "--"}, paths...)...); err != nil {
+		return nil, &GitServiceError{Code: ErrCodeGitFailed, Message: fmt.Sprintf("Failed to commit: %v", err)}
+	}
+	head, err := s.headCommit(ctx, root)
+	if err != nil {
+		return nil, &GitServiceError{Code: ErrCodeGitFailed, Message: fmt.Sprintf("Committed but failed to read the commit: %v", err)}
+	}
+	return &types.GitCommitResponse{Commit: *head, ArtifactIDs: req.ArtifactIDs}, nil
+}
+
+// currentSnapshot returns the cached snapshot, taking a new one if it is missing or stale.
+func (s *GitService) currentSnapshot(ctx context.Context) (*gitSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshot != nil && time.Since(s.snapshot.takenAt) < gitStatusTTL {
+		return s.snapshot, nil
+	}
+
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &GitServiceError{
+			Code:    ErrCodeGitConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+
+	snap := &gitSnapshot{takenAt: time.Now()}
+	outputRel, err := filepath.Rel(config.ProjectRoot, config.OutputFolder)
+	if err != nil || strings.HasPrefix(outputRel, "..") {
+		outputRel = "."
+	}
+	root, pathspec := gitRepoPath(ctx, config.ProjectRoot, filepath.ToSlash(outputRel))
+	if root == "" {
+		s.snapshot = snap
+		return snap, nil
+	}
+	snap.root = root
+
+	files, err := gitStatus(ctx, root, pathspec)
+	if err != nil {
+		return nil, &GitServiceError{Code: ErrCodeGitFailed, Message: fmt.Sprintf("Failed to read git status: %v", err)}
+	}
+	snap.files = files
+
+	// Tracked status comes from the index, not the log, so files whose last change is older
+	// than the scanned commits are still tracked
+	tracked, err := gitTrackedFiles(ctx, root, pathspec)
+	if err != nil {
+		return nil, &GitServiceError{Code: ErrCodeGitFailed, Message: fmt.Sprintf("Failed to list tracked files: %v", err)}
+	}
+	snap.tracked = tracked
+
+	// A repository without commits has no log; every file then simply has no last commit
+	snap.lastCommits = make(map[string]*types.GitCommitInfo)
+	commits, _ := gitLog(ctx, root, s.lastCommitScanLimit, false, pathspec)
+	for _, c := range commits {
+		info := commitInfo(c)
+		for _, f := range c.Files {
+			if _, seen := snap.lastCommits[f]; !seen {
+				snap.lastCommits[f] = info
+			}
+		}
+	}
+
+	s.snapshot = snap
+	return snap, nil
+}
+
+// headCommit returns the commit HEAD points to.
+func (s *GitService) headCommit(ctx context.Context, root string) (*types.GitCommitInfo, error) {
+	out, err := runGit(ctx, root, "log", "-1", gitLogFormat)
+	if err != nil {
+		return nil, err
+	}
+	commits := parseGitLog(out)
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits")
+	}
+	return commitInfo(commits[0]), nil
+}
+
+// gitStatus returns the porcelain status of changed files under pathspec, keyed by repository-relative path.
+func gitStatus(ctx context.Context, root, pathspec string) (map[string]gitFileState, error) {
+	out, err := runGit(ctx, root, "status", "--porcelain=v1", "-z", "--untracked-files=all", "--", pathspec)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]gitFileState)
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		x, y, path := entry[0], entry[1], entry[3:]
+		if x == 'R' || x == 'C' {
+			i++ // The next entry is the source path of the rename or copy
+		}
+		if x == '?' {
+			files[path] = gitFileState{untracked: true}
+			continue
+		}
+		files[path] = gitFileState{
+			staged: x != ' ' && x != '!',
+			dirty:  y != ' ' && y != '!',
+		}
+	}
+	return files, nil
+}
+
+// gitTrackedFiles returns the repository-relative paths of the files under pathspec that are in the index.
+func gitTrackedFiles(ctx context.Context, root, pathspec string) (map[string]bool, error) {
+	out, err := runGit(ctx, root, "ls-files", "-z", "--", pathspec)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make(map[string]bool)
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path != "" {
+			tracked[path] = true
+		}
+	}
+	return tracked, nil
+}
+
+// commitInfo converts a parsed commit to its API form.
+func commitInfo(c gitCommit) *types.GitCommitInfo {
+	return &types.GitCommitInfo{
+		Hash:    c.Hash,
+		Author:  c.Author,
+		Email:   c.Email,
+		Date:    types.Timestamp(c.Time),
+		Message: c.Subject,
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+// setupGitTest creates a project with a committed PRD. Commits made by the service use gitTestEnv.
+func setupGitTest(t *testing.T) (*GitService, *ArtifactService, string) {
+	t.Helper()
+	svc, _ := setupContentTest(t, "# Product Requirements\n\nCommitted.\n")
+	root := svc.configService.GetConfig().ProjectRoot
+
+	runTestGit(t, root, "init", "-q")
+	runTestGit(t, root, "add", "-A")
+	runTestGit(t, root, "commit", "-q", "-m", "Add PRD")
+	for _, kv := range gitTestEnv {
+		parts := strings.SplitN(kv, "=", 2)
+		t.Setenv(parts[0], parts[1])
+	}
+
+	return NewGitService(svc.configService, svc), svc, root
+}
+
+func gitStatusByID(t *testing.T, gs *GitService, svc *ArtifactService) map[string]*types.ArtifactGitStatus {
+	t.Helper()
+	artifacts, err := svc.GetArtifacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs.Annotate(context.Background(), artifacts)
+	byID := make(map[string]*types.ArtifactGitStatus)
+	for _, a := range artifacts {
+		byID[a.ID] = a.Git
+	}
+	return byID
+}
+
+func TestGitService_NotARepository(t *testing.T) {
+	svc, id := setupContentTest(t, "# PRD\n")
+	gs := NewGitService(svc.configService, svc)
+
+	if status := gitStatusByID(t, gs, svc)[id]; status != nil {
+		t.Errorf("Expected no git status outside a repository, got %+v", status)
+	}
+	repo, err := gs.RepoStatus(context.Background())
+	if err != nil || repo.IsRepo {
+		t.Errorf("Expected IsRepo false, got %+v, %v", repo, err)
+	}
+	_, err = gs.Commit(context.Background(), types.GitCommitRequest{ArtifactIDs: []string{id}, Message: "x"})
+	if gitErr, ok := err.(*GitServiceError); !ok || gitErr.Code != ErrCodeNotGitRepo {
+		t.Errorf("Expected not_a_git_repo, got %v", err)
+	}
+}
+
+func TestGitService_AnnotatesFileStates(t *testing.T) {
+	gs, svc, root := setupGitTest(t)
+	planningDir := filepath.Join(root, "_bmad-output", "planning-artifacts")
+
+	if err := os.WriteFile(filepath.Join(planningDir, "prd.md"), []byte("# Product Requirements\n\nEdited.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(planningDir, "architecture.md"), []byte("# Architecture\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := gitStatusByID(t, gs, svc)
+	prd := statuses["_bmad-output-planning-artifacts-prd"]
+	if prd == nil || !prd.Tracked || !prd.Dirty || prd.Staged || prd.Untracked {
+		t.Errorf("Unexpected PRD status %+v", prd)
+	}
+	if prd.LastCommit == nil || prd.LastCommit.Author != "Test Author" || prd.LastCommit.Message != "Add PRD" {
+		t.Errorf("Unexpected PRD last commit %+v", prd.LastCommit)
+	}
+	arch := statuses["_bmad-output-planning-artifacts-architecture"]
+	if arch == nil || arch.Tracked || !arch.Untracked || arch.LastCommit != nil {
+		t.Errorf("Unexpected architecture status %+v", arch)
+	}
+
+	runTestGit(t, root, "add", "_bmad-output/planning-artifacts/prd.md")
+	gs.invalidate()
+	if prd := gitStatusByID(t, gs, svc)["_bmad-output-planning-artifacts-prd"]; !prd.Staged || prd.Dirty {
+		t.Errorf("Expected the PRD staged and clean in the work tree, got %+v", prd)
+	}
+
+	// The PRD, the new architecture doc and the rewritten artifact registry
+	repo, err := gs.RepoStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !repo.IsRepo || repo.Head == nil || repo.Uncommitted != 3 {
+		t.Errorf("Unexpected repo status %+v", repo)
+	}
+}
+
+func TestGitService_TrackedBeyondLastCommitScan(t *testing.T) {
+	gs, svc, root := setupGitTest(t)
+	gs.lastCommitScanLimit = 1
+
+	// A newer commit touching another artifact pushes the PRD's commit out of the scan
+	if err := os.WriteFile(filepath.Join(root, "_bmad-output", "planning-artifacts", "architecture.md"), []byte("# Architecture\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runTestGit(t, root, "add", "-A")
+	runTestGit(t, root, "commit", "-q", "-m", "Add architecture")
+	if err := svc.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+
+	prd := gitStatusByID(t, gs, svc)["_bmad-output-planning-artifacts-prd"]
+	if prd == nil || !prd.Tracked || prd.Untracked || prd.LastCommit != nil {
+		t.Errorf("Expected a clean committed PRD tracked without a scanned last commit, got %+v", prd)
+	}
+}
+
+func TestGitService_CommitsOnlySelectedArtifacts(t *testing.T) {
+	gs, svc, root := setupGitTest(t)
+	planningDir := filepath.Join(root, "_bmad-output", "planning-artifacts")
+
+	if err := os.WriteFile(filepath.Join(planningDir, "prd.md"), []byte("# Product Requirements\n\nEdited.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(planningDir, "architecture.md"), []byte("# Architecture\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	archID := "_bmad-output-planning-artifacts-architecture"
+
+	result, err := gs.Commit(context.Background(), types.GitCommitRequest{ArtifactIDs: []string{archID}, Message: "Add architecture"})
+	if err != nil {
+		t.Fatalf("Commit error: %v", err)
+	}
+	if result.Commit.Message != "Add architecture" || result.Commit.Hash == "" {
+		t.Errorf("Unexpected commit %+v", result.Commit)
+	}
+
+	statuses := gitStatusByID(t, gs, svc)
+	if arch := statuses[archID]; arch.Untracked || arch.LastCommit == nil || arch.LastCommit.Hash != result.Commit.Hash {
+		t.Errorf("Expected architecture committed, got %+v", arch)
+	}
+	if prd := statuses["_bmad-output-planning-artifacts-prd"]; !prd.Dirty {
+		t.Errorf("Expected the unselected PRD to stay dirty, got %+v", prd)
+	}
+
+	_, err = gs.Commit(context.Background(), types.GitCommitRequest{ArtifactIDs: []string{archID}, Message: "Again"})
+	if gitErr, ok := err.(*GitServiceError); !ok || gitErr.Code != ErrCodeNothingToCommit {
+		t.Errorf("Expected nothing_to_commit, got %v", err)
+	}
+	_, err = gs.Commit(context.Background(), types.GitCommitRequest{ArtifactIDs: []string{archID}, Message: "  "})
+	if gitErr, ok := err.(*GitServiceError); !ok || gitErr.Code != ErrCodeInvalidCommit {
+		t.Errorf("Expected invalid_commit, got %v", err)
+	}
+}
+
+func TestGitStatus_ParsesPorcelain(t *testing.T) {
+	_, _, root := setupGitTest(t)
+	runTestGit(t, root, "mv", "_bmad-output/planning-artifacts/prd.md", "_bmad-output/planning-artifacts/prd-v2.md")
+
+	files, err := gitStatus(context.Background(), root, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, ok := files["_bmad-output/planning-artifacts/prd-v2.md"]
+	if !ok || !state.staged || len(files) != 1 {
+		t.Errorf("Expected the renamed file staged and its source skipped, got %+v", files)
+	}
+}
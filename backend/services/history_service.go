@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// HistoryServiceError represents a structured error from the history service
+type HistoryServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *HistoryServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for history service
+const (
+	ErrCodeHistoryConfigNotLoaded = "config_not_loaded"
+	ErrCodeVersionNotFound        = "version_not_found"
+	ErrCodeInvalidDiffMode        = "invalid_diff_mode"
+	ErrCodeHistoryFailed          = "history_failed"
+)
+
+// gitHistoryLimit caps the number of commits merged into an artifact's timeline
+const gitHistoryLimit = 200
+
+// HistoryService records a local snapshot of an artifact each time its content changes and
+// merges those snapshots with the file's git history. Snapshots are taken when the service
+// starts and on every watcher change, so the content before any modification is always stored.
+type HistoryService struct {
+	mu              sync.Mutex // Serialises timeline read-modify-write
+	configService   *BMadConfigService
+	artifactService *ArtifactService
+	store           *storage.HistoryStore
+}
+
+// NewHistoryService creates a new HistoryService.
+func NewHistoryService(configService *BMadConfigService, artifactService *ArtifactService, store *storage.HistoryStore) *HistoryService {
+	return &HistoryService{
+		configService:   configService,
+		artifactService: artifactService,
+		store:           store,
+	}
+}
+
+// Seed snapshots the current content of every artifact whose latest snapshot differs.
+func (s *HistoryService) Seed() error {
+	artifacts, err := s.artifactService.GetArtifacts()
+	if err != nil {
+		return err
+	}
+	for i := range artifacts {
+		content, err := s.artifactService.ReadContent(&artifacts[i])
+		if err != nil {
+			log.Printf("Warning: Failed to read artifact %s for history: %v", artifacts[i].ID, err)
+			continue
+		}
+		if err := s.Snapshot(&artifacts[i], content); err != nil {
+			log.Printf("Warning: Failed to snapshot artifact %s: %v", artifacts[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// ArtifactChanged snapshots an artifact the watcher created or modified.
+func (s *HistoryService) ArtifactChanged(artifact *types.ArtifactResponse) {
+	content, err := s.artifactService.ReadContent(artifact)
+	if err != nil {
+		log.Printf("Warning: Failed to read artifact %s for history: %v", artifact.ID, err)
+		return
+	}
+	if err := s.Snapshot(artifact, content); err != nil {
+		log.Printf("Warning: Failed to snapshot artifact %s: %v", artifact.ID, err)
+	}
+}
+
+// ArtifactRemoved keeps the history of deleted artifacts.
+func (s *HistoryService) ArtifactRemoved(artifact *types.ArtifactResponse) {}
+
+// Snapshot appends content to the artifact's timeline unless it matches the latest snapshot.
+func (s *HistoryService) Snapshot(artifact *types.ArtifactResponse, content []byte) error {
+	projectRoot, err := s.projectRoot()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timeline, err := s.store.LoadTimeline(projectRoot, artifact.ID)
+	if err != nil {
+		return err
+	}
+	if timeline == nil {
+		timeline = &types.ArtifactTimeline{ArtifactID: artifact.ID}
+	}
+
+	hash := contentHash(content)
+	if n := len(timeline.Versions); n > 0 && timeline.Versions[n-1].ContentHash == hash {
+		return nil
+	}
+	if _, err := s.store.PutBlob(projectRoot, content); err != nil {
+		return err
+	}
+
+	timestamp := time.Now()
+	if artifact.ModifiedAt > 0 {
+		timestamp = time.Unix(artifact.ModifiedAt, 0)
+	}
+	timeline.Versions = append(timeline.Versions, types.ArtifactVersion{
+		ID:          fmt.Sprintf("local:%d", len(timeline.Versions)+1),
+		Source:      types.VersionSourceLocal,
+		Timestamp:   types.Timestamp(timestamp),
+		ContentHash: hash,
+		Size:        int64(len(content)),
+		Path:        artifact.Path,
+	})
+	return s.store.SaveTimeline(projectRoot, timeline)
+}
+
+// History returns the artifact's local snapshots and git commits as one timeline, newest first.
+func (s *HistoryService) History(ctx context.Context, id string) (*types.ArtifactHistoryResponse, error) {
+	artifact, err := s.artifactService.GetArtifact(id)
+	if err != nil {
+		return nil, err
+	}
+	projectRoot, err := s.projectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := s.store.LoadTimeline(projectRoot, id)
+	if err != nil {
+		return nil, &HistoryServiceError{
+			Code:    ErrCodeHistoryFailed,
+			Message: fmt.Sprintf("Failed to load history for %s: %v", id, err),
+		}
+	}
+	versions := []types.ArtifactVersion{}
+	if timeline != nil {
+		for i := len(timeline.Versions) - 1; i >= 0; i-- {
+			versions = append(versions, timeline.Versions[i]) // Newest first, so ties keep snapshot order
+		}
+	}
+
+	commits, isGitRepo := s.gitCommits(ctx, projectRoot, artifact)
+	for _, c := range commits {
+		versions = append(versions, types.ArtifactVersion{
+			ID:        "git:" + c.Hash,
+			Source:    types.VersionSourceGit,
+			Timestamp: types.Timestamp(c.Time),
+			Path:      c.Path,
+			Commit:    c.Hash,
+			Author:    c.Author,
+			Message:   c.Subject,
+		})
+	}
+
+	// Newest first; at equal times a commit sorts after the snapshot of the same edit
+	sort.SliceStable(versions, func(i, j int) bool {
+		ti, tj := versions[i].Timestamp.Time(), versions[j].Timestamp.Time()
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return versions[i].Source == types.VersionSourceLocal && versions[j].Source == types.VersionSourceGit
+	})
+
+	return &types.ArtifactHistoryResponse{ArtifactID: id, IsGitRepo: isGitRepo, Versions: versions}, nil
+}
+
+// VersionContent returns the content of an artifact at a version: a local snapshot ID,
+// a git version ID, or types.VersionCurrent for the file on disk.
+func (s *HistoryService) VersionContent(ctx context.Context, id, versionID string) ([]byte, error) {
+	artifact, err := s.artifactService.GetArtifact(id)
+	if err != nil {
+		return nil, err
+	}
+	projectRoot, err := s.projectRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case versionID == types.VersionCurrent:
+		return s.artifactService.GetRawContent(id)
+
+	case strings.HasPrefix(versionID, "local:"):
+		timeline, err := s.store.LoadTimeline(projectRoot, id)
+		if err != nil {
+			return nil, &HistoryServiceError{
+				Code:    ErrCodeHistoryFailed,
+				Message: fmt.Sprintf("Failed to load history for %s: %v", id, err),
+			}
+		}
+		if timeline != nil {
+			for _, v := range timeline.Versions {
+				if v.ID == versionID {
+					content, err := s.store.GetBlob(projectRoot, v.ContentHash)
+					if err != nil {
+						return nil, &HistoryServiceError{
+							Code:    ErrCodeHistoryFailed,
+							Message: fmt.Sprintf("Snapshot %s of %s is unreadable: %v", versionID, id, err),
+						}
+					}
+					return content, nil
+				}
+			}
+		}
+
+	case strings.HasPrefix(versionID, "git:"):
+		commits, _ := s.gitCommits(ctx, projectRoot, artifact)
+		for _, c := range commits {
+			if c.Hash == strings.TrimPrefix(versionID, "git:") {
+				content, err := gitShowFile(ctx, gitTopLevel(ctx, projectRoot), c.Hash, c.Path)
+				if err != nil {
+					return nil, &HistoryServiceError{
+						Code:    ErrCodeHistoryFailed,
+						Message: fmt.Sprintf("Failed to read %s at %s: %v", id, c.Hash, err),
+					}
+				}
+				return content, nil
+			}
+		}
+	}
+
+	return nil, &HistoryServiceError{
+		Code:    ErrCodeVersionNotFound,
+		Message: fmt.Sprintf("Version not found for artifact %s: %s", id, versionID),
+	}
+}
+
+// Diff compares two versions of an artifact. mode is types.DiffModeUnified (the default) or types.DiffModeWord.
+func (s *HistoryService) Diff(ctx context.Context, id, from, to, mode string) (*types.ArtifactDiffResponse, error) {
+	if mode == "" {
+		mode = types.DiffModeUnified
+	}
+	if mode != types.DiffModeUnified && mode != types.DiffModeWord {
+		return nil, &HistoryServiceError{
+			Code:    ErrCodeInvalidDiffMode,
+			Message: fmt.Sprintf("Invalid diff mode %q: must be unified or word", mode),
+		}
+	}
+	if to == "" {
+		to = types.VersionCurrent
+	}
+
+	fromContent, err := s.VersionContent(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toContent, err := s.VersionContent(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &types.ArtifactDiffResponse{ArtifactID: id, From: from, To: to, Mode: mode}
+	var unified string
+	unified, resp.Additions, resp.Deletions = unifiedDiff(from, to, string(fromContent), string(toContent))
+	if mode == types.DiffModeUnified {
+		resp.Unified = unified
+	} else {
+		resp.Words = wordDiff(string(fromContent), string(toContent))
+	}
+	return resp, nil
+}
+
+// Restore writes the content of an earlier version back to the artifact.
+// ifMatch must be the artifact's current ETag, as for any other write.
+func (s *HistoryService) Restore(ctx context.Context, id, versionID, ifMatch string) (*types.ArtifactResponse, error) {
+	content, err := s.VersionContent(ctx, id, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := s.artifactService.WriteContent(id, content, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Snapshot(artifact, content); err != nil {
+		log.Printf("Warning: Failed to snapshot restored artifact %s: %v", id, err)
+	}
+	return artifact, nil
+}
+
+// gitCommits lists the commits that touched the artifact and reports whether the project is in a git repository.
+func (s *HistoryService) gitCommits(ctx context.Context, projectRoot string, artifact *types.ArtifactResponse) ([]gitCommit, bool) {
+	root, repoPath := gitRepoPath(ctx, projectRoot, artifact.Path)
+	if root == "" {
+		return nil, false
+	}
+
+	commits, err := gitFileLog(ctx, root, repoPath, gitHistoryLimit)
+	if err != nil {
+		log.Printf("Warning: Failed to read git history for %s: %v", artifact.ID, err)
+		return nil, true
+	}
+	return commits, true
+}
+
+// projectRoot returns the project root from the loaded config.
+func (s *HistoryService) projectRoot() (string, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return "", &HistoryServiceError{
+			Code:    ErrCodeHistoryConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+	return config.ProjectRoot, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// gitTestEnv makes commits in tests independent of the user's git configuration.
+var gitTestEnv = []string{
+	"GIT_AUTHOR_NAME=Test Author", "GIT_AUTHOR_EMAIL=author@example.com",
+	"GIT_COMMITTER_NAME=Test Author", "GIT_COMMITTER_EMAIL=author@example.com",
+	"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+}
+
+// runTestGit runs git in dir, skipping the test if git is not installed.
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), gitTestEnv...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func setupHistoryTest(t *testing.T) (*HistoryService, *ArtifactService, string, string) {
+	t.Helper()
+	svc, id := setupContentTest(t, "# Product Requirements\n\nFirst draft.\n")
+	config := svc.configService.GetConfig()
+	path := filepath.Join(config.ProjectRoot, "_bmad-output", "planning-artifacts", "prd.md")
+
+	history := NewHistoryService(svc.configService, svc, storage.NewHistoryStoreWithDir(t.TempDir()))
+	if err := history.Seed(); err != nil {
+		t.Fatalf("Seed error: %v", err)
+	}
+	return history, svc, id, path
+}
+
+// editArtifact rewrites the file and re-processes it, as the watcher would.
+func editArtifact(t *testing.T, history *HistoryService, svc *ArtifactService, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := svc.ProcessSingleArtifact(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history.ArtifactChanged(artifact)
+}
+
+func TestHistory_SnapshotsEachDistinctVersion(t *testing.T) {
+	history, svc, id, path := setupHistoryTest(t)
+
+	editArtifact(t, history, svc, path, "# Product Requirements\n\nSecond draft.\n")
+	editArtifact(t, history, svc, path, "# Product Requirements\n\nSecond draft.\n") // Unchanged save
+
+	resp, err := history.History(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Versions) != 2 {
+		t.Fatalf("Expected 2 versions, got %+v", resp.Versions)
+	}
+
+	first, err := history.VersionContent(context.Background(), id, "local:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != "# Product Requirements\n\nFirst draft.\n" {
+		t.Errorf("Expected the seeded content as local:1, got %q", first)
+	}
+}
+
+func TestHistory_DiffModes(t *testing.T) {
+	history, svc, id, path := setupHistoryTest(t)
+	editArtifact(t, history, svc, path, "# Product Requirements\n\nFinal draft.\n")
+
+	unified, err := history.Diff(context.Background(), id, "local:1", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unified.To != types.VersionCurrent || !strings.Contains(unified.Unified, "-First draft.\n+Final draft.\n") {
+		t.Errorf("Unexpected unified diff %+v", unified)
+	}
+	if unified.Additions != 1 || unified.Deletions != 1 {
+		t.Errorf("additions/deletions = %d/%d", unified.Additions, unified.Deletions)
+	}
+
+	words, err := history.Diff(context.Background(), id, "local:1", "local:2", types.DiffModeWord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changed []string
+	for _, s := range words.Words {
+		if s.Op != types.DiffOpEqual {
+			changed = append(changed, s.Op+":"+s.Text)
+		}
+	}
+	if strings.Join(changed, ",") != "delete:First,insert:Final" {
+		t.Errorf("Unexpected word diff %v", changed)
+	}
+
+	if _, err := history.Diff(context.Background(), id, "local:1", "", "side-by-side"); err == nil {
+		t.Error("Expected an error for an unknown mode")
+	}
+	_, err = history.Diff(context.Background(), id, "local:9", "", "")
+	if herr, ok := err.(*HistoryServiceError); !ok || herr.Code != ErrCodeVersionNotFound {
+		t.Errorf("Expected version_not_found, got %v", err)
+	}
+}
+
+func TestHistory_Restore(t *testing.T) {
+	history, svc, id, path := setupHistoryTest(t)
+	editArtifact(t, history, svc, path, "# Product Requirements\n\nA much longer rewrite.\n")
+
+	current, _ := svc.GetArtifact(id)
+	artifact, err := history.Restore(context.Background(), id, "local:1", ArtifactETag(current))
+	if err != nil {
+		t.Fatalf("Restore error: %v", err)
+	}
+	content, _ := svc.GetRawContent(artifact.ID)
+	if string(content) != "# Product Requirements\n\nFirst draft.\n" {
+		t.Errorf("Restored content = %q", content)
+	}
+
+	resp, _ := history.History(context.Background(), id)
+	if len(resp.Versions) != 3 || resp.Versions[0].ID != "local:3" {
+		t.Errorf("Expected the restore recorded as the newest version, got %+v", resp.Versions)
+	}
+
+	if _, err := history.Restore(context.Background(), id, "local:2", ArtifactETag(current)); !isArtifactErr(err, ErrCodeETagMismatch) {
+		t.Errorf("Expected a stale ETag to be rejected, got %v", err)
+	}
+}
+
+func TestHistory_MergesGitCommits(t *testing.T) {
+	history, svc, id, path := setupHistoryTest(t)
+	root := svc.configService.GetConfig().ProjectRoot
+
+	runTestGit(t, root, "init", "-q")
+	runTestGit(t, root, "add", "-A")
+	runTestGit(t, root, "commit", "-q", "-m", "Add PRD")
+	commit := runTestGit(t, root, "rev-parse", "HEAD")
+
+	editArtifact(t, history, svc, path, "# Product Requirements\n\nUncommitted edit.\n")
+
+	resp, err := history.History(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.IsGitRepo {
+		t.Error("Expected IsGitRepo")
+	}
+	var gitVersion *types.ArtifactVersion
+	for i, v := range resp.Versions {
+		if v.Source == types.VersionSourceGit {
+			gitVersion = &resp.Versions[i]
+		}
+	}
+	if gitVersion == nil || gitVersion.Commit != commit || gitVersion.Author != "Test Author" || gitVersion.Message != "Add PRD" {
+		t.Fatalf("Expected the commit in the timeline, got %+v", resp.Versions)
+	}
+
+	content, err := history.VersionContent(context.Background(), id, gitVersion.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "# Product Requirements\n\nFirst draft.\n" {
+		t.Errorf("Git version content = %q", content)
+	}
+}
@@ -0,0 +1,789 @@
+package services
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mdBlockKind is the kind of a markdown block
+type mdBlockKind int
+
+const (
+	mdParagraph mdBlockKind = iota
+	mdHeading
+	mdCodeBlock
+	mdList
+	mdQuote
+	mdTable
+	mdRule
+)
+
+// mdBlock is a block of a parsed markdown document. Inline markdown is kept as text and
+// parsed by parseInline when rendered.
+type mdBlock struct {
+	kind    mdBlockKind
+	level   int          // Heading level 1-6
+	id      string       // Heading anchor
+	text    string       // Inline markdown of paragraphs and headings; content of code blocks
+	lang    string       // Info string of fenced code
+	ordered bool         // Numbered list
+	start   int          // First number of a numbered list
+	items   []mdListItem // List items
+	blocks  []mdBlock    // Content of a blockquote
+	rows    [][]string   // Table cells, header row first
+	align   []string     // Table column alignment: "", "left", "center" or "right"
+}
+
+// mdListItem is an item of a list block
+type mdListItem struct {
+	task   bool // Starts with a "[ ]" or "[x]" checkbox
+	done   bool
+	blocks []mdBlock
+}
+
+var (
+	// listMarkerRegex matches a list item line: indent, marker, spacing and content
+	listMarkerRegex = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+
+	// thematicBreakRegex matches a horizontal rule
+	thematicBreakRegex = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+
+	// tableDelimiterRegex matches the row under a table header
+	tableDelimiterRegex = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?\s*$`)
+
+	setextRegex = regexp.MustCompile(`^ {0,3}(=+|-+)\s*$`)
+
+	// linkDefinitionRegex matches a reference link definition: "[label]: destination"
+	linkDefinitionRegex = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ \t]*(<[^>]*>|\S+)`)
+
+	// referenceUseRegex matches a "[text][label]" reference link; an empty label repeats the text
+	referenceUseRegex = regexp.MustCompile(`\[([^\]]+)\]\[([^\]]*)\]`)
+)
+
+// parseMarkdownBlocks parses a markdown body into blocks. Heading anchors are made unique
+// across used, so one map can be shared by the parts of a combined document.
+func parseMarkdownBlocks(body string, used map[string]bool) []mdBlock {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	p := &mdParser{used: used, refs: make(map[string]string)}
+	fence := ""
+	for i, line := range lines {
+		lines[i] = strings.ReplaceAll(line, "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case isCodeFence(trimmed):
+			fence = trimmed[:3]
+		default:
+			if m := linkDefinitionRegex.FindStringSubmatch(line); m != nil {
+				if _, seen := p.refs[strings.ToLower(m[1])]; !seen {
+					p.refs[strings.ToLower(m[1])] = strings.Trim(m[2], "<>")
+				}
+			}
+		}
+	}
+	return p.parse(lines)
+}
+
+type mdParser struct {
+	used map[string]bool
+	refs map[string]string // Link definitions by lowercased label
+}
+
+// resolveReferences turns the reference links of text into inline links
+func (p *mdParser) resolveReferences(text string) string {
+	if len(p.refs) == 0 {
+		return text
+	}
+	return referenceUseRegex.ReplaceAllStringFunc(text, func(match string) string {
+		m := referenceUseRegex.FindStringSubmatch(match)
+		label := m[2]
+		if label == "" {
+			label = m[1]
+		}
+		dest, ok := p.refs[strings.ToLower(label)]
+		if !ok {
+			return match
+		}
+		return "[" + m[1] + "](" + dest + ")"
+	})
+}
+
+func (p *mdParser) parse(lines []string) []mdBlock {
+	var blocks []mdBlock
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			i++
+		case isCodeFence(trimmed):
+			var block mdBlock
+			block, i = parseFencedCode(lines, i)
+			blocks = append(blocks, block)
+		case indentation(line) >= 4:
+			var code []string
+			for i < len(lines) && (indentation(lines[i]) >= 4 || strings.TrimSpace(lines[i]) == "") {
+				code = append(code, strings.TrimPrefix(lines[i], "    "))
+				i++
+			}
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, mdBlock{kind: mdCodeBlock, text: strings.Join(code, "\n")})
+		case thematicBreakRegex.MatchString(line):
+			blocks = append(blocks, mdBlock{kind: mdRule})
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			var inner []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				quoted := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				inner = append(inner, strings.TrimPrefix(quoted, " "))
+				i++
+			}
+			blocks = append(blocks, mdBlock{kind: mdQuote, blocks: p.parse(inner)})
+		case listMarkerRegex.MatchString(line):
+			var block mdBlock
+			block, i = p.parseList(lines, i)
+			blocks = append(blocks, block)
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableDelimiterRegex.MatchString(lines[i+1]):
+			var block mdBlock
+			block, i = parseTable(lines, i)
+			blocks = append(blocks, block)
+		case linkDefinitionRegex.MatchString(line):
+			i++ // Definitions are not shown
+		default:
+			if level, heading, ok := parseATXHeading(line); ok {
+				blocks = append(blocks, p.heading(level, heading))
+				i++
+				continue
+			}
+			var block mdBlock
+			block, i = p.parseParagraph(lines, i)
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// heading returns a heading block with its anchor, slugged the way GitHub anchors headings.
+func (p *mdParser) heading(level int, text string) mdBlock {
+	return mdBlock{kind: mdHeading, level: level, text: text, id: uniqueSectionID(sectionSlug(inlinePlainText(text)), p.used)}
+}
+
+// parseParagraph reads a paragraph, or a setext heading, starting at lines[i].
+func (p *mdParser) parseParagraph(lines []string, i int) (mdBlock, int) {
+	var text []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if len(text) > 0 {
+			if m := setextRegex.FindStringSubmatch(line); m != nil {
+				level := 2
+				if m[1][0] == '=' {
+					level = 1
+				}
+				return p.heading(level, strings.Join(text, " ")), i + 1
+			}
+		}
+		if len(text) > 0 && startsBlock(line) {
+			break
+		}
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		if linkDefinitionRegex.MatchString(line) {
+			continue
+		}
+		text = append(text, strings.TrimSpace(line))
+	}
+	return mdBlock{kind: mdParagraph, text: p.resolveReferences(strings.Join(text, "\n"))}, i
+}
+
+// startsBlock reports whether line interrupts a paragraph
+func startsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if _, _, ok := parseATXHeading(line); ok {
+		return true
+	}
+	return isCodeFence(trimmed) || strings.HasPrefix(trimmed, ">") || thematicBreakRegex.MatchString(line) ||
+		(indentation(line) < 4 && listMarkerRegex.MatchString(line) && strings.TrimSpace(listMarkerRegex.FindStringSubmatch(line)[4]) != "")
+}
+
+func isCodeFence(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// parseFencedCode reads a fenced code block starting at lines[i]. An unclosed fence runs to the end.
+func parseFencedCode(lines []string, i int) (mdBlock, int) {
+	indent := indentation(lines[i])
+	opening := strings.TrimSpace(lines[i])
+	fenceLen := len(opening) - len(strings.TrimLeft(opening, opening[:1]))
+	fence := opening[:fenceLen]
+	block := mdBlock{kind: mdCodeBlock, lang: strings.TrimSpace(opening[fenceLen:])}
+
+	var code []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		line := lines[i]
+		line = line[min(indent, indentation(line)):]
+		code = append(code, line)
+	}
+	block.text = strings.Join(code, "\n")
+	return block, i
+}
+
+// parseList reads a list starting at lines[i]. Item content is dedented and parsed as blocks,
+// so nested lists and paragraphs inside items work.
+func (p *mdParser) parseList(lines []string, i int) (mdBlock, int) {
+	first := listMarkerRegex.FindStringSubmatch(lines[i])
+	indent := len(first[1])
+	ordered := first[2][0] >= '0' && first[2][0] <= '9'
+	block := mdBlock{kind: mdList, ordered: ordered, start: 1}
+	if ordered {
+		block.start, _ = strconv.Atoi(first[2][:len(first[2])-1])
+	}
+
+	for i < len(lines) {
+		m := listMarkerRegex.FindStringSubmatch(lines[i])
+		if m == nil || len(m[1]) != indent || (m[2][0] >= '0' && m[2][0] <= '9') != ordered {
+			break
+		}
+		contentIndent := indent + len(m[2]) + max(len(m[3]), 1)
+		itemLines := []string{m[4]}
+		i++
+
+		prevBlank := false
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				// A blank line continues the item only if indented content follows
+				next := i + 1
+				for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+					next++
+				}
+				if next == len(lines) || indentation(lines[next]) < contentIndent {
+					break
+				}
+				itemLines = append(itemLines, "")
+				prevBlank = true
+				i++
+				continue
+			}
+			lineIndent := indentation(line)
+			if lineIndent > indent {
+				itemLines = append(itemLines, line[min(lineIndent, contentIndent):])
+			} else if !prevBlank && !startsBlock(line) {
+				itemLines = append(itemLines, strings.TrimSpace(line)) // Lazy continuation
+			} else {
+				break
+			}
+			prevBlank = false
+			i++
+		}
+
+		item := mdListItem{}
+		if content := itemLines[0]; len(content) >= 3 && content[0] == '[' && content[2] == ']' && strings.ContainsRune(" xX", rune(content[1])) {
+			item.task = true
+			item.done = content[1] != ' '
+			itemLines[0] = strings.TrimSpace(content[3:])
+		}
+		item.blocks = p.parse(itemLines)
+		block.items = append(block.items, item)
+
+		// Blank lines between items keep the list going
+		for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+			if i+1 < len(lines) && listMarkerRegex.MatchString(lines[i+1]) && indentation(lines[i+1]) == indent {
+				i++
+				continue
+			}
+			break
+		}
+	}
+	return block, i
+}
+
+// parseTable reads a pipe table whose header is lines[i].
+func parseTable(lines []string, i int) (mdBlock, int) {
+	block := mdBlock{kind: mdTable}
+	header := splitTableRow(lines[i])
+	block.rows = append(block.rows, header)
+	for _, cell := range splitTableRow(lines[i+1]) {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			block.align = append(block.align, "center")
+		case strings.HasSuffix(cell, ":"):
+			block.align = append(block.align, "right")
+		case strings.HasPrefix(cell, ":"):
+			block.align = append(block.align, "left")
+		default:
+			block.align = append(block.align, "")
+		}
+	}
+	for len(block.align) < len(header) {
+		block.align = append(block.align, "")
+	}
+
+	for i += 2; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+		row := splitTableRow(lines[i])
+		for len(row) < len(header) {
+			row = append(row, "")
+		}
+		block.rows = append(block.rows, row[:len(header)])
+	}
+	return block, i
+}
+
+// splitTableRow splits a table row into trimmed cells. "\|" is a literal pipe.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for j := 0; j < len(line); j++ {
+		switch {
+		case line[j] == '\\' && j+1 < len(line) && line[j+1] == '|':
+			cell.WriteByte('|')
+			j++
+		case line[j] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[j])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// mdSpan is a run of inline text with one style
+type mdSpan struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+	href   string // Link target; the image source when image is set
+	image  bool   // text is the alt text
+}
+
+// parseInline parses inline markdown: code spans, emphasis, links, images and autolinks.
+// Raw HTML is kept as text.
+func parseInline(text string) []mdSpan {
+	var spans []mdSpan
+	appendInline(&spans, text, mdSpan{})
+	return spans
+}
+
+// inlinePlainText returns inline markdown with its markup removed
+func inlinePlainText(text string) string {
+	var b strings.Builder
+	for _, span := range parseInline(text) {
+		b.WriteString(span.text)
+	}
+	return b.String()
+}
+
+func appendInline(spans *[]mdSpan, text string, style mdSpan) {
+	var plain strings.Builder
+	emit := func(span mdSpan) {
+		if span.text == "" && !span.image {
+			return
+		}
+		if n := len(*spans); n > 0 && !span.image {
+			last := &(*spans)[n-1]
+			if !last.image && last.bold == span.bold && last.italic == span.italic && last.code == span.code && last.href == span.href {
+				last.text += span.text
+				return
+			}
+		}
+		*spans = append(*spans, span)
+	}
+	flush := func() {
+		span := style
+		span.text = plain.String()
+		emit(span)
+		plain.Reset()
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_{}[]()#+-.!|<>~", text[i+1]) >= 0:
+			plain.WriteByte(text[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			run := 1
+			for i+run < len(text) && text[i+run] == '`' {
+				run++
+			}
+			fence := strings.Repeat("`", run)
+			if end := findCodeSpanEnd(text, i+run, fence); end >= 0 {
+				flush()
+				span := style
+				span.code = true
+				span.text = strings.ReplaceAll(text[i+run:end], "\n", " ")
+				if len(span.text) > 2 && span.text[0] == ' ' && span.text[len(span.text)-1] == ' ' {
+					span.text = span.text[1 : len(span.text)-1]
+				}
+				emit(span)
+				i = end + run
+				continue
+			}
+			plain.WriteString(fence)
+			i += run
+			continue
+
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if label, dest, end, ok := parseLink(text, i+1); ok {
+				flush()
+				emit(mdSpan{text: inlinePlainText(label), href: dest, image: true})
+				i = end
+				continue
+			}
+
+		case c == '[':
+			if label, dest, end, ok := parseLink(text, i); ok {
+				flush()
+				linked := style
+				linked.href = dest
+				appendInline(spans, label, linked)
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(text[i:], '>'); end > 0 {
+				target := text[i+1 : i+end]
+				if isAutolink(target) {
+					flush()
+					span := style
+					span.text = strings.TrimPrefix(target, "mailto:")
+					span.href = target
+					emit(span)
+					i += end + 1
+					continue
+				}
+			}
+
+		case (c == 'h' || c == 'H') && style.href == "" && (i == 0 || !isWordByte(text[i-1])) &&
+			(strings.HasPrefix(strings.ToLower(text[i:]), "https://") || strings.HasPrefix(strings.ToLower(text[i:]), "http://")):
+			end := i
+			for end < len(text) && text[end] != ' ' && text[end] != '\n' && text[end] != '<' {
+				end++
+			}
+			url := strings.TrimRight(text[i:end], ".,;:!?)'\"")
+			flush()
+			span := style
+			span.text = url
+			span.href = url
+			emit(span)
+			i += len(url)
+			continue
+
+		case c == '*' || c == '_' || c == '~':
+			if end, width, ok := findEmphasisEnd(text, i); ok {
+				flush()
+				inner := style
+				switch {
+				case c == '~':
+					// Strikethrough is kept as plain text
+				case width == 2:
+					inner.bold = true
+				default:
+					inner.italic = true
+				}
+				appendInline(spans, text[i+width:end], inner)
+				i = end + width
+				continue
+			}
+		}
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+// findCodeSpanEnd returns the index of the backtick run closing a code span, or -1
+func findCodeSpanEnd(text string, from int, fence string) int {
+	for j := from; j < len(text); {
+		k := strings.Index(text[j:], fence)
+		if k < 0 {
+			return -1
+		}
+		k += j
+		end := k + len(fence)
+		if end < len(text) && text[end] == '`' {
+			for end < len(text) && text[end] == '`' {
+				end++
+			}
+			j = end
+			continue
+		}
+		return k
+	}
+	return -1
+}
+
+// parseLink parses "[label](destination "title")" at text[open] and returns the label,
+// the destination and the index after the link.
+func parseLink(text string, open int) (label, dest string, end int, ok bool) {
+	depth := 0
+	closeBracket := -1
+	for j := open; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			closeBracket = j
+			break
+		}
+	}
+	if closeBracket < 0 || closeBracket+1 >= len(text) || text[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+
+	depth = 0
+	for j := closeBracket + 1; j < len(text); j++ {
+		switch text[j] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 {
+			inner := strings.TrimSpace(text[closeBracket+2 : j])
+			if strings.HasPrefix(inner, "<") {
+				if gt := strings.IndexByte(inner, '>'); gt > 0 {
+					inner = inner[1:gt]
+				}
+			} else if space := strings.IndexAny(inner, " \n"); space >= 0 {
+				inner = inner[:space] // Drop the title
+			}
+			return text[open+1 : closeBracket], inner, j + 1, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// findEmphasisEnd finds the delimiter closing emphasis opened at text[i]. "**" and "__"
+// are strong, "*" and "_" emphasis and "~~" strikethrough. Underscores inside words do
+// not count, so snake_case stays as written.
+func findEmphasisEnd(text string, i int) (end, width int, ok bool) {
+	c := text[i]
+	width = 1
+	if i+1 < len(text) && text[i+1] == c {
+		width = 2
+	}
+	if c == '~' && width != 2 {
+		return 0, 0, false
+	}
+	if c == '_' && i > 0 && isWordByte(text[i-1]) {
+		return 0, 0, false
+	}
+	delim := text[i : i+width]
+	start := i + width
+	if start >= len(text) || text[start] == ' ' || text[start] == '\n' {
+		return 0, 0, false
+	}
+
+	for j := start + 1; j <= len(text)-width; j++ {
+		if text[j] == '\\' {
+			j++
+			continue
+		}
+		if text[j] == '`' {
+			if k := findCodeSpanEnd(text, j+1, "`"); k >= 0 {
+				j = k
+			}
+			continue
+		}
+		if text[j:j+width] != delim || text[j-1] == ' ' {
+			continue
+		}
+		// A single delimiter must not be half of a double one
+		if width == 1 && ((j+1 < len(text) && text[j+1] == c) || text[j-1] == c) {
+			continue
+		}
+		if c == '_' && j+width < len(text) && isWordByte(text[j+width]) {
+			continue
+		}
+		return j, width, true
+	}
+	return 0, 0, false
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isAutolink(target string) bool {
+	lower := strings.ToLower(target)
+	return !strings.ContainsAny(target, " \n<") &&
+		(strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "mailto:"))
+}
+
+// hasURLScheme reports whether href starts with a scheme such as "https:"
+func hasURLScheme(href string) bool {
+	colon := strings.IndexByte(href, ':')
+	return colon > 0 && !strings.ContainsAny(href[:colon], "/?#")
+}
+
+// safeURL returns href unless it uses a scheme other than http, https or mailto
+func safeURL(href string) string {
+	if !hasURLScheme(href) {
+		return href // Relative
+	}
+	switch strings.ToLower(href[:strings.IndexByte(href, ':')]) {
+	case "http", "https", "mailto":
+		return href
+	}
+	return "#"
+}
+
+// renderMarkdownHTML writes blocks as HTML
+func renderMarkdownHTML(b *strings.Builder, blocks []mdBlock) {
+	for _, block := range blocks {
+		switch block.kind {
+		case mdHeading:
+			b.WriteString("<h" + strconv.Itoa(block.level) + ` id="` + html.EscapeString(block.id) + `">`)
+			renderInlineHTML(b, block.text)
+			b.WriteString("</h" + strconv.Itoa(block.level) + ">\n")
+		case mdParagraph:
+			b.WriteString("<p>")
+			renderInlineHTML(b, block.text)
+			b.WriteString("</p>\n")
+		case mdCodeBlock:
+			b.WriteString("<pre><code")
+			if lang := strings.Fields(block.lang); len(lang) > 0 {
+				b.WriteString(` class="language-` + html.EscapeString(lang[0]) + `"`)
+			}
+			b.WriteString(">" + html.EscapeString(block.text) + "</code></pre>\n")
+		case mdRule:
+			b.WriteString("<hr>\n")
+		case mdQuote:
+			b.WriteString("<blockquote>\n")
+			renderMarkdownHTML(b, block.blocks)
+			b.WriteString("</blockquote>\n")
+		case mdList:
+			renderListHTML(b, block)
+		case mdTable:
+			renderTableHTML(b, block)
+		}
+	}
+}
+
+func renderListHTML(b *strings.Builder, block mdBlock) {
+	tag := "ul"
+	if block.ordered {
+		tag = "ol"
+	}
+	b.WriteString("<" + tag)
+	if block.ordered && block.start != 1 {
+		b.WriteString(` start="` + strconv.Itoa(block.start) + `"`)
+	}
+	b.WriteString(">\n")
+	for _, item := range block.items {
+		if item.task {
+			b.WriteString(`<li class="task"><input type="checkbox" disabled`)
+			if item.done {
+				b.WriteString(" checked")
+			}
+			b.WriteString("> ")
+		} else {
+			b.WriteString("<li>")
+		}
+		// The first paragraph of an item is written without <p> to keep lists compact
+		blocks := item.blocks
+		if len(blocks) > 0 && blocks[0].kind == mdParagraph {
+			renderInlineHTML(b, blocks[0].text)
+			blocks = blocks[1:]
+			if len(blocks) > 0 {
+				b.WriteString("\n")
+			}
+		}
+		renderMarkdownHTML(b, blocks)
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+}
+
+func renderTableHTML(b *strings.Builder, block mdBlock) {
+	b.WriteString("<table>\n")
+	for r, row := range block.rows {
+		if r == 0 {
+			b.WriteString("<thead>\n")
+		} else if r == 1 {
+			b.WriteString("<tbody>\n")
+		}
+		b.WriteString("<tr>")
+		cellTag := "td"
+		if r == 0 {
+			cellTag = "th"
+		}
+		for c, cell := range row {
+			b.WriteString("<" + cellTag)
+			if align := block.align[c]; align != "" {
+				b.WriteString(` style="text-align: ` + align + `"`)
+			}
+			b.WriteString(">")
+			renderInlineHTML(b, cell)
+			b.WriteString("</" + cellTag + ">")
+		}
+		b.WriteString("</tr>\n")
+		if r == 0 {
+			b.WriteString("</thead>\n")
+		}
+	}
+	if len(block.rows) > 1 {
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+}
+
+// renderInlineHTML writes inline markdown as HTML
+func renderInlineHTML(b *strings.Builder, text string) {
+	for _, span := range parseInline(text) {
+		if span.image {
+			b.WriteString(`<img src="` + html.EscapeString(safeURL(span.href)) + `" alt="` + html.EscapeString(span.text) + `">`)
+			continue
+		}
+		var closing []string
+		if span.href != "" {
+			b.WriteString(`<a href="` + html.EscapeString(safeURL(span.href)) + `">`)
+			closing = append(closing, "</a>")
+		}
+		for _, tag := range []struct {
+			on   bool
+			name string
+		}{{span.bold, "strong"}, {span.italic, "em"}, {span.code, "code"}} {
+			if tag.on {
+				b.WriteString("<" + tag.name + ">")
+				closing = append(closing, "</"+tag.name+">")
+			}
+		}
+		b.WriteString(html.EscapeString(span.text))
+		for j := len(closing) - 1; j >= 0; j-- {
+			b.WriteString(closing[j])
+		}
+	}
+}
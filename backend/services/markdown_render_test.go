@@ -0,0 +1,66 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderTestHTML(markdown string) string {
+	var b strings.Builder
+	renderMarkdownHTML(&b, parseMarkdownBlocks(markdown, map[string]bool{}))
+	return b.String()
+}
+
+func TestRenderMarkdownHTML_Blocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"headings get GitHub anchors", "# Goals\n\n## Goals\n\nSetext\n---", "<h1 id=\"goals\">Goals</h1>\n<h2 id=\"goals-1\">Goals</h2>\n<h2 id=\"setext\">Setext</h2>\n"},
+		{"paragraph lines join", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>\n"},
+		{"fenced code is escaped", "```go\nif a < b {}\n# not a heading\n```", "<pre><code class=\"language-go\">if a &lt; b {}\n# not a heading</code></pre>\n"},
+		{"nested lists", "- a\n  - b\n- c", "<ul>\n<li>a\n<ul>\n<li>b</li>\n</ul>\n</li>\n<li>c</li>\n</ul>\n"},
+		{"ordered list start", "3. x\n4. y", "<ol start=\"3\">\n<li>x</li>\n<li>y</li>\n</ol>\n"},
+		{"task list", "- [x] done\n- [ ] open", "<ul>\n<li class=\"task\"><input type=\"checkbox\" disabled checked> done</li>\n<li class=\"task\"><input type=\"checkbox\" disabled> open</li>\n</ul>\n"},
+		{"blockquote", "> quoted\n> text", "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>\n"},
+		{"table with alignment", "| A | B |\n|:--|--:|\n| 1 | 2 \\| 3 |", "<table>\n<thead>\n<tr><th style=\"text-align: left\">A</th><th style=\"text-align: right\">B</th></tr>\n</thead>\n<tbody>\n<tr><td style=\"text-align: left\">1</td><td style=\"text-align: right\">2 | 3</td></tr>\n</tbody>\n</table>\n"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"reference links", "See [the PRD][prd] and [Goals][].\n\n[prd]: prd.md\n[goals]: #goals", "<p>See <a href=\"prd.md\">the PRD</a> and <a href=\"#goals\">Goals</a>.</p>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTestHTML(tt.markdown); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderInlineHTML(t *testing.T) {
+	tests := []struct {
+		markdown string
+		want     string
+	}{
+		{"**bold** and *em* and `a<b`", "<strong>bold</strong> and <em>em</em> and <code>a&lt;b</code>"},
+		{"snake_case_name stays", "snake_case_name stays"},
+		{"[**docs**](guide.md#setup \"Guide\")", "<a href=\"guide.md#setup\"><strong>docs</strong></a>"},
+		{"![flow](img/flow.png)", "<img src=\"img/flow.png\" alt=\"flow\">"},
+		{"see https://example.com/a.", "see <a href=\"https://example.com/a\">https://example.com/a</a>."},
+		{"[x](javascript:alert(1)) <b>raw</b>", "<a href=\"#\">x</a> &lt;b&gt;raw&lt;/b&gt;"},
+		{`\*not em\*`, "*not em*"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		renderInlineHTML(&b, tt.markdown)
+		if got := b.String(); got != tt.want {
+			t.Errorf("renderInlineHTML(%q) = %q, want %q", tt.markdown, got, tt.want)
+		}
+	}
+}
+
+func TestInlinePlainText(t *testing.T) {
+	if got := inlinePlainText("Story 1.2: **Login** via [`OAuth`](x.md)"); got != "Story 1.2: Login via OAuth" {
+		t.Errorf("got %q", got)
+	}
+}
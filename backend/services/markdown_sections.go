@@ -0,0 +1,104 @@
+package services
+
+import (
+	"strings"
+)
+
+// markdownSection is an ATX heading and the text that follows it up to the next heading.
+type markdownSection struct {
+	Level   int      // Heading level 1-6; 0 for text before the first heading
+	Heading string   // Heading text without markers
+	Path    []string // Enclosing headings, outermost first, ending with Heading
+	Line    int      // 1-based line of the heading in the source; 1 for the preamble
+	Content string   // Text below the heading line
+}
+
+// splitMarkdownSections splits a markdown body into sections at ATX headings.
+// Lines inside fenced code blocks are never treated as headings. A preamble section
+// is returned only if there is non-blank text before the first heading.
+func splitMarkdownSections(body string) []markdownSection {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	var sections []markdownSection
+	current := markdownSection{Line: 1}
+	var content []string
+	var stack []string // Heading text by level - 1
+	fence := ""
+
+	flush := func() {
+		current.Content = strings.Join(content, "\n")
+		if current.Level > 0 || strings.TrimSpace(current.Content) != "" {
+			sections = append(sections, current)
+		}
+		content = nil
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			content = append(content, line)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			content = append(content, line)
+			continue
+		}
+
+		level, heading, ok := parseATXHeading(line)
+		if !ok {
+			content = append(content, line)
+			continue
+		}
+
+		flush()
+		if len(stack) >= level {
+			stack = stack[:level-1]
+		}
+		for len(stack) < level-1 {
+			stack = append(stack, "") // Skipped levels, e.g. # followed by ###
+		}
+		stack = append(stack, heading)
+
+		path := make([]string, 0, len(stack))
+		for _, h := range stack {
+			if h != "" {
+				path = append(path, h)
+			}
+		}
+		current = markdownSection{Level: level, Heading: heading, Path: path, Line: i + 1}
+	}
+	flush()
+
+	return sections
+}
+
+// parseATXHeading parses a "# Heading" line, returning its level and text.
+// Up to three leading spaces are allowed and optional closing #s are removed.
+func parseATXHeading(line string) (int, string, bool) {
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	if indent > 3 {
+		return 0, "", false
+	}
+	rest := line[indent:]
+
+	level := 0
+	for level < len(rest) && rest[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	if level < len(rest) && rest[level] != ' ' && rest[level] != '\t' {
+		return 0, "", false // "#hashtag" is not a heading
+	}
+
+	text := strings.TrimSpace(rest[level:])
+	if closing := strings.TrimRight(text, "#"); closing != text && (closing == "" || strings.HasSuffix(closing, " ")) {
+		text = strings.TrimSpace(closing)
+	}
+	return level, text, true
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitMarkdownSections(t *testing.T) {
+	body := "Intro text\n\n# PRD\n\nOverview\n\n## Goals ##\n\nShip it\n\n```\n# not a heading\n```\n\n### Metrics\n\nUptime\n\n## Scope\n\n#hashtag line\n"
+
+	sections := splitMarkdownSections(body)
+	var headings []string
+	for _, s := range sections {
+		headings = append(headings, strings.Join(s.Path, " > "))
+	}
+	want := []string{"", "PRD", "PRD > Goals", "PRD > Goals > Metrics", "PRD > Scope"}
+	if strings.Join(headings, "|") != strings.Join(want, "|") {
+		t.Fatalf("Section paths = %q, want %q", headings, want)
+	}
+
+	if sections[0].Level != 0 || strings.TrimSpace(sections[0].Content) != "Intro text" {
+		t.Errorf("Expected preamble section, got %+v", sections[0])
+	}
+	if !strings.Contains(sections[2].Content, "# not a heading") {
+		t.Errorf("Fenced code should stay in the Goals section, got %q", sections[2].Content)
+	}
+	if sections[2].Line != 7 {
+		t.Errorf("Goals heading line = %d, want 7", sections[2].Line)
+	}
+	if !strings.Contains(sections[4].Content, "#hashtag") {
+		t.Errorf("#hashtag should not start a section, got %q", sections[4].Content)
+	}
+}
+
+func TestSplitMarkdownSections_SkippedLevels(t *testing.T) {
+	sections := splitMarkdownSections("# Top\n### Deep\ntext\n")
+	if len(sections) != 2 || strings.Join(sections[1].Path, ">") != "Top>Deep" {
+		t.Errorf("Expected skipped level omitted from path, got %+v", sections)
+	}
+}
@@ -0,0 +1,431 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// MetricsServiceError represents a structured error from the metrics service
+type MetricsServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *MetricsServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for metrics service
+const (
+	ErrCodeMetricsConfigNotLoaded = "config_not_loaded"
+	ErrCodeEventLogFailed         = "event_log_failed"
+)
+
+// MetricsService records every workflow_status and development_status transition in a
+// per-project event log and derives progress metrics from it. Transitions are found by
+// comparing each reload of the status files with the state the log last recorded, so
+// changes made through the API, by agents or by hand are all captured.
+type MetricsService struct {
+	mu                    sync.Mutex // Serialises recording
+	configService         *BMadConfigService
+	pathService           *WorkflowPathService
+	workflowStatusService *WorkflowStatusService
+	store                 *storage.EventStore
+	state                 map[string]string // Last recorded value by kind + ":" + key; nil until loaded from the log
+	now                   func() time.Time
+}
+
+// NewMetricsService creates a new MetricsService
+func NewMetricsService(configService *BMadConfigService, pathService *WorkflowPathService, workflowStatusService *WorkflowStatusService, store *storage.EventStore) *MetricsService {
+	return &MetricsService{
+		configService:         configService,
+		pathService:           pathService,
+		workflowStatusService: workflowStatusService,
+		store:                 store,
+		now:                   time.Now,
+	}
+}
+
+// Seed records the current state on startup. On a project's first run every entry is
+// logged as a baseline event; later runs log what changed while the studio was closed.
+func (s *MetricsService) Seed() error {
+	return s.record()
+}
+
+// StatusChanged implements StatusChangeListener.
+func (s *MetricsService) StatusChanged() {
+	if err := s.record(); err != nil {
+		log.Printf("Warning: Failed to record progress events: %v", err)
+	}
+}
+
+// record appends an event for every entry whose value differs from the recorded state.
+func (s *MetricsService) record() error {
+	projectRoot, err := s.projectRoot()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	baseline := false
+	if s.state == nil {
+		events, err := s.store.Load(projectRoot)
+		if err != nil {
+			return &MetricsServiceError{Code: ErrCodeEventLogFailed, Message: fmt.Sprintf("Failed to load event log: %v", err)}
+		}
+		s.state = replayEvents(events, time.Time{})
+		baseline = len(events) == 0
+	}
+
+	// A status file that is missing as a whole (mid checkout, branch switch) is not a removal of
+	// its entries; they are compared again once it reappears
+	current := make(map[string]string)
+	present := make(map[string]bool)
+	if workflows := s.workflowStatusService.GetWorkflowStatuses(); workflows != nil {
+		present[types.ProgressEventWorkflow] = true
+		for id, value := range workflows {
+			current[types.ProgressEventWorkflow+":"+id] = value
+		}
+	}
+	if sprint := s.workflowStatusService.GetSprintStatus(); sprint != nil {
+		present[types.ProgressEventSprint] = true
+		for key, value := range sprint.DevelopmentStatus {
+			current[types.ProgressEventSprint+":"+key] = value
+		}
+	}
+
+	timestamp := types.Timestamp(s.now())
+	var events []types.ProgressEvent
+	for stateKey, value := range current {
+		if s.state[stateKey] != value {
+			events = append(events, progressEvent(timestamp, stateKey, s.state[stateKey], value, baseline))
+		}
+	}
+	for stateKey, value := range s.state {
+		if _, ok := current[stateKey]; ok || value == "" {
+			continue
+		}
+		if event := progressEvent(timestamp, stateKey, value, "", false); present[event.Kind] {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Kind != events[j].Kind {
+			return events[i].Kind > events[j].Kind // Workflows first
+		}
+		return events[i].Key < events[j].Key
+	})
+
+	if err := s.store.Append(projectRoot, events); err != nil {
+		return &MetricsServiceError{Code: ErrCodeEventLogFailed, Message: fmt.Sprintf("Failed to append to event log: %v", err)}
+	}
+	for _, event := range events {
+		s.state[event.Kind+":"+event.Key] = event.To
+	}
+	return nil
+}
+
+func progressEvent(timestamp types.Timestamp, stateKey, from, to string, baseline bool) types.ProgressEvent {
+	kind := types.ProgressEventSprint
+	key, isWorkflow := stateKeySuffix(stateKey, types.ProgressEventWorkflow)
+	if isWorkflow {
+		kind = types.ProgressEventWorkflow
+	} else {
+		key, _ = stateKeySuffix(stateKey, types.ProgressEventSprint)
+	}
+	return types.ProgressEvent{Timestamp: timestamp, Kind: kind, Key: key, From: from, To: to, Baseline: baseline}
+}
+
+// replayEvents returns the value of every entry after the events before until; a zero until replays all.
+func replayEvents(events []types.ProgressEvent, until time.Time) map[string]string {
+	state := make(map[string]string)
+	for _, event := range events {
+		if !until.IsZero() && !event.Timestamp.Time().Before(until) {
+			break
+		}
+		if event.To == "" {
+			delete(state, event.Kind+":"+event.Key)
+			continue
+		}
+		state[event.Kind+":"+event.Key] = event.To
+	}
+	return state
+}
+
+// Timeline returns the recorded events, oldest first.
+func (s *MetricsService) Timeline() (*types.ProgressTimelineResponse, error) {
+	events, err := s.events()
+	if err != nil {
+		return nil, err
+	}
+	return &types.ProgressTimelineResponse{Events: events}, nil
+}
+
+// Metrics computes phase durations, story cycle times, weekly throughput and a daily burndown from the event log.
+func (s *MetricsService) Metrics() (*types.MetricsResponse, error) {
+	events, err := s.events()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	resp := &types.MetricsResponse{
+		PhaseDurations:  []types.PhaseDuration{},
+		StoryCycleTimes: storyCycleTimes(events),
+		Throughput:      weeklyThroughput(events, now, s.workflowStatusService.isComplete),
+		Burndown:        burndown(events, now),
+	}
+	if len(events) > 0 {
+		first := events[0].Timestamp
+		resp.TrackingSince = &first
+	}
+
+	var total float64
+	var completed int
+	for _, story := range resp.StoryCycleTimes {
+		if story.CycleTimeHours != nil {
+			total += *story.CycleTimeHours
+			completed++
+		}
+	}
+	if completed > 0 {
+		avg := roundHours(total / float64(completed))
+		resp.AverageCycleTimeHours = &avg
+	}
+
+	// Phase durations need the track; without path definitions they are left empty
+	phases, err := s.pathService.GetPhases()
+	if err != nil {
+		return resp, nil
+	}
+	status, err := s.workflowStatusService.GetStatus()
+	if err != nil {
+		return resp, nil
+	}
+	resp.PhaseDurations = phaseDurations(events, phases.Phases, status.WorkflowStatuses, now, s.workflowStatusService.isComplete)
+	return resp, nil
+}
+
+// events loads the project's event log
+func (s *MetricsService) events() ([]types.ProgressEvent, error) {
+	projectRoot, err := s.projectRoot()
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.store.Load(projectRoot)
+	if err != nil {
+		return nil, &MetricsServiceError{Code: ErrCodeEventLogFailed, Message: fmt.Sprintf("Failed to load event log: %v", err)}
+	}
+	return events, nil
+}
+
+func (s *MetricsService) projectRoot() (string, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return "", &MetricsServiceError{
+			Code:    ErrCodeMetricsConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+	return config.ProjectRoot, nil
+}
+
+// phaseDurations dates each phase from the first event of one of its workflows to the
+// completion of its last required workflow.
+func phaseDurations(events []types.ProgressEvent, phases []types.PhaseResponse, statuses map[string]types.WorkflowCompletionStatus, now time.Time, isComplete func(string) bool) []types.PhaseDuration {
+	first := make(map[string]time.Time)
+	completedAt := make(map[string]time.Time)
+	for _, event := range events {
+		if event.Kind != types.ProgressEventWorkflow {
+			continue
+		}
+		ts := event.Timestamp.Time()
+		if _, ok := first[event.Key]; !ok {
+			first[event.Key] = ts
+		}
+		if isComplete(event.To) && !isComplete(event.From) {
+			completedAt[event.Key] = ts
+		}
+	}
+
+	result := make([]types.PhaseDuration, 0, len(phases))
+	for _, phase := range phases {
+		duration := types.PhaseDuration{PhaseNum: phase.PhaseNum, Name: phase.Name}
+
+		var started, finished time.Time
+		complete, required := true, 0
+		for _, wf := range phase.Workflows {
+			if ts, ok := first[wf.ID]; ok && (started.IsZero() || ts.Before(started)) {
+				started = ts
+			}
+			if !statuses[wf.ID].IsRequired {
+				continue
+			}
+			required++
+			ts, ok := completedAt[wf.ID]
+			if !statuses[wf.ID].IsComplete || !ok {
+				complete = false
+				continue
+			}
+			if ts.After(finished) {
+				finished = ts
+			}
+		}
+
+		if !started.IsZero() {
+			startedAt := types.Timestamp(started)
+			duration.StartedAt = &startedAt
+			end := now
+			if complete && required > 0 {
+				completed := types.Timestamp(finished)
+				duration.CompletedAt = &completed
+				end = finished
+			}
+			hours := roundHours(end.Sub(started).Hours())
+			duration.DurationHours = &hours
+		}
+		result = append(result, duration)
+	}
+	return result
+}
+
+// storyCycleTimes measures stories from their first move to in-progress to their last move to done.
+// Baseline events are skipped: a story found in progress has no known start.
+func storyCycleTimes(events []types.ProgressEvent) []types.StoryCycleTime {
+	started := make(map[string]time.Time)
+	done := make(map[string]time.Time)
+	current := make(map[string]string)
+	for _, event := range events {
+		if event.Kind != types.ProgressEventSprint {
+			continue
+		}
+		if _, _, _, ok := parseStoryKey(event.Key); !ok {
+			continue
+		}
+		current[event.Key] = event.To
+		if event.Baseline {
+			continue
+		}
+		ts := event.Timestamp.Time()
+		if _, ok := started[event.Key]; !ok && event.To == types.StoryInProgress {
+			started[event.Key] = ts
+		}
+		if event.To == types.StoryDone {
+			done[event.Key] = ts
+		}
+	}
+
+	result := []types.StoryCycleTime{}
+	for key, start := range started {
+		cycle := types.StoryCycleTime{Key: key, StartedAt: types.Timestamp(start)}
+		if end, ok := done[key]; ok && current[key] == types.StoryDone {
+			completed := types.Timestamp(end)
+			hours := roundHours(end.Sub(start).Hours())
+			cycle.CompletedAt = &completed
+			cycle.CycleTimeHours = &hours
+		}
+		result = append(result, cycle)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].StartedAt.Time().Equal(result[j].StartedAt.Time()) {
+			return result[i].StartedAt.Time().Before(result[j].StartedAt.Time())
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// weeklyThroughput counts stories moved to done and workflows completed per week, from the
+// week of the first event to the current week. Baseline events are not completions.
+func weeklyThroughput(events []types.ProgressEvent, now time.Time, isComplete func(string) bool) []types.WeeklyThroughput {
+	if len(events) == 0 {
+		return []types.WeeklyThroughput{}
+	}
+
+	counts := make(map[string]*types.WeeklyThroughput)
+	var weeks []types.WeeklyThroughput
+	for week := weekStart(events[0].Timestamp.Time()); !week.After(now.UTC()); week = week.AddDate(0, 0, 7) {
+		weeks = append(weeks, types.WeeklyThroughput{WeekStart: week.Format(time.DateOnly)})
+	}
+	for i := range weeks {
+		counts[weeks[i].WeekStart] = &weeks[i]
+	}
+
+	for _, event := range events {
+		if event.Baseline {
+			continue
+		}
+		week, ok := counts[weekStart(event.Timestamp.Time()).Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+		switch event.Kind {
+		case types.ProgressEventSprint:
+			if _, _, _, isStory := parseStoryKey(event.Key); isStory && event.To == types.StoryDone && event.From != types.StoryDone {
+				week.StoriesDone++
+			}
+		case types.ProgressEventWorkflow:
+			if isComplete(event.To) && !isComplete(event.From) {
+				week.WorkflowsCompleted++
+			}
+		}
+	}
+	return weeks
+}
+
+// burndown reports the stories in development_status at the end of each day, from the
+// day of the first event to today.
+func burndown(events []types.ProgressEvent, now time.Time) []types.BurndownPoint {
+	points := []types.BurndownPoint{}
+	if len(events) == 0 {
+		return points
+	}
+
+	first := events[0].Timestamp.Time().UTC()
+	for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC); !day.After(now.UTC()); day = day.AddDate(0, 0, 1) {
+		point := types.BurndownPoint{Date: day.Format(time.DateOnly)}
+		for stateKey, value := range replayEvents(events, day.AddDate(0, 0, 1)) {
+			key, isSprint := stateKeySuffix(stateKey, types.ProgressEventSprint)
+			if !isSprint {
+				continue
+			}
+			if _, _, _, ok := parseStoryKey(key); !ok {
+				continue
+			}
+			point.Total++
+			if value == types.StoryDone {
+				point.Done++
+			}
+		}
+		point.Remaining = point.Total - point.Done
+		points = append(points, point)
+	}
+	return points
+}
+
+// stateKeySuffix returns the key of a state entry of the given kind
+func stateKeySuffix(stateKey, kind string) (string, bool) {
+	return strings.CutPrefix(stateKey, kind+":")
+}
+
+// weekStart returns midnight UTC of the Monday starting t's week
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// roundHours rounds to one decimal place
+func roundHours(h float64) float64 {
+	return math.Round(h*10) / 10
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+func TestMetricsService_RecordsTransitionsAndComputesMetrics(t *testing.T) {
+	_, statusService, pathService, root := setupStatusWriterTest(t, "")
+	implDir := filepath.Join(root, "_bmad-output", "implementation-artifacts")
+	if err := os.MkdirAll(implDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // A Monday
+	store := storage.NewEventStoreWithDir(t.TempDir())
+	metrics := NewMetricsService(statusService.configService, pathService, statusService, store)
+	metrics.now = func() time.Time { return clock }
+	statusService.AddListener(metrics)
+
+	update := func(at time.Time, workflows, sprint string) {
+		t.Helper()
+		clock = at
+		if err := os.WriteFile(filepath.Join(root, "_bmad-output", "planning-artifacts", "bmm-workflow-status.yaml"), []byte(workflows), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(implDir, "sprint-status.yaml"), []byte(sprint), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := statusService.Reload(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	update(clock, "workflow_status:\n  product-brief: required\n  prd: required\n",
+		"development_status:\n  epic-1: backlog\n  1-1-setup: backlog\n  1-2-login: backlog\n")
+	update(time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC), "workflow_status:\n  product-brief: required\n  prd: required\n",
+		"development_status:\n  epic-1: in-progress\n  1-1-setup: in-progress\n  1-2-login: backlog\n")
+	update(time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC), "workflow_status:\n  product-brief: docs/brief.md\n  prd: required\n",
+		"development_status:\n  epic-1: in-progress\n  1-1-setup: done\n  1-2-login: backlog\n")
+	update(time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC), "workflow_status:\n  product-brief: docs/brief.md\n  prd: docs/prd.md\n",
+		"development_status:\n  epic-1: in-progress\n  1-1-setup: done\n  1-2-login: in-progress\n")
+	clock = time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+
+	timeline, err := metrics.Timeline()
+	if err != nil {
+		t.Fatalf("Timeline failed: %v", err)
+	}
+	if len(timeline.Events) != 11 {
+		t.Fatalf("Expected 11 events, got %d: %+v", len(timeline.Events), timeline.Events)
+	}
+	if first := timeline.Events[0]; !first.Baseline || first.Kind != types.ProgressEventWorkflow || first.Key != "prd" {
+		t.Errorf("Expected baseline workflow events first, got %+v", first)
+	}
+
+	resp, err := metrics.Metrics()
+	if err != nil {
+		t.Fatalf("Metrics failed: %v", err)
+	}
+	if resp.TrackingSince == nil || !resp.TrackingSince.Time().Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected tracking_since %v", resp.TrackingSince)
+	}
+
+	if len(resp.StoryCycleTimes) != 2 {
+		t.Fatalf("Expected 2 cycle times, got %+v", resp.StoryCycleTimes)
+	}
+	if c := resp.StoryCycleTimes[0]; c.Key != "1-1-setup" || c.CycleTimeHours == nil || *c.CycleTimeHours != 48 {
+		t.Errorf("Expected 1-1-setup to take 48h, got %+v", c)
+	}
+	if c := resp.StoryCycleTimes[1]; c.Key != "1-2-login" || c.CompletedAt != nil {
+		t.Errorf("Expected 1-2-login open, got %+v", c)
+	}
+	if resp.AverageCycleTimeHours == nil || *resp.AverageCycleTimeHours != 48 {
+		t.Errorf("Expected average cycle time 48h, got %v", resp.AverageCycleTimeHours)
+	}
+
+	wantWeeks := []types.WeeklyThroughput{
+		{WeekStart: "2026-03-02", StoriesDone: 1, WorkflowsCompleted: 1},
+		{WeekStart: "2026-03-09", StoriesDone: 0, WorkflowsCompleted: 1},
+	}
+	if len(resp.Throughput) != len(wantWeeks) || resp.Throughput[0] != wantWeeks[0] || resp.Throughput[1] != wantWeeks[1] {
+		t.Errorf("Expected throughput %+v, got %+v", wantWeeks, resp.Throughput)
+	}
+
+	if len(resp.Burndown) != 10 {
+		t.Fatalf("Expected 10 burndown days, got %+v", resp.Burndown)
+	}
+	if p := resp.Burndown[0]; p.Date != "2026-03-02" || p.Total != 2 || p.Remaining != 2 {
+		t.Errorf("Unexpected first burndown point %+v", p)
+	}
+	if p := resp.Burndown[3]; p.Date != "2026-03-05" || p.Done != 1 || p.Remaining != 1 {
+		t.Errorf("Unexpected burndown point after the first story %+v", p)
+	}
+
+	if len(resp.PhaseDurations) != 2 {
+		t.Fatalf("Expected 2 phases, got %+v", resp.PhaseDurations)
+	}
+	if p := resp.PhaseDurations[0]; p.CompletedAt == nil || p.DurationHours == nil || *p.DurationHours != 73 {
+		t.Errorf("Expected Analysis to take 73h, got %+v", p)
+	}
+	if p := resp.PhaseDurations[1]; p.CompletedAt == nil || !p.CompletedAt.Time().Equal(time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected Planning completed on 2026-03-10, got %+v", p)
+	}
+
+	// A restart replays the log instead of recording a second baseline
+	restarted := NewMetricsService(statusService.configService, pathService, statusService, store)
+	restarted.now = func() time.Time { return clock }
+	if err := restarted.Seed(); err != nil {
+		t.Fatal(err)
+	}
+	if timeline, _ := restarted.Timeline(); len(timeline.Events) != 11 {
+		t.Errorf("Expected no events from an unchanged restart, got %d", len(timeline.Events))
+	}
+}
+
+func TestMetricsService_RecordsRemovedEntries(t *testing.T) {
+	_, statusService, pathService, root := setupStatusWriterTest(t, "workflow_status:\n  prd: required\n")
+
+	metrics := NewMetricsService(statusService.configService, pathService, statusService, storage.NewEventStoreWithDir(t.TempDir()))
+	if err := metrics.Seed(); err != nil {
+		t.Fatal(err)
+	}
+	statusService.AddListener(metrics)
+
+	if err := os.WriteFile(filepath.Join(root, "_bmad-output", "planning-artifacts", "bmm-workflow-status.yaml"), []byte("workflow_status: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := statusService.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	timeline, err := metrics.Timeline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeline.Events) != 2 {
+		t.Fatalf("Expected baseline and removal events, got %+v", timeline.Events)
+	}
+	if removed := timeline.Events[1]; removed.Key != "prd" || removed.From != "required" || removed.To != "" || removed.Baseline {
+		t.Errorf("Unexpected removal event %+v", removed)
+	}
+}
+
+func TestMetricsService_IgnoresStatusFileMissingAsAWhole(t *testing.T) {
+	_, statusService, pathService, root := setupStatusWriterTest(t, "")
+	implDir := filepath.Join(root, "_bmad-output", "implementation-artifacts")
+	if err := os.MkdirAll(implDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	sprintPath := filepath.Join(implDir, "sprint-status.yaml")
+	workflowPath := filepath.Join(root, "_bmad-output", "planning-artifacts", "bmm-workflow-status.yaml")
+
+	clock := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	metrics := NewMetricsService(statusService.configService, pathService, statusService, storage.NewEventStoreWithDir(t.TempDir()))
+	metrics.now = func() time.Time { return clock }
+	statusService.AddListener(metrics)
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reload := func(at time.Time) {
+		t.Helper()
+		clock = at
+		if err := statusService.Reload(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(workflowPath, "workflow_status:\n  prd: required\n")
+	write(sprintPath, "development_status:\n  1-1-setup: in-progress\n")
+	reload(clock)
+	write(workflowPath, "workflow_status:\n  prd: docs/prd.md\n")
+	write(sprintPath, "development_status:\n  1-1-setup: done\n")
+	reload(time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC))
+
+	// Both files vanish during a checkout and come back unchanged a week later
+	os.Remove(workflowPath)
+	os.Remove(sprintPath)
+	reload(time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC))
+	write(workflowPath, "workflow_status:\n  prd: docs/prd.md\n")
+	write(sprintPath, "development_status:\n  1-1-setup: done\n")
+	reload(time.Date(2026, 3, 10, 9, 1, 0, 0, time.UTC))
+
+	timeline, err := metrics.Timeline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeline.Events) != 4 {
+		t.Fatalf("Expected only the baseline and the two completions, got %+v", timeline.Events)
+	}
+
+	resp, err := metrics.Metrics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, week := range resp.Throughput {
+		if week.WeekStart == "2026-03-09" && (week.StoriesDone != 0 || week.WorkflowsCompleted != 0) {
+			t.Errorf("Expected no completions counted for the reappearing files, got %+v", week)
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A4 page geometry in points
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+	pdfMargin     = 56.0
+	pdfBodySize   = 10.0
+	pdfCodeSize   = 8.5
+)
+
+// pdfFont is one of the standard PDF fonts, which readers provide, so nothing is embedded
+type pdfFont int
+
+const (
+	pdfRegular pdfFont = iota
+	pdfBold
+	pdfItalic
+	pdfMono
+)
+
+// pdfFontNames are the base fonts of pdfFont, in resource order F1, F2, ...
+var pdfFontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Courier"}
+
+// Glyph widths of printable ASCII (32-126) in 1/1000 em, from the Adobe font metrics.
+// Helvetica-Oblique shares the Helvetica widths and Courier is 600 throughout.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// winAnsiExtras maps the typographic characters markdown commonly uses to WinAnsiEncoding
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99, '→': '>', '←': '<', '✓': 'v', '✔': 'v',
+}
+
+// pdfEncode converts text to WinAnsiEncoding bytes. Characters it cannot represent become "?".
+func pdfEncode(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch b, ok := winAnsiExtras[r]; {
+		case ok:
+			out = append(out, b)
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 32 && r < 127, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// pdfTextWidth returns the width in points of encoded text set in font at size
+func pdfTextWidth(encoded []byte, font pdfFont, size float64) float64 {
+	total := 0
+	for _, c := range encoded {
+		switch {
+		case font == pdfMono:
+			total += 600
+		case c >= 32 && c < 127 && font == pdfBold:
+			total += helveticaBoldWidths[c-32]
+		case c >= 32 && c < 127:
+			total += helveticaWidths[c-32]
+		case c == 0x95:
+			total += 350
+		case c == 0x97 || c == 0x85 || c == 0x89:
+			total += 1000
+		case c >= 0x91 && c <= 0x94:
+			total += 333
+		default:
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfEscape writes encoded text as the body of a PDF string literal
+func pdfEscape(encoded []byte) string {
+	var b strings.Builder
+	for _, c := range encoded {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// pdfNum formats a coordinate
+func pdfNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// pdfPiece is a word, or the part of one, in a single font
+type pdfPiece struct {
+	text  []byte // WinAnsi encoded, including a leading space when spaced
+	font  pdfFont
+	size  float64
+	link  bool
+	width float64
+}
+
+// pdfDocument lays out markdown blocks on A4 pages and writes them as a PDF file. It handles
+// headings, paragraphs, lists, quotes, code and tables; images are shown by their alt text.
+type pdfDocument struct {
+	pages []*bytes.Buffer // Content streams
+	page  *bytes.Buffer
+	y     float64 // Baseline of the next line
+	gray  bool    // Set text in gray, for quotes
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.newPage()
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// ensure starts a new page unless height fits above the bottom margin
+func (d *pdfDocument) ensure(height float64) {
+	if d.y-height < pdfMargin && d.y < pdfPageHeight-pdfMargin {
+		d.newPage()
+	}
+}
+
+// addBlocks lays out blocks in the column starting at x with the given width
+func (d *pdfDocument) addBlocks(blocks []mdBlock, x, width float64) {
+	for _, block := range blocks {
+		switch block.kind {
+		case mdHeading:
+			size := map[int]float64{1: 20, 2: 16, 3: 13, 4: 11.5}[block.level]
+			if size == 0 {
+				size = pdfBodySize
+			}
+			d.y -= size * 0.6
+			d.ensure(size * 3) // Keep a heading with the line after it
+			d.addText(parseInline(block.text), pdfBold, size, x, width)
+			d.y -= 4
+		case mdParagraph:
+			d.addText(parseInline(block.text), pdfRegular, pdfBodySize, x, width)
+			d.y -= 6
+		case mdCodeBlock:
+			d.addCode(block.text, x, width)
+			d.y -= 6
+		case mdRule:
+			d.ensure(12)
+			d.y -= 4
+			fmt.Fprintf(d.page, "0.75 G 0.5 w %s %s m %s %s l S\n", pdfNum(x), pdfNum(d.y), pdfNum(x+width), pdfNum(d.y))
+			d.y -= 10
+		case mdQuote:
+			gray := d.gray
+			d.gray = true
+			d.addBlocks(block.blocks, x+14, width-14)
+			d.gray = gray
+		case mdList:
+			d.addList(block, x, width)
+			d.y -= 2
+		case mdTable:
+			d.addTable(block, x, width)
+			d.y -= 8
+		}
+	}
+}
+
+func (d *pdfDocument) addList(block mdBlock, x, width float64) {
+	const indent = 16.0
+	for n, item := range block.items {
+		marker := "•"
+		switch {
+		case item.task && item.done:
+			marker = "[x]"
+		case item.task:
+			marker = "[ ]"
+		case block.ordered:
+			marker = strconv.Itoa(block.start+n) + "."
+		}
+		d.ensure(pdfBodySize * 1.4)
+		// The marker sits on the baseline of the item's first line
+		encoded := pdfEncode(marker)
+		markerX := x + indent - 4 - pdfTextWidth(encoded, pdfRegular, pdfBodySize)
+		fmt.Fprintf(d.page, "BT %s /F1 %s Tf %s %s Td (%s) Tj ET\n", d.textColor(false), pdfNum(pdfBodySize), pdfNum(markerX), pdfNum(d.y-pdfBodySize), pdfEscape(encoded))
+
+		blocks := item.blocks
+		if len(blocks) > 0 && blocks[0].kind == mdParagraph {
+			d.addText(parseInline(blocks[0].text), pdfRegular, pdfBodySize, x+indent, width-indent)
+			d.y -= 2
+			blocks = blocks[1:]
+		} else if len(blocks) == 0 {
+			d.y -= pdfBodySize * 1.4
+		}
+		d.addBlocks(blocks, x+indent, width-indent)
+	}
+}
+
+func (d *pdfDocument) addCode(code string, x, width float64) {
+	leading := pdfCodeSize * 1.3
+	perLine := int((width - 8) / (pdfCodeSize * 0.6))
+	for _, line := range strings.Split(code, "\n") {
+		encoded := pdfEncode(line)
+		for {
+			chunk := encoded
+			if len(chunk) > perLine {
+				chunk = chunk[:perLine]
+			}
+			d.ensure(leading)
+			fmt.Fprintf(d.page, "0.95 g %s %s %s %s re f\n", pdfNum(x), pdfNum(d.y-leading), pdfNum(width), pdfNum(leading))
+			fmt.Fprintf(d.page, "BT %s /F4 %s Tf %s %s Td (%s) Tj ET\n", d.textColor(false), pdfNum(pdfCodeSize), pdfNum(x+4), pdfNum(d.y-pdfCodeSize), pdfEscape(chunk))
+			d.y -= leading
+			if len(encoded) <= perLine {
+				break
+			}
+			encoded = encoded[perLine:]
+		}
+	}
+}
+
+func (d *pdfDocument) addTable(block mdBlock, x, width float64) {
+	const padding = 4.0
+	columns := len(block.rows[0])
+	colWidth := width / float64(columns)
+	leading := pdfBodySize * 1.3
+
+	for r, row := range block.rows {
+		font := pdfRegular
+		if r == 0 {
+			font = pdfBold
+		}
+		cells := make([][][]pdfPiece, columns)
+		lines := 1
+		for c, cell := range row {
+			cells[c] = breakLines(pdfPieces(parseInline(cell), font, pdfBodySize), colWidth-2*padding)
+			lines = max(lines, len(cells[c]))
+		}
+		height := float64(lines)*leading + padding
+		d.ensure(height)
+		for c := range cells {
+			for l, line := range cells[c] {
+				d.drawLine(line, x+float64(c)*colWidth+padding, d.y-pdfBodySize-float64(l)*leading)
+			}
+		}
+		d.y -= height
+		fmt.Fprintf(d.page, "0.75 G 0.5 w %s %s m %s %s l S\n", pdfNum(x), pdfNum(d.y), pdfNum(x+width), pdfNum(d.y))
+		d.y -= padding / 2
+	}
+}
+
+// addText lays out inline spans as wrapped lines
+func (d *pdfDocument) addText(spans []mdSpan, font pdfFont, size, x, width float64) {
+	leading := size * 1.4
+	for _, line := range breakLines(pdfPieces(spans, font, size), width) {
+		d.ensure(leading)
+		d.drawLine(line, x, d.y-size)
+		d.y -= leading
+	}
+}
+
+// drawLine writes one line of pieces with its baseline at y
+func (d *pdfDocument) drawLine(line []pdfPiece, x, y float64) {
+	if len(line) == 0 {
+		return
+	}
+	fmt.Fprintf(d.page, "BT %s %s Td", pdfNum(x), pdfNum(y))
+	for _, piece := range line {
+		fmt.Fprintf(d.page, " %s /F%d %s Tf (%s) Tj", d.textColor(piece.link), piece.font+1, pdfNum(piece.size), pdfEscape(piece.text))
+	}
+	d.page.WriteString(" ET\n")
+}
+
+func (d *pdfDocument) textColor(link bool) string {
+	switch {
+	case link:
+		return "0.1 0.3 0.75 rg"
+	case d.gray:
+		return "0.4 g"
+	}
+	return "0 g"
+}
+
+// pdfPieces splits spans into words set in base font, or the font a span's style calls for
+func pdfPieces(spans []mdSpan, base pdfFont, size float64) []pdfPiece {
+	var pieces []pdfPiece
+	spaced := false
+	for _, span := range spans {
+		font := base
+		switch {
+		case span.code:
+			font = pdfMono
+		case span.bold:
+			font = pdfBold
+		case span.italic && base == pdfRegular:
+			font = pdfItalic
+		}
+		text := span.text
+		if span.image {
+			text = "[" + span.text + "]"
+		}
+		for i, word := range strings.Fields(text) {
+			if i > 0 || (len(text) > 0 && strings.ContainsRune(" \n", rune(text[0]))) {
+				spaced = true
+			}
+			encoded := pdfEncode(word)
+			if spaced && len(pieces) > 0 {
+				encoded = append([]byte{' '}, encoded...)
+			}
+			pieceSize := size
+			if font == pdfMono {
+				pieceSize = size * 0.9 // Courier runs wide next to Helvetica
+			}
+			pieces = append(pieces, pdfPiece{text: encoded, font: font, size: pieceSize, link: span.href != "" && !span.image, width: pdfTextWidth(encoded, font, pieceSize)})
+			spaced = false
+		}
+		if len(text) > 0 && strings.ContainsRune(" \n", rune(text[len(text)-1])) {
+			spaced = true
+		}
+	}
+	return pieces
+}
+
+// breakLines wraps pieces to width. A line break is only taken before a spaced piece, so
+// words split across styles stay together; words wider than the line are cut.
+func breakLines(pieces []pdfPiece, width float64) [][]pdfPiece {
+	var lines [][]pdfPiece
+	var line []pdfPiece
+	lineWidth := 0.0
+	for _, piece := range pieces {
+		if len(line) > 0 && lineWidth+piece.width > width && piece.text[0] == ' ' {
+			lines = append(lines, line)
+			line, lineWidth = nil, 0
+		}
+		if len(line) == 0 && piece.text[0] == ' ' {
+			piece.text = piece.text[1:]
+			piece.width = pdfTextWidth(piece.text, piece.font, piece.size)
+		}
+		for len(line) == 0 && piece.width > width && len(piece.text) > 1 {
+			// Cut an overlong word at the last character that fits
+			cut := 1
+			for cut < len(piece.text) && pdfTextWidth(piece.text[:cut+1], piece.font, piece.size) <= width {
+				cut++
+			}
+			head := piece
+			head.text = piece.text[:cut]
+			head.width = pdfTextWidth(head.text, head.font, head.size)
+			lines = append(lines, []pdfPiece{head})
+			piece.text = piece.text[cut:]
+			piece.width = pdfTextWidth(piece.text, piece.font, piece.size)
+		}
+		line = append(line, piece)
+		lineWidth += piece.width
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// bytes writes the document as a PDF file with page numbers in the footer.
+func (d *pdfDocument) bytes(title string) ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects: 1 catalog, 2 page tree, 3 info, fonts, then a page and its content per page
+	fontBase := 4
+	pageBase := fontBase + len(pdfFontNames)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageBase+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object(fmt.Sprintf("<< /Title (%s) /Producer (BMAD Studio) >>", pdfEscape(pdfEncode(title))))
+
+	var fonts []string
+	for i, name := range pdfFontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+		fonts = append(fonts, fmt.Sprintf("/F%d %d 0 R", i+1, fontBase+i))
+	}
+
+	for i, page := range d.pages {
+		footer := pdfEncode(fmt.Sprintf("%d / %d", i+1, len(d.pages)))
+		footerX := (pdfPageWidth - pdfTextWidth(footer, pdfRegular, 8)) / 2
+		fmt.Fprintf(page, "BT 0.5 g /F1 8 Tf %s %s Td (%s) Tj ET\n", pdfNum(footerX), pdfNum(pdfMargin/2), pdfEscape(footer))
+
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pdfNum(pdfPageWidth), pdfNum(pdfPageHeight), strings.Join(fonts, " "), pageBase+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPDFDocument_WritesValidStructure(t *testing.T) {
+	var markdown strings.Builder
+	markdown.WriteString("# Plan (draft)\n\n| Name | Notes |\n|---|---|\n| a | b |\n\n```\ncode \\ line\n```\n\n")
+	for i := 0; i < 120; i++ {
+		markdown.WriteString("- Item with **bold**, `code` and a [link](https://example.com) – number " + strconv.Itoa(i) + "\n")
+	}
+
+	doc := newPDFDocument()
+	doc.addBlocks(parseMarkdownBlocks(markdown.String(), map[string]bool{}), pdfMargin, pdfPageWidth-2*pdfMargin)
+	out, err := doc.bytes("Plan (draft)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("expected a PDF header and trailer")
+	}
+	if len(doc.pages) < 2 {
+		t.Errorf("expected the list to flow onto a second page, got %d page(s)", len(doc.pages))
+	}
+	if !bytes.Contains(out, []byte("/Count "+strconv.Itoa(len(doc.pages)))) || !bytes.Contains(out, []byte(`/Title (Plan \(draft\))`)) {
+		t.Error("expected the page count and an escaped title")
+	}
+
+	// startxref points at the table, and every entry at its object
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := strconv.Itoa(i+1) + " 0 obj"; !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, out[offset:offset+10])
+		}
+	}
+}
+
+func TestBreakLines_WrapsAtSpacesAndCutsLongWords(t *testing.T) {
+	pieces := pdfPieces(parseInline("alpha **beta**gamma "+strings.Repeat("x", 80)), pdfRegular, 10)
+	lines := breakLines(pieces, 100)
+	if len(lines) < 3 {
+		t.Fatalf("expected the long word to be cut over several lines, got %d", len(lines))
+	}
+	first := ""
+	for _, piece := range lines[0] {
+		first += string(piece.text)
+	}
+	if first != "alpha betagamma" {
+		t.Errorf("expected words split across styles to stay together, got %q", first)
+	}
+	for _, line := range lines {
+		width := 0.0
+		for _, piece := range line {
+			width += piece.width
+		}
+		if width > 100.01 {
+			t.Errorf("line wider than the column: %.2f", width)
+		}
+	}
+}
+
+func TestPDFEncode(t *testing.T) {
+	if got := pdfEncode("café – “ok” 日本"); !bytes.Equal(got, []byte("caf\xe9 \x96 \x93ok\x94 ??")) {
+		t.Errorf("got %q", got)
+	}
+}
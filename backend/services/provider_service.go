@@ -3,16 +3,22 @@ package services
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"bmad-studio/backend/providers"
 )
 
 // ProviderService manages provider creation and operations.
-type ProviderService struct{}
+type ProviderService struct {
+	mu          sync.Mutex
+	activePulls map[string]bool // Ollama models currently being pulled, keyed by endpoint + model
+}
 
 // NewProviderService creates a new ProviderService instance.
 func NewProviderService() *ProviderService {
-	return &ProviderService{}
+	return &ProviderService{
+		activePulls: make(map[string]bool),
+	}
 }
 
 // GetProvider returns a provider instance for the given type and API key.
@@ -61,3 +67,60 @@ func (s *ProviderService) SendMessage(ctx context.Context, providerType string,
 	}
 	return provider.SendMessage(ctx, req)
 }
+
+// Embed returns embeddings for req.Input from the given provider.
+// Providers without an embeddings API return an embeddings_unsupported error.
+func (s *ProviderService) Embed(ctx context.Context, providerType string, apiKey string, req providers.EmbedRequest) (*providers.EmbedResponse, error) {
+	provider, err := s.GetProvider(providerType, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	embedder, ok := provider.(providers.Embedder)
+	if !ok {
+		return nil, &providers.ProviderError{
+			Code:        "embeddings_unsupported",
+			Message:     fmt.Sprintf("provider does not support embeddings: %s", providerType),
+			UserMessage: fmt.Sprintf("Provider '%s' does not offer embeddings. Use openai or ollama for semantic retrieval.", providerType),
+		}
+	}
+	return embedder.Embed(ctx, req)
+}
+
+// ShowOllamaModel returns details for a model installed on the Ollama instance at endpoint.
+func (s *ProviderService) ShowOllamaModel(ctx context.Context, endpoint string, model string) (*providers.ModelDetails, error) {
+	return providers.NewOllamaProvider(endpoint).ShowModel(ctx, model)
+}
+
+// DeleteOllamaModel removes a model from the Ollama instance at endpoint.
+func (s *ProviderService) DeleteOllamaModel(ctx context.Context, endpoint string, model string) error {
+	return providers.NewOllamaProvider(endpoint).DeleteModel(ctx, model)
+}
+
+// StartOllamaPull reserves a pull slot for model so that concurrent pulls of the
+// same model are rejected. The returned release function must be called when the pull ends.
+func (s *ProviderService) StartOllamaPull(endpoint string, model string) (func(), error) {
+	key := endpoint + "|" + model
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.activePulls[key] {
+		return nil, &providers.ProviderError{
+			Code:        "pull_in_progress",
+			Message:     fmt.Sprintf("pull already in progress: %s", model),
+			UserMessage: fmt.Sprintf("Model '%s' is already being pulled.", model),
+		}
+	}
+	s.activePulls[key] = true
+
+	return func() {
+		s.mu.Lock()
+		delete(s.activePulls, key)
+		s.mu.Unlock()
+	}, nil
+}
+
+// PullOllamaModel downloads a model to the Ollama instance at endpoint, reporting progress via onProgress.
+func (s *ProviderService) PullOllamaModel(ctx context.Context, endpoint string, model string, onProgress func(providers.PullProgress)) error {
+	return providers.NewOllamaProvider(endpoint).PullModel(ctx, model, onProgress)
+}
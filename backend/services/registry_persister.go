@@ -0,0 +1,92 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// registryFlushDelay is how long the registry waits for further changes before it is written
+	registryFlushDelay = 500 * time.Millisecond
+
+	// registryMaxFlushDelay bounds how long a steady stream of changes can postpone a write
+	registryMaxFlushDelay = 5 * time.Second
+)
+
+// registryPersister coalesces registry changes and writes them behind the caller:
+// each change pushes the write back by delay, but never more than maxDelay after
+// the first unsaved change, so a burst of file events costs one write.
+type registryPersister struct {
+	mu         sync.Mutex
+	saveMu     sync.Mutex // Serializes writes so a flush never overlaps the timer's
+	delay      time.Duration
+	maxDelay   time.Duration
+	save       func() error
+	timer      *time.Timer
+	dirtySince time.Time // Zero when there are no unsaved changes
+}
+
+func newRegistryPersister(delay, maxDelay time.Duration, save func() error) *registryPersister {
+	return &registryPersister{delay: delay, maxDelay: maxDelay, save: save}
+}
+
+// markDirty records an unsaved change and schedules a write.
+func (p *registryPersister) markDirty() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.dirtySince.IsZero() {
+		p.dirtySince = now
+	}
+	wait := p.delay
+	if remaining := p.maxDelay - now.Sub(p.dirtySince); remaining < wait {
+		wait = max(remaining, 0)
+	}
+
+	if p.timer == nil {
+		p.timer = time.AfterFunc(wait, p.flushInBackground)
+		return
+	}
+	p.timer.Reset(wait)
+}
+
+// flushInBackground is the timer callback; a failed write is logged and retried by flush.
+func (p *registryPersister) flushInBackground() {
+	if err := p.flush(); err != nil {
+		log.Printf("Warning: Failed to save artifact registry: %v", err)
+	}
+}
+
+// flush writes the registry now if it has unsaved changes. If the write fails the changes
+// stay unsaved and another write is attempted after maxDelay.
+func (p *registryPersister) flush() error {
+	p.saveMu.Lock()
+	defer p.saveMu.Unlock()
+
+	p.mu.Lock()
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	dirty := !p.dirtySince.IsZero()
+	p.dirtySince = time.Time{}
+	p.mu.Unlock()
+
+	if !dirty {
+		return nil
+	}
+	if err := p.save(); err != nil {
+		p.mu.Lock()
+		if p.dirtySince.IsZero() {
+			p.dirtySince = time.Now()
+		}
+		if p.timer == nil {
+			p.timer = time.AfterFunc(p.maxDelay, p.flushInBackground)
+		}
+		p.mu.Unlock()
+		return err
+	}
+	return nil
+}
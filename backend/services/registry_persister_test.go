@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRegistryPersister_CoalescesChanges(t *testing.T) {
+	var saves atomic.Int32
+	p := newRegistryPersister(20*time.Millisecond, time.Second, func() error {
+		saves.Add(1)
+		return nil
+	})
+
+	for i := 0; i < 10; i++ {
+		p.markDirty()
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if got := saves.Load(); got != 1 {
+		t.Errorf("expected 1 save, got %d", got)
+	}
+}
+
+func TestRegistryPersister_MaxDelayBoundsPostponement(t *testing.T) {
+	var saves atomic.Int32
+	p := newRegistryPersister(50*time.Millisecond, 100*time.Millisecond, func() error {
+		saves.Add(1)
+		return nil
+	})
+
+	// Changes every 10ms would postpone a pure debounce forever
+	deadline := time.Now().Add(300 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		p.markDirty()
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := saves.Load(); got < 2 {
+		t.Errorf("expected at least 2 saves during continuous changes, got %d", got)
+	}
+	if err := p.flush(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegistryPersister_FlushSavesImmediately(t *testing.T) {
+	var saves atomic.Int32
+	p := newRegistryPersister(time.Hour, time.Hour, func() error {
+		saves.Add(1)
+		return nil
+	})
+
+	p.markDirty()
+	if err := p.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := saves.Load(); got != 1 {
+		t.Fatalf("expected flush to save once, got %d", got)
+	}
+
+	// Nothing changed since, so a second flush is a no-op
+	if err := p.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := saves.Load(); got != 1 {
+		t.Errorf("expected a clean flush not to save, got %d saves", got)
+	}
+}
+
+func TestRegistryPersister_FlushReturnsSaveError(t *testing.T) {
+	want := errors.New("disk full")
+	p := newRegistryPersister(time.Hour, time.Hour, func() error { return want })
+
+	p.markDirty()
+	if err := p.flush(); !errors.Is(err, want) {
+		t.Errorf("expected %v, got %v", want, err)
+	}
+}
+
+func TestRegistryPersister_RetriesFailedSave(t *testing.T) {
+	var saves atomic.Int32
+	p := newRegistryPersister(time.Hour, 20*time.Millisecond, func() error {
+		if saves.Add(1) == 1 {
+			return errors.New("disk full")
+		}
+		return nil
+	})
+
+	p.markDirty()
+	if err := p.flush(); err == nil {
+		t.Fatal("expected the first save to fail")
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if got := saves.Load(); got != 2 {
+		t.Errorf("expected the failed save to be retried once, got %d saves", got)
+	}
+}
@@ -0,0 +1,464 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// RetrievalServiceError represents a structured error from the retrieval service
+type RetrievalServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *RetrievalServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for retrieval service
+const (
+	ErrCodeRetrievalConfigNotLoaded = "config_not_loaded"
+	ErrCodeInvalidRetrievalQuery    = "invalid_query"
+	ErrCodeVectorIndexFailed        = "vector_index_failed"
+)
+
+// vectorIndexVersion is bumped whenever chunking changes so existing indexes are rebuilt.
+const vectorIndexVersion = 1
+
+const (
+	// defaultEmbeddingProvider keeps retrieval local unless another provider is configured.
+	defaultEmbeddingProvider = "ollama"
+
+	// maxChunkChars bounds chunk size; longer sections are split at paragraph breaks.
+	maxChunkChars = 1500
+
+	// embedBatchSize is the number of chunks sent per embedding request.
+	embedBatchSize = 32
+
+	defaultTopK = 5
+	maxTopK     = 50
+)
+
+// RetrievalQuery holds a semantic retrieval request.
+type RetrievalQuery struct {
+	Query        string
+	TopK         int
+	ArtifactType string // Optional filter
+	// APIKey authenticates with the embedding provider. For Ollama it is the endpoint URL
+	// and falls back to the configured endpoint when empty.
+	APIKey string
+}
+
+// RetrievalService embeds artifacts chunked by markdown heading and answers top-k
+// similarity queries. Vectors are persisted per project so only artifacts whose
+// ModifiedAt changed are re-embedded.
+type RetrievalService struct {
+	mu              sync.RWMutex
+	syncMu          sync.Mutex // Serialises Sync so an artifact is never embedded twice at once
+	configService   *BMadConfigService
+	artifactService *ArtifactService
+	providerService *ProviderService
+	configStore     *storage.ConfigStore
+	store           *storage.VectorStore
+	index           *types.VectorIndex
+}
+
+// NewRetrievalService creates a new RetrievalService.
+// configStore may be nil, in which case the default embedding model is used.
+func NewRetrievalService(
+	configService *BMadConfigService,
+	artifactService *ArtifactService,
+	providerService *ProviderService,
+	configStore *storage.ConfigStore,
+	store *storage.VectorStore,
+) *RetrievalService {
+	return &RetrievalService{
+		configService:   configService,
+		artifactService: artifactService,
+		providerService: providerService,
+		configStore:     configStore,
+		store:           store,
+	}
+}
+
+// embeddingModel resolves the provider, model and credential used for embeddings.
+func (s *RetrievalService) embeddingModel(apiKey string) (provider, model, key string) {
+	settings := storage.DefaultSettings()
+	if s.configStore != nil {
+		if loaded, err := s.configStore.Load(); err == nil {
+			settings = loaded
+		}
+	}
+
+	cfg := types.EmbeddingSettings{}
+	if settings.Embedding != nil {
+		cfg = *settings.Embedding
+	}
+	provider = cfg.Provider
+	if provider == "" {
+		provider = defaultEmbeddingProvider
+	}
+	model = cfg.Model
+	if model == "" {
+		model = providers.DefaultEmbeddingModels[provider]
+	}
+
+	key = apiKey
+	if key == "" && provider == "ollama" {
+		key = settings.OllamaEndpoint
+	}
+	return provider, model, key
+}
+
+// indexLocked returns the in-memory index for the current project and embedding model,
+// loading it from disk on first use. An index built with a different model, chunking
+// version or project is discarded. The caller must hold s.mu for writing.
+func (s *RetrievalService) indexLocked(projectRoot, provider, model string) *types.VectorIndex {
+	if s.index == nil && s.store != nil {
+		index, err := s.store.Load(projectRoot)
+		if err != nil {
+			log.Printf("Warning: Failed to load vector index, rebuilding: %v", err)
+		}
+		s.index = index
+	}
+
+	if s.index == nil || s.index.Version != vectorIndexVersion || s.index.ProjectRoot != projectRoot ||
+		s.index.Provider != provider || s.index.Model != model {
+		s.index = &types.VectorIndex{
+			Version:     vectorIndexVersion,
+			ProjectRoot: projectRoot,
+			Provider:    provider,
+			Model:       model,
+			Artifacts:   make(map[string]types.VectorArtifact),
+		}
+	}
+	return s.index
+}
+
+// Status reports the size of the index and how many artifacts need (re-)embedding.
+func (s *RetrievalService) Status() (*types.RetrievalIndexStatus, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &RetrievalServiceError{Code: ErrCodeRetrievalConfigNotLoaded, Message: "BMadConfigService has no config loaded"}
+	}
+	artifacts, err := s.artifactService.GetArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	provider, model, _ := s.embeddingModel("")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.indexLocked(config.ProjectRoot, provider, model)
+
+	status := &types.RetrievalIndexStatus{Provider: provider, Model: model, Artifacts: len(index.Artifacts)}
+	for _, entry := range index.Artifacts {
+		status.Chunks += len(entry.Chunks)
+	}
+	status.Stale = len(staleArtifacts(index, artifacts))
+	return status, nil
+}
+
+// Sync embeds every artifact that is new or whose ModifiedAt changed since it was last
+// embedded, drops deleted artifacts and persists the index. If embedding fails part way,
+// the artifacts embedded so far are kept and the error is returned.
+func (s *RetrievalService) Sync(ctx context.Context, apiKey string) (*types.RetrievalIndexStatus, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &RetrievalServiceError{Code: ErrCodeRetrievalConfigNotLoaded, Message: "BMadConfigService has no config loaded"}
+	}
+	artifacts, err := s.artifactService.GetArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	provider, model, key := s.embeddingModel(apiKey)
+
+	s.mu.Lock()
+	index := s.indexLocked(config.ProjectRoot, provider, model)
+	stale := staleArtifacts(index, artifacts)
+	current := make(map[string]bool, len(artifacts))
+	for _, artifact := range artifacts {
+		current[artifact.ID] = true
+	}
+	removed := 0
+	for id := range index.Artifacts {
+		if !current[id] {
+			delete(index.Artifacts, id)
+			removed++
+		}
+	}
+	s.mu.Unlock()
+
+	// Embed outside the index lock so queries are not blocked by slow providers
+	embedded := make(map[string]types.VectorArtifact, len(stale))
+	var embedErr error
+	for _, artifact := range stale {
+		entry, err := s.embedArtifact(ctx, provider, model, key, &artifact)
+		if err != nil {
+			embedErr = err
+			break
+		}
+		embedded[artifact.ID] = *entry
+	}
+
+	s.mu.Lock()
+	if s.index != index {
+		// The embedding model or project changed while embedding; these vectors belong to
+		// an index nobody reads any more, so they are neither kept nor saved
+		s.mu.Unlock()
+		return nil, &RetrievalServiceError{
+			Code:    ErrCodeVectorIndexFailed,
+			Message: "Vector index was replaced during sync; the embedded artifacts were discarded",
+		}
+	}
+	for id, entry := range embedded {
+		index.Artifacts[id] = entry
+	}
+	status := &types.RetrievalIndexStatus{
+		Provider:  provider,
+		Model:     model,
+		Artifacts: len(index.Artifacts),
+		Stale:     len(stale) - len(embedded),
+		Embedded:  len(embedded),
+		Removed:   removed,
+	}
+	for _, entry := range index.Artifacts {
+		status.Chunks += len(entry.Chunks)
+	}
+	var saveErr error
+	if s.store != nil && (len(embedded) > 0 || removed > 0) {
+		saveErr = s.store.Save(index)
+	}
+	s.mu.Unlock()
+
+	if embedErr != nil {
+		return status, embedErr
+	}
+	if saveErr != nil {
+		return status, &RetrievalServiceError{
+			Code:    ErrCodeVectorIndexFailed,
+			Message: fmt.Sprintf("Failed to save vector index: %v", saveErr),
+		}
+	}
+	return status, nil
+}
+
+// Retrieve syncs the index and returns the chunks most similar to the query. A failed sync
+// is logged rather than returned so one artifact that cannot be embedded doesn't block
+// queries against everything already indexed.
+func (s *RetrievalService) Retrieve(ctx context.Context, query RetrievalQuery) (*types.RetrievalResponse, error) {
+	if strings.TrimSpace(query.Query) == "" {
+		return nil, &RetrievalServiceError{Code: ErrCodeInvalidRetrievalQuery, Message: "Query is required"}
+	}
+	topK := query.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+	if topK > maxTopK {
+		topK = maxTopK
+	}
+
+	if _, err := s.Sync(ctx, query.APIKey); err != nil {
+		var svcErr *RetrievalServiceError
+		if errors.As(err, &svcErr) && svcErr.Code == ErrCodeRetrievalConfigNotLoaded {
+			return nil, err
+		}
+		log.Printf("Warning: Failed to sync vector index, searching the existing index: %v", err)
+	}
+
+	provider, model, key := s.embeddingModel(query.APIKey)
+	resp, err := s.providerService.Embed(ctx, provider, key, providers.EmbedRequest{Model: model, Input: []string{query.Query}})
+	if err != nil {
+		return nil, err
+	}
+	queryVector := resp.Embeddings[0]
+
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &RetrievalServiceError{Code: ErrCodeRetrievalConfigNotLoaded, Message: "BMadConfigService has no config loaded"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.indexLocked(config.ProjectRoot, provider, model)
+
+	results := []types.RetrievalResult{}
+	for id, entry := range index.Artifacts {
+		if query.ArtifactType != "" && entry.Type != query.ArtifactType {
+			continue
+		}
+		for _, chunk := range entry.Chunks {
+			results = append(results, types.RetrievalResult{
+				ArtifactID:   id,
+				ArtifactName: entry.Name,
+				ArtifactType: entry.Type,
+				Path:         entry.Path,
+				ChunkID:      chunk.ID,
+				HeadingPath:  chunk.HeadingPath,
+				Text:         chunk.Text,
+				Score:        cosineSimilarity(queryVector, chunk.Vector),
+			})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ChunkID < results[j].ChunkID
+	})
+	if len(results) > topK {
+		results = results[:topK]
+	}
+
+	return &types.RetrievalResponse{Query: query.Query, Model: model, Results: results}, nil
+}
+
+// embedArtifact reads, chunks and embeds a single artifact.
+func (s *RetrievalService) embedArtifact(ctx context.Context, provider, model, key string, artifact *types.ArtifactResponse) (*types.VectorArtifact, error) {
+	content, err := s.artifactService.ReadContent(artifact)
+	if err != nil {
+		return nil, err
+	}
+	_, body := splitFrontmatter(content)
+	chunks := chunkMarkdown(artifact.ID, string(body))
+
+	// Each chunk is embedded with its document and heading path for context
+	inputs := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		inputs[i] = strings.Join(append([]string{artifact.Name}, chunk.HeadingPath...), " > ") + "\n\n" + chunk.Text
+	}
+
+	for start := 0; start < len(inputs); start += embedBatchSize {
+		end := start + embedBatchSize
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		resp, err := s.providerService.Embed(ctx, provider, key, providers.EmbedRequest{Model: model, Input: inputs[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		for i, vector := range resp.Embeddings {
+			chunks[start+i].Vector = vector
+		}
+	}
+
+	return &types.VectorArtifact{
+		Name:       artifact.Name,
+		Type:       artifact.Type,
+		Path:       artifact.Path,
+		ModifiedAt: artifact.ModifiedAt,
+		Chunks:     chunks,
+	}, nil
+}
+
+// staleArtifacts returns the artifacts missing from index or modified since they were embedded.
+func staleArtifacts(index *types.VectorIndex, artifacts []types.ArtifactResponse) []types.ArtifactResponse {
+	var stale []types.ArtifactResponse
+	for _, artifact := range artifacts {
+		if entry, ok := index.Artifacts[artifact.ID]; !ok || entry.ModifiedAt != artifact.ModifiedAt {
+			stale = append(stale, artifact)
+		}
+	}
+	return stale
+}
+
+// chunkMarkdown splits a markdown body into one chunk per heading section. Sections
+// longer than maxChunkChars are split at paragraph breaks; empty sections are skipped.
+func chunkMarkdown(artifactID string, body string) []types.VectorChunk {
+	var chunks []types.VectorChunk
+	for _, section := range splitMarkdownSections(body) {
+		for _, text := range splitParagraphs(strings.TrimSpace(section.Content), maxChunkChars) {
+			chunks = append(chunks, types.VectorChunk{
+				ID:          fmt.Sprintf("%s#%d", artifactID, len(chunks)),
+				HeadingPath: section.Path,
+				Text:        text,
+			})
+		}
+	}
+	return chunks
+}
+
+// splitParagraphs groups the paragraphs of text into pieces of at most limit bytes.
+// A single paragraph longer than limit is split on whitespace.
+func splitParagraphs(text string, limit int) []string {
+	if text == "" {
+		return nil
+	}
+	if len(text) <= limit {
+		return []string{text}
+	}
+
+	var pieces []string
+	var current strings.Builder
+	add := func(part string) {
+		if current.Len() > 0 && current.Len()+2+len(part) > limit {
+			pieces = append(pieces, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(part)
+	}
+
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if len(paragraph) <= limit {
+			add(paragraph)
+			continue
+		}
+		var line strings.Builder
+		for _, word := range strings.Fields(paragraph) {
+			if line.Len() > 0 && line.Len()+1+len(word) > limit {
+				add(line.String())
+				line.Reset()
+			}
+			if line.Len() > 0 {
+				line.WriteByte(' ')
+			}
+			line.WriteString(word)
+		}
+		if line.Len() > 0 {
+			add(line.String())
+		}
+	}
+	if current.Len() > 0 {
+		pieces = append(pieces, current.String())
+	}
+	return pieces
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0 if either is
+// empty, zero or they differ in length.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bmad-studio/backend/storage"
+)
+
+// embeddingVocabulary defines the dimensions of the fake embedding space.
+var embeddingVocabulary = []string{"database", "login", "layout", "deploy"}
+
+// fakeEmbedder is an Ollama /api/embed stand-in that embeds text as keyword counts
+// and records how many inputs it was asked to embed.
+type fakeEmbedder struct {
+	mu     sync.Mutex
+	inputs int
+	failOn string // Requests with an input containing this text fail
+}
+
+func (f *fakeEmbedder) handler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Input []string `json:"input"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	f.mu.Lock()
+	f.inputs += len(req.Input)
+	failOn := f.failOn
+	f.mu.Unlock()
+	for _, text := range req.Input {
+		if failOn != "" && strings.Contains(text, failOn) {
+			http.Error(w, `{"error":"embedding failed"}`, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	embeddings := make([][]float32, len(req.Input))
+	for i, text := range req.Input {
+		vector := make([]float32, len(embeddingVocabulary)+1)
+		vector[len(embeddingVocabulary)] = 0.1 // Keeps vectors non-zero
+		for d, word := range embeddingVocabulary {
+			vector[d] = float32(strings.Count(strings.ToLower(text), word))
+		}
+		embeddings[i] = vector
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"model": "nomic-embed-text", "embeddings": embeddings})
+}
+
+func setupRetrievalTest(t *testing.T) (*RetrievalService, *ArtifactService, *fakeEmbedder, string, string) {
+	t.Helper()
+	configService, tmpDir := setupArtifactTestConfig(t)
+	planningDir := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts")
+	if err := os.MkdirAll(planningDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"prd.md":          "# PRD\n\n## Authentication\n\nUsers login with email. Login must be fast.\n\n## Interface\n\nA responsive layout.\n",
+		"architecture.md": "---\nstatus: complete\n---\n# Architecture\n\n## Storage\n\nThe database is PostgreSQL; database backups run nightly.\n\n## Delivery\n\nWe deploy with containers.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(planningDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	artifactService := NewArtifactService(configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatalf("LoadArtifacts error: %v", err)
+	}
+
+	fake := &fakeEmbedder{}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	t.Cleanup(server.Close)
+
+	store := storage.NewVectorStoreWithDir(t.TempDir())
+	svc := NewRetrievalService(configService, artifactService, NewProviderService(), nil, store)
+	return svc, artifactService, fake, server.URL, planningDir
+}
+
+func TestChunkMarkdown_ByHeading(t *testing.T) {
+	chunks := chunkMarkdown("prd", "# PRD\n\nIntro\n\n## Goals\n\nGoal text\n\n## Empty\n\n## Risks\n\nRisk text\n")
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 non-empty chunks, got %d: %+v", len(chunks), chunks)
+	}
+	if chunks[1].ID != "prd#1" || strings.Join(chunks[1].HeadingPath, ">") != "PRD>Goals" || chunks[1].Text != "Goal text" {
+		t.Errorf("Unexpected chunk: %+v", chunks[1])
+	}
+}
+
+func TestSplitParagraphs_RespectsLimit(t *testing.T) {
+	text := strings.Repeat("alpha beta gamma. ", 20) + "\n\n" + strings.Repeat("delta ", 100)
+	for _, piece := range splitParagraphs(text, 200) {
+		if len(piece) > 200 {
+			t.Errorf("Piece exceeds limit: %d bytes", len(piece))
+		}
+	}
+	if got := splitParagraphs("short", 200); len(got) != 1 || got[0] != "short" {
+		t.Errorf("Expected short text unchanged, got %v", got)
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	if got := cosineSimilarity([]float32{1, 0}, []float32{1, 0}); got < 0.999 {
+		t.Errorf("Identical vectors = %f, want 1", got)
+	}
+	if got := cosineSimilarity([]float32{1, 0}, []float32{0, 1}); got != 0 {
+		t.Errorf("Orthogonal vectors = %f, want 0", got)
+	}
+	if got := cosineSimilarity([]float32{1}, []float32{1, 0}); got != 0 {
+		t.Errorf("Mismatched lengths = %f, want 0", got)
+	}
+}
+
+func TestRetrievalService_RetrievesRelevantSection(t *testing.T) {
+	svc, _, _, endpoint, _ := setupRetrievalTest(t)
+
+	resp, err := svc.Retrieve(context.Background(), RetrievalQuery{Query: "which database do we use", TopK: 2, APIKey: endpoint})
+	if err != nil {
+		t.Fatalf("Retrieve error: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected top 2 results, got %d", len(resp.Results))
+	}
+	top := resp.Results[0]
+	if strings.Join(top.HeadingPath, ">") != "Architecture>Storage" || top.ArtifactType != "architecture" {
+		t.Errorf("Expected the Storage section first, got %+v", top)
+	}
+
+	resp, _ = svc.Retrieve(context.Background(), RetrievalQuery{Query: "database", ArtifactType: "prd", APIKey: endpoint})
+	for _, result := range resp.Results {
+		if result.ArtifactType != "prd" {
+			t.Errorf("Type filter leaked %s", result.ArtifactType)
+		}
+	}
+}
+
+func TestRetrievalService_ReembedsOnlyModifiedArtifacts(t *testing.T) {
+	svc, artifactService, fake, endpoint, planningDir := setupRetrievalTest(t)
+
+	status, err := svc.Sync(context.Background(), endpoint)
+	if err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	if status.Embedded != 2 || status.Chunks != 4 {
+		t.Fatalf("Expected 2 artifacts and 4 chunks embedded, got %+v", status)
+	}
+	initial := fake.inputs
+
+	// Nothing changed: no embedding requests
+	if status, _ = svc.Sync(context.Background(), endpoint); status.Embedded != 0 || fake.inputs != initial {
+		t.Errorf("Expected no re-embedding, got %+v (%d inputs)", status, fake.inputs-initial)
+	}
+
+	// Modify one artifact with a later mtime
+	path := filepath.Join(planningDir, "prd.md")
+	if err := os.WriteFile(path, []byte("# PRD\n\n## Authentication\n\nSSO login only.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+	if _, err := artifactService.ProcessSingleArtifact(path); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = svc.Sync(context.Background(), endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Embedded != 1 || fake.inputs-initial != 1 {
+		t.Errorf("Expected only the modified PRD re-embedded, got %+v (%d inputs)", status, fake.inputs-initial)
+	}
+
+	// A new service instance reuses the persisted index
+	fresh := NewRetrievalService(svc.configService, artifactService, NewProviderService(), nil, svc.store)
+	before := fake.inputs
+	if status, _ = fresh.Sync(context.Background(), endpoint); status.Embedded != 0 || fake.inputs != before {
+		t.Errorf("Expected persisted index reused, got %+v", status)
+	}
+}
+
+func TestRetrievalService_DropsDeletedArtifacts(t *testing.T) {
+	svc, artifactService, _, endpoint, planningDir := setupRetrievalTest(t)
+	svc.Sync(context.Background(), endpoint)
+
+	path := filepath.Join(planningDir, "architecture.md")
+	os.Remove(path)
+	artifactService.RemoveArtifact(path)
+
+	status, err := svc.Sync(context.Background(), endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Removed != 1 || status.Artifacts != 1 {
+		t.Errorf("Expected deleted artifact dropped, got %+v", status)
+	}
+}
+
+func TestRetrievalService_SearchesExistingIndexWhenSyncFails(t *testing.T) {
+	svc, artifactService, fake, endpoint, planningDir := setupRetrievalTest(t)
+	if _, err := svc.Sync(context.Background(), endpoint); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.mu.Lock()
+	fake.failOn = "unembeddable"
+	fake.mu.Unlock()
+	path := filepath.Join(planningDir, "epics.md")
+	if err := os.WriteFile(path, []byte("# Epics\n\nAn unembeddable section.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := artifactService.ProcessSingleArtifact(path); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := svc.Retrieve(context.Background(), RetrievalQuery{Query: "which database do we use", TopK: 1, APIKey: endpoint})
+	if err != nil {
+		t.Fatalf("Expected retrieval from the existing index, got %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].ArtifactType != "architecture" {
+		t.Errorf("Expected the indexed architecture section, got %+v", resp.Results)
+	}
+}
+
+func TestRetrievalService_EmptyQuery(t *testing.T) {
+	svc, _, _, endpoint, _ := setupRetrievalTest(t)
+
+	_, err := svc.Retrieve(context.Background(), RetrievalQuery{Query: "  ", APIKey: endpoint})
+	svcErr, ok := err.(*RetrievalServiceError)
+	if !ok || svcErr.Code != ErrCodeInvalidRetrievalQuery {
+		t.Errorf("Expected invalid_query error, got %v", err)
+	}
+}
+
+func TestRetrievalTool_FormatsSources(t *testing.T) {
+	svc, _, _, endpoint, _ := setupRetrievalTest(t)
+	registry := NewToolRegistry()
+	registry.Register(NewRetrievalTool(svc))
+
+	defs := registry.Definitions()
+	if len(defs) != 1 || defs[0].Name != "search_project_docs" || defs[0].InputSchema["type"] != "object" {
+		t.Fatalf("Unexpected tool definitions: %+v", defs)
+	}
+
+	result, err := registry.Invoke(context.Background(), "search_project_docs", json.RawMessage(`{"query":"deploy","top_k":1}`), endpoint)
+	if err != nil {
+		t.Fatalf("Invoke error: %v", err)
+	}
+	if !strings.HasPrefix(result.Content, "[1] Architecture > Architecture > Delivery") || !strings.Contains(result.Content, "containers") {
+		t.Errorf("Unexpected tool content: %q", result.Content)
+	}
+
+	_, err = registry.Invoke(context.Background(), "search_project_docs", json.RawMessage(`{}`), endpoint)
+	if toolErr, ok := err.(*ToolRegistryError); !ok || toolErr.Code != ErrCodeInvalidToolInput {
+		t.Errorf("Expected invalid_tool_input error, got %v", err)
+	}
+	_, err = registry.Invoke(context.Background(), "missing", nil, endpoint)
+	if toolErr, ok := err.(*ToolRegistryError); !ok || toolErr.Code != ErrCodeToolNotFound {
+		t.Errorf("Expected tool_not_found error, got %v", err)
+	}
+}
@@ -0,0 +1,605 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SearchServiceError represents a structured error from the search service
+type SearchServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *SearchServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for search service
+const (
+	ErrCodeInvalidQuery = "invalid_query"
+)
+
+// Indexed fields of a search document
+const (
+	fieldTitle = iota
+	fieldFrontmatter
+	fieldBody
+	numSearchFields
+)
+
+// searchFieldNames are the field names reported in SearchResult.MatchedField.
+var searchFieldNames = [numSearchFields]string{"title", "frontmatter", "body"}
+
+// searchFieldWeights boost matches in titles and frontmatter over matches in the body.
+var searchFieldWeights = [numSearchFields]float64{3, 1.5, 1}
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+
+	// minPrefixLength is the shortest query term expanded to prefix matches when it
+	// has no exact match, so "postgres" finds "postgresql".
+	minPrefixLength = 3
+
+	// snippetContext is the number of characters shown before the first match;
+	// snippetLength is the total window before trimming to word boundaries.
+	snippetContext = 60
+	snippetLength  = 200
+)
+
+// searchStopwords are common English words that carry no meaning on their own.
+var searchStopwords = map[string]bool{
+	"an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true,
+	"or": true, "that": true, "the": true, "this": true, "to": true, "was": true, "with": true,
+}
+
+// SearchQuery holds a query string and optional result filters.
+type SearchQuery struct {
+	Query        string
+	Kind         string // Restrict to "artifact" or "message" results
+	ArtifactType string // Artifacts only
+	Phase        *int   // Artifacts only
+	AgentID      string // Messages only, matched against the session's agent
+	Limit        int
+	Offset       int
+}
+
+// matches reports whether doc passes the query's filters.
+// Artifact filters exclude messages and the agent filter excludes artifacts.
+func (q SearchQuery) matches(doc *searchDoc) bool {
+	if q.Kind != "" && doc.meta.Kind != q.Kind {
+		return false
+	}
+	if q.ArtifactType != "" || q.Phase != nil {
+		if doc.meta.Kind != types.SearchKindArtifact {
+			return false
+		}
+		if q.ArtifactType != "" && doc.meta.ArtifactType != q.ArtifactType {
+			return false
+		}
+		if q.Phase != nil && (doc.meta.Phase == nil || *doc.meta.Phase != *q.Phase) {
+			return false
+		}
+	}
+	if q.AgentID != "" && (doc.meta.Kind != types.SearchKindMessage || doc.meta.AgentID != q.AgentID) {
+		return false
+	}
+	return true
+}
+
+// searchToken is a normalised term and its position in the source text, in runes.
+type searchToken struct {
+	term  string
+	start int
+	end   int
+}
+
+// searchDoc is an indexed artifact or message.
+type searchDoc struct {
+	meta   types.SearchResult // Identity and filter fields; scoring fields are filled per query
+	fields [numSearchFields]string
+	terms  map[string][numSearchFields]int // Term frequency per field
+}
+
+// SearchService maintains an in-memory inverted index over artifact markdown, artifact
+// frontmatter and session messages. It is rebuilt on startup and kept current through
+// the file watcher and session service listener hooks.
+type SearchService struct {
+	mu              sync.RWMutex
+	artifactService *ArtifactService
+	sessionStore    *storage.SessionStore
+	docs            map[string]*searchDoc
+	postings        map[string]map[string]struct{} // Term to the keys of docs containing it
+	sessionDocs     map[string][]string            // Session ID to its message doc keys
+}
+
+// NewSearchService creates a new SearchService.
+// artifactService and sessionStore may be nil, in which case that content is not indexed.
+func NewSearchService(artifactService *ArtifactService, sessionStore *storage.SessionStore) *SearchService {
+	return &SearchService{
+		artifactService: artifactService,
+		sessionStore:    sessionStore,
+		docs:            make(map[string]*searchDoc),
+		postings:        make(map[string]map[string]struct{}),
+		sessionDocs:     make(map[string][]string),
+	}
+}
+
+// Rebuild discards the index and re-indexes every artifact and session.
+// Artifacts that cannot be read are skipped with a warning.
+func (s *SearchService) Rebuild() error {
+	artifactDocs := make(map[string]*searchDoc)
+	if s.artifactService != nil {
+		artifacts, err := s.artifactService.GetArtifacts()
+		if err != nil {
+			return err
+		}
+		for i := range artifacts {
+			doc, err := s.artifactDoc(&artifacts[i])
+			if err != nil {
+				log.Printf("Warning: Failed to index artifact %s: %v", artifacts[i].ID, err)
+				continue
+			}
+			artifactDocs[artifactDocKey(artifacts[i].ID)] = doc
+		}
+	}
+
+	var sessions []types.Session
+	if s.sessionStore != nil {
+		var err error
+		sessions, err = s.sessionStore.List()
+		if err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs = make(map[string]*searchDoc)
+	s.postings = make(map[string]map[string]struct{})
+	s.sessionDocs = make(map[string][]string)
+	for key, doc := range artifactDocs {
+		s.putLocked(key, doc)
+	}
+	for i := range sessions {
+		s.indexSessionLocked(&sessions[i])
+	}
+	return nil
+}
+
+// ArtifactChanged re-indexes an artifact after it was created or modified.
+func (s *SearchService) ArtifactChanged(artifact *types.ArtifactResponse) {
+	doc, err := s.artifactDoc(artifact)
+	if err != nil {
+		log.Printf("Warning: Failed to index artifact %s: %v", artifact.ID, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putLocked(artifactDocKey(artifact.ID), doc)
+}
+
+// ArtifactRemoved drops a deleted artifact from the index.
+func (s *SearchService) ArtifactRemoved(artifact *types.ArtifactResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(artifactDocKey(artifact.ID))
+}
+
+// SessionChanged re-indexes the messages of a session after it was written.
+func (s *SearchService) SessionChanged(session *types.Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexSessionLocked(session)
+}
+
+// SessionDeleted drops every message of a deleted session from the index.
+func (s *SearchService) SessionDeleted(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range s.sessionDocs[id] {
+		s.removeLocked(key)
+	}
+	delete(s.sessionDocs, id)
+}
+
+// Search returns the documents matching every term of the query, best match first.
+// Terms without an exact match are expanded to the indexed terms they prefix.
+func (s *SearchService) Search(query SearchQuery) (*types.SearchResponse, error) {
+	var queryTerms []string
+	seen := make(map[string]bool)
+	for _, tok := range tokenize(query.Query) {
+		if !seen[tok.term] {
+			seen[tok.term] = true
+			queryTerms = append(queryTerms, tok.term)
+		}
+	}
+	if len(queryTerms) == 0 {
+		return nil, &SearchServiceError{
+			Code:    ErrCodeInvalidQuery,
+			Message: "Search query must contain at least one word",
+		}
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	resp := &types.SearchResponse{Query: query.Query, Results: []types.SearchResult{}}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Expand each query term into the indexed terms it matches
+	expansions := make([][]string, len(queryTerms))
+	matchedTerms := make(map[string]bool)
+	for i, term := range queryTerms {
+		if _, ok := s.postings[term]; ok {
+			expansions[i] = []string{term}
+		} else if utf8.RuneCountInString(term) >= minPrefixLength {
+			for indexed := range s.postings {
+				if strings.HasPrefix(indexed, term) {
+					expansions[i] = append(expansions[i], indexed)
+				}
+			}
+		}
+		if len(expansions[i]) == 0 {
+			return resp, nil // A term matches nothing, so no document matches every term
+		}
+		for _, indexed := range expansions[i] {
+			matchedTerms[indexed] = true
+		}
+	}
+
+	// Score with TF-IDF, saturating term frequency and weighting by field
+	total := float64(len(s.docs))
+	var scores map[string]float64
+	for i, terms := range expansions {
+		termScores := make(map[string]float64)
+		for _, term := range terms {
+			idf := math.Log(1 + total/float64(len(s.postings[term])))
+			for key := range s.postings[term] {
+				if scores != nil {
+					if _, ok := scores[key]; !ok {
+						continue
+					}
+				}
+				doc := s.docs[key]
+				if !query.matches(doc) {
+					continue
+				}
+				counts := doc.terms[term]
+				weighted := 0.0
+				for f := range counts {
+					weighted += searchFieldWeights[f] * float64(counts[f])
+				}
+				termScores[key] += idf * weighted / (weighted + 1.2)
+			}
+		}
+
+		// Keep only documents that match every query term so far
+		if i == 0 {
+			scores = termScores
+			continue
+		}
+		for key := range scores {
+			if score, ok := termScores[key]; ok {
+				scores[key] += score
+			} else {
+				delete(scores, key)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(scores))
+	for key := range scores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] != scores[keys[j]] {
+			return scores[keys[i]] > scores[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	resp.Total = len(keys)
+	if offset >= len(keys) {
+		return resp, nil
+	}
+	keys = keys[offset:]
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	for _, key := range keys {
+		doc := s.docs[key]
+		result := doc.meta
+		result.Score = math.Round(scores[key]*1000) / 1000
+		result.MatchedField, result.Snippet, result.Highlights = doc.snippet(matchedTerms)
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+// artifactDoc reads an artifact from disk and builds its search document.
+func (s *SearchService) artifactDoc(artifact *types.ArtifactResponse) (*searchDoc, error) {
+	if s.artifactService == nil {
+		return nil, fmt.Errorf("artifact service not available")
+	}
+	content, err := s.artifactService.ReadContent(artifact)
+	if err != nil {
+		return nil, err
+	}
+	frontmatter, body := splitFrontmatter(content)
+
+	phase := artifact.Phase
+	doc := &searchDoc{
+		meta: types.SearchResult{
+			Kind:         types.SearchKindArtifact,
+			ID:           artifact.ID,
+			Title:        artifact.Name,
+			ArtifactType: artifact.Type,
+			Phase:        &phase,
+			PhaseName:    artifact.PhaseName,
+			Path:         artifact.Path,
+		},
+	}
+	doc.fields[fieldTitle] = artifact.Name
+	doc.fields[fieldFrontmatter] = flattenFrontmatter(frontmatter)
+	doc.fields[fieldBody] = string(body)
+	return doc, nil
+}
+
+// indexSessionLocked indexes every message in a session's tree, including inactive branches.
+// Messages are immutable, so unchanged messages only have their session metadata refreshed.
+// The caller must hold s.mu.
+func (s *SearchService) indexSessionLocked(session *types.Session) {
+	current := make(map[string]bool, len(session.Messages))
+	keys := make([]string, 0, len(session.Messages))
+	for _, msg := range session.Messages {
+		key := messageDocKey(session.ID, msg.ID)
+		current[key] = true
+		keys = append(keys, key)
+
+		if existing, ok := s.docs[key]; ok && existing.fields[fieldBody] == msg.Content {
+			existing.meta.Title = session.Title
+			existing.meta.AgentID = session.AgentID
+			continue
+		}
+
+		doc := &searchDoc{
+			meta: types.SearchResult{
+				Kind:      types.SearchKindMessage,
+				ID:        msg.ID,
+				Title:     session.Title,
+				SessionID: session.ID,
+				AgentID:   session.AgentID,
+				Role:      msg.Role,
+			},
+		}
+		doc.fields[fieldBody] = msg.Content
+		s.putLocked(key, doc)
+	}
+
+	for _, key := range s.sessionDocs[session.ID] {
+		if !current[key] {
+			s.removeLocked(key)
+		}
+	}
+	s.sessionDocs[session.ID] = keys
+}
+
+// putLocked tokenizes doc and adds it to the index, replacing any document under key.
+// The caller must hold s.mu.
+func (s *SearchService) putLocked(key string, doc *searchDoc) {
+	s.removeLocked(key)
+
+	doc.terms = make(map[string][numSearchFields]int)
+	for f, text := range doc.fields {
+		for _, tok := range tokenize(text) {
+			counts := doc.terms[tok.term]
+			counts[f]++
+			doc.terms[tok.term] = counts
+		}
+	}
+
+	for term := range doc.terms {
+		postings, ok := s.postings[term]
+		if !ok {
+			postings = make(map[string]struct{})
+			s.postings[term] = postings
+		}
+		postings[key] = struct{}{}
+	}
+	s.docs[key] = doc
+}
+
+// removeLocked removes the document under key from the index, if present.
+// The caller must hold s.mu.
+func (s *SearchService) removeLocked(key string) {
+	doc, ok := s.docs[key]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(s.postings[term], key)
+		if len(s.postings[term]) == 0 {
+			delete(s.postings, term)
+		}
+	}
+	delete(s.docs, key)
+}
+
+// snippet picks the field to show for a hit and returns its name, an excerpt around the
+// first matching term and the highlighted ranges within the excerpt. The body is preferred
+// for context, then frontmatter, then the title.
+func (d *searchDoc) snippet(terms map[string]bool) (string, string, []types.TextRange) {
+	for _, f := range []int{fieldBody, fieldFrontmatter, fieldTitle} {
+		for _, tok := range tokenize(d.fields[f]) {
+			if terms[tok.term] {
+				text, highlights := makeSnippet(d.fields[f], tok, terms)
+				return searchFieldNames[f], text, highlights
+			}
+		}
+	}
+	return "", "", []types.TextRange{}
+}
+
+// makeSnippet returns a single-line excerpt of text around match, trimmed to word
+// boundaries, with every occurrence of terms highlighted.
+func makeSnippet(text string, match searchToken, terms map[string]bool) (string, []types.TextRange) {
+	runes := []rune(text)
+	start := match.start - snippetContext
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+	if end < match.end {
+		end = match.end
+	}
+
+	// Snap to word boundaries so the excerpt doesn't begin or end mid-word
+	if start > 0 {
+		for i := start; i < match.start; i++ {
+			if unicode.IsSpace(runes[i]) {
+				start = i + 1
+				break
+			}
+		}
+	}
+	if end < len(runes) {
+		for i := end; i > match.end; i-- {
+			if unicode.IsSpace(runes[i]) {
+				end = i
+				break
+			}
+		}
+	}
+
+	snippet := strings.Join(strings.Fields(string(runes[start:end])), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+
+	highlights := []types.TextRange{}
+	for _, tok := range tokenize(snippet) {
+		if terms[tok.term] {
+			highlights = append(highlights, types.TextRange{Start: tok.start, End: tok.end})
+		}
+	}
+	return snippet, highlights
+}
+
+// tokenize splits text into lowercase terms of letters and digits, dropping single
+// characters and stopwords. Offsets are in runes.
+func tokenize(text string) []searchToken {
+	var tokens []searchToken
+	var term strings.Builder
+	start, pos := 0, 0
+
+	flush := func() {
+		if term.Len() == 0 {
+			return
+		}
+		t := term.String()
+		term.Reset()
+		if utf8.RuneCountInString(t) < 2 || searchStopwords[t] {
+			return
+		}
+		tokens = append(tokens, searchToken{term: t, start: start, end: pos})
+	}
+
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if term.Len() == 0 {
+				start = pos
+			}
+			term.WriteRune(unicode.ToLower(r))
+		} else {
+			flush()
+		}
+		pos++
+	}
+	flush()
+	return tokens
+}
+
+// flattenFrontmatter renders YAML frontmatter as sorted "key: value" lines so both keys
+// and values are searchable. Nested keys are joined with dots. Malformed YAML yields "".
+func flattenFrontmatter(raw []byte) string {
+	if raw == nil {
+		return ""
+	}
+	var fm map[string]interface{}
+	if err := yaml.Unmarshal(raw, &fm); err != nil {
+		return ""
+	}
+
+	var lines []string
+	flattenYAMLValue("", fm, &lines)
+	return strings.Join(lines, "\n")
+}
+
+// flattenYAMLValue appends the "key: value" lines for v to lines.
+func flattenYAMLValue(key string, v interface{}, lines *[]string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := k
+			if key != "" {
+				child = key + "." + k
+			}
+			flattenYAMLValue(child, val[k], lines)
+		}
+	case []interface{}:
+		for _, item := range val {
+			flattenYAMLValue(key, item, lines)
+		}
+	case nil:
+	default:
+		*lines = append(*lines, fmt.Sprintf("%s: %v", key, val))
+	}
+}
+
+// artifactDocKey returns the index key for an artifact.
+func artifactDocKey(id string) string {
+	return types.SearchKindArtifact + ":" + id
+}
+
+// messageDocKey returns the index key for a session message.
+func messageDocKey(sessionID, messageID string) string {
+	return types.SearchKindMessage + ":" + sessionID + "/" + messageID
+}
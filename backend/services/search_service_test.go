@@ -0,0 +1,289 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// setupSearchTest creates a project with a PRD and an architecture document and an indexed SearchService.
+func setupSearchTest(t *testing.T) (*SearchService, *ArtifactService, *storage.SessionStore, string) {
+	t.Helper()
+	configService, tmpDir := setupArtifactTestConfig(t)
+	planningDir := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts")
+	if err := os.MkdirAll(planningDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"prd.md": `---
+status: complete
+workflowType: prd
+inputDocuments:
+  - product-brief.md
+---
+# Product Requirements
+
+Users sign in with single sign-on. Reports export nightly to the warehouse.
+`,
+		"architecture.md": `---
+status: in-progress
+workflowType: architecture
+---
+# Architecture
+
+The service stores reports in PostgreSQL and caches sessions in Redis.
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(planningDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	artifactService := NewArtifactService(configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatalf("LoadArtifacts error: %v", err)
+	}
+
+	sessionStore := storage.NewSessionStoreWithDir(t.TempDir())
+	svc := NewSearchService(artifactService, sessionStore)
+	if err := svc.Rebuild(); err != nil {
+		t.Fatalf("Rebuild error: %v", err)
+	}
+	return svc, artifactService, sessionStore, planningDir
+}
+
+func searchIDs(t *testing.T, svc *SearchService, query SearchQuery) []string {
+	t.Helper()
+	resp, err := svc.Search(query)
+	if err != nil {
+		t.Fatalf("Search(%q) error: %v", query.Query, err)
+	}
+	var ids []string
+	for _, r := range resp.Results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize("The PRD's goals: Sign-in & SSO, a b")
+	var terms []string
+	for _, tok := range tokens {
+		terms = append(terms, tok.term)
+	}
+	if strings.Join(terms, ",") != "prd,goals,sign,sso" {
+		t.Errorf("tokenize terms = %v", terms)
+	}
+	if tokens[0].start != 4 || tokens[0].end != 7 {
+		t.Errorf("Expected rune offsets 4-7 for prd, got %d-%d", tokens[0].start, tokens[0].end)
+	}
+}
+
+func TestSearch_MatchesBodyAndFrontmatter(t *testing.T) {
+	svc, _, _, _ := setupSearchTest(t)
+
+	if ids := searchIDs(t, svc, SearchQuery{Query: "warehouse"}); len(ids) != 1 || !strings.Contains(ids[0], "prd") {
+		t.Errorf("Expected the PRD for a body match, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "product-brief"}); len(ids) != 1 || !strings.Contains(ids[0], "prd") {
+		t.Errorf("Expected the PRD for a frontmatter match, got %v", ids)
+	}
+}
+
+func TestSearch_RequiresEveryTerm(t *testing.T) {
+	svc, _, _, _ := setupSearchTest(t)
+
+	if ids := searchIDs(t, svc, SearchQuery{Query: "reports redis"}); len(ids) != 1 || !strings.Contains(ids[0], "architecture") {
+		t.Errorf("Expected only the architecture doc, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "reports"}); len(ids) != 2 {
+		t.Errorf("Expected both docs for a shared term, got %v", ids)
+	}
+}
+
+func TestSearch_PrefixExpansion(t *testing.T) {
+	svc, _, _, _ := setupSearchTest(t)
+
+	if ids := searchIDs(t, svc, SearchQuery{Query: "postgres"}); len(ids) != 1 {
+		t.Errorf("Expected postgres to match postgresql, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "po"}); len(ids) != 0 {
+		t.Errorf("Expected short terms not to be prefix-expanded, got %v", ids)
+	}
+}
+
+func TestSearch_TitleMatchesRankFirst(t *testing.T) {
+	svc, _, _, _ := setupSearchTest(t)
+
+	resp, err := svc.Search(SearchQuery{Query: "architecture"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) == 0 || !strings.Contains(resp.Results[0].ID, "architecture") {
+		t.Fatalf("Expected architecture doc first, got %+v", resp.Results)
+	}
+}
+
+func TestSearch_SnippetAndHighlights(t *testing.T) {
+	svc, _, _, _ := setupSearchTest(t)
+
+	resp, err := svc.Search(SearchQuery{Query: "nightly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := resp.Results[0]
+	if result.MatchedField != "body" {
+		t.Errorf("MatchedField = %q, want body", result.MatchedField)
+	}
+	if strings.Contains(result.Snippet, "\n") {
+		t.Errorf("Snippet should be a single line, got %q", result.Snippet)
+	}
+	if len(result.Highlights) != 1 {
+		t.Fatalf("Expected one highlight, got %v", result.Highlights)
+	}
+	h := result.Highlights[0]
+	if got := string([]rune(result.Snippet)[h.Start:h.End]); got != "nightly" {
+		t.Errorf("Highlight covers %q, want nightly", got)
+	}
+}
+
+func TestMakeSnippet_TrimsLongText(t *testing.T) {
+	text := strings.Repeat("filler words here ", 20) + "needle " + strings.Repeat("more trailing text ", 20)
+	match := tokenize(text)[0]
+	for _, tok := range tokenize(text) {
+		if tok.term == "needle" {
+			match = tok
+		}
+	}
+
+	snippet, highlights := makeSnippet(text, match, map[string]bool{"needle": true})
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Errorf("Expected ellipses on both ends, got %q", snippet)
+	}
+	if strings.HasPrefix(snippet, "…ere") || strings.HasPrefix(snippet, "…ords") {
+		t.Errorf("Snippet should start on a word boundary, got %q", snippet)
+	}
+	if len(highlights) != 1 || string([]rune(snippet)[highlights[0].Start:highlights[0].End]) != "needle" {
+		t.Errorf("Expected needle highlighted, got %v in %q", highlights, snippet)
+	}
+}
+
+func TestSearch_Filters(t *testing.T) {
+	svc, _, sessionStore, _ := setupSearchTest(t)
+	session := &types.Session{
+		BaseEntity: types.BaseEntity{ID: "sess_1"},
+		AgentID:    "architect",
+		Title:      "Caching",
+		Messages: []types.SessionMessage{
+			{ID: "msg_1", Role: "user", Content: "Should reports be cached in Redis?"},
+		},
+	}
+	if err := sessionStore.Save(session); err != nil {
+		t.Fatal(err)
+	}
+	svc.SessionChanged(session)
+
+	if ids := searchIDs(t, svc, SearchQuery{Query: "redis"}); len(ids) != 2 {
+		t.Errorf("Expected artifact and message hits, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "redis", Kind: types.SearchKindMessage}); len(ids) != 1 || ids[0] != "msg_1" {
+		t.Errorf("Expected only the message for kind=message, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "redis", AgentID: "architect"}); len(ids) != 1 || ids[0] != "msg_1" {
+		t.Errorf("Expected agent filter to select messages, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "redis", AgentID: "pm"}); len(ids) != 0 {
+		t.Errorf("Expected no hits for another agent, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "reports", ArtifactType: types.ArtifactTypePRD}); len(ids) != 1 || !strings.Contains(ids[0], "prd") {
+		t.Errorf("Expected type filter to select the PRD, got %v", ids)
+	}
+
+	phase := 2
+	if ids := searchIDs(t, svc, SearchQuery{Query: "reports", Phase: &phase}); len(ids) != 1 || !strings.Contains(ids[0], "prd") {
+		t.Errorf("Expected phase filter to select the PRD, got %v", ids)
+	}
+}
+
+func TestSearch_Paging(t *testing.T) {
+	svc, _, _, _ := setupSearchTest(t)
+
+	resp, err := svc.Search(SearchQuery{Query: "reports", Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 2 || len(resp.Results) != 1 {
+		t.Errorf("Expected total 2 with one result on the second page, got %d/%d", resp.Total, len(resp.Results))
+	}
+}
+
+func TestSearch_EmptyQuery(t *testing.T) {
+	svc, _, _, _ := setupSearchTest(t)
+
+	_, err := svc.Search(SearchQuery{Query: "the a"})
+	svcErr, ok := err.(*SearchServiceError)
+	if !ok || svcErr.Code != ErrCodeInvalidQuery {
+		t.Errorf("Expected invalid_query error, got %v", err)
+	}
+}
+
+func TestSearch_IncrementalArtifactUpdates(t *testing.T) {
+	svc, artifactService, _, planningDir := setupSearchTest(t)
+	path := filepath.Join(planningDir, "architecture.md")
+
+	if err := os.WriteFile(path, []byte("# Architecture\n\nEvents flow through Kafka.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := artifactService.ProcessSingleArtifact(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.ArtifactChanged(artifact)
+
+	if ids := searchIDs(t, svc, SearchQuery{Query: "kafka"}); len(ids) != 1 {
+		t.Errorf("Expected new content indexed, got %v", ids)
+	}
+	if ids := searchIDs(t, svc, SearchQuery{Query: "redis"}); len(ids) != 0 {
+		t.Errorf("Expected old content dropped, got %v", ids)
+	}
+
+	svc.ArtifactRemoved(artifact)
+	if ids := searchIDs(t, svc, SearchQuery{Query: "kafka"}); len(ids) != 0 {
+		t.Errorf("Expected removed artifact dropped, got %v", ids)
+	}
+}
+
+func TestSearch_SessionListenerHooks(t *testing.T) {
+	sessionSvc, _, opts := newTestSessionService(t)
+	search := NewSearchService(nil, nil)
+	sessionSvc.AddListener(search)
+
+	session := createOllamaSession(t, sessionSvc)
+	if _, err := sessionSvc.SendMessage(t.Context(), session.ID, "Outline the onboarding flow", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := search.Search(SearchQuery{Query: "onboarding"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].SessionID != session.ID || resp.Results[0].Role != "user" {
+		t.Fatalf("Expected the user message indexed, got %+v", resp.Results)
+	}
+	if ids := searchIDs(t, search, SearchQuery{Query: "reply"}); len(ids) != 1 {
+		t.Errorf("Expected the assistant reply indexed, got %v", ids)
+	}
+
+	if err := sessionSvc.DeleteSession(session.ID); err != nil {
+		t.Fatal(err)
+	}
+	if ids := searchIDs(t, search, SearchQuery{Query: "onboarding"}); len(ids) != 0 {
+		t.Errorf("Expected deleted session dropped, got %v", ids)
+	}
+}
@@ -0,0 +1,538 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// SessionServiceError represents a structured error from the session service
+type SessionServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *SessionServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for session service
+const (
+	ErrCodeSessionNotFound     = "session_not_found"
+	ErrCodeMessageNotFound     = "message_not_found"
+	ErrCodeInvalidMessage      = "invalid_message"
+	ErrCodeNothingToRegenerate = "nothing_to_regenerate"
+	ErrCodeSessionStoreFailed  = "session_store_failed"
+)
+
+// defaultSessionMaxTokens caps the length of generated assistant replies.
+const defaultSessionMaxTokens = 4096
+
+// SessionChangeListener is notified after a session has been written or deleted.
+// Callbacks run synchronously on the request goroutine, or on a background goroutine for
+// title and summary updates, and must not block for long.
+type SessionChangeListener interface {
+	SessionChanged(session *types.Session)
+	SessionDeleted(id string)
+}
+
+// SessionService manages conversation sessions and their branching message trees.
+type SessionService struct {
+	store           *storage.SessionStore
+	providerService *ProviderService
+	configStore     *storage.ConfigStore
+	mu              sync.RWMutex
+	listeners       []SessionChangeListener
+	// maintaining holds the IDs of sessions with a title or summary update in flight.
+	maintaining map[string]bool
+	// maintenance tracks background title and summary work so tests can wait for it.
+	maintenance sync.WaitGroup
+}
+
+// NewSessionService creates a new SessionService.
+// configStore may be nil, in which case default settings are used for provider selection
+// and generation parameters.
+func NewSessionService(store *storage.SessionStore, providerService *ProviderService, configStore *storage.ConfigStore) *SessionService {
+	return &SessionService{
+		store:           store,
+		providerService: providerService,
+		configStore:     configStore,
+		maintaining:     make(map[string]bool),
+	}
+}
+
+// CreateSessionRequest holds the caller-supplied fields for a new session.
+type CreateSessionRequest struct {
+	ProjectID    string                  `json:"project_id"`
+	AgentID      string                  `json:"agent_id"`
+	Title        string                  `json:"title,omitempty"`
+	Provider     string                  `json:"provider,omitempty"`
+	Model        string                  `json:"model,omitempty"`
+	SystemPrompt string                  `json:"system_prompt,omitempty"`
+	Generation   *types.GenerationPreset `json:"generation,omitempty"`
+}
+
+// GenerateOptions controls how an assistant reply is produced.
+type GenerateOptions struct {
+	// APIKey authenticates with the session's provider. For Ollama it is the endpoint URL
+	// and falls back to the configured endpoint when empty.
+	APIKey string
+	// UtilityAPIKey authenticates with the utility model used for titles and summaries when it
+	// uses a different provider than the session. Background tasks are skipped without one.
+	UtilityAPIKey string
+	// Generation overrides the resolved generation parameters for this request only.
+	Generation providers.GenerationParams
+	// OnChunk, if set, is called for every streamed chunk with the ID the reply will be stored under.
+	OnChunk func(messageID string, chunk providers.StreamChunk)
+}
+
+// newSessionID generates a random identifier with the given prefix.
+func newSessionID(prefix string) string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%s_%x", prefix, b)
+}
+
+// mapStoreError converts storage errors to SessionServiceError values.
+func mapStoreError(err error, sessionID string) error {
+	if errors.Is(err, storage.ErrSessionNotFound) {
+		return &SessionServiceError{
+			Code:    ErrCodeSessionNotFound,
+			Message: fmt.Sprintf("Session not found: %s", sessionID),
+		}
+	}
+	var svcErr *SessionServiceError
+	if errors.As(err, &svcErr) {
+		return svcErr
+	}
+	return &SessionServiceError{
+		Code:    ErrCodeSessionStoreFailed,
+		Message: fmt.Sprintf("Failed to access session %s: %v", sessionID, err),
+	}
+}
+
+// AddListener registers a listener for session writes and deletions.
+func (s *SessionService) AddListener(l SessionChangeListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, l)
+}
+
+// notifyChanged calls SessionChanged on every registered listener.
+func (s *SessionService) notifyChanged(session *types.Session) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, l := range listeners {
+		l.SessionChanged(session)
+	}
+}
+
+// notifyDeleted calls SessionDeleted on every registered listener.
+func (s *SessionService) notifyDeleted(id string) {
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, l := range listeners {
+		l.SessionDeleted(id)
+	}
+}
+
+// settings returns the current settings, or defaults when no config store is available.
+func (s *SessionService) settings() types.Settings {
+	if s.configStore == nil {
+		return storage.DefaultSettings()
+	}
+	settings, err := s.configStore.Load()
+	if err != nil {
+		return storage.DefaultSettings()
+	}
+	return settings
+}
+
+// CreateSession creates and persists an empty session.
+// Provider and model default to the configured defaults when not given.
+func (s *SessionService) CreateSession(req CreateSessionRequest) (*types.Session, error) {
+	if req.Generation != nil {
+		if err := ValidateGenerationPreset(*req.Generation); err != nil {
+			return nil, &SessionServiceError{Code: ErrCodeInvalidMessage, Message: "Invalid generation preset: " + err.Error()}
+		}
+	}
+
+	settings := s.settings()
+	now := types.Now()
+	session := &types.Session{
+		BaseEntity: types.BaseEntity{
+			ID:        newSessionID("sess"),
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		ProjectID:    req.ProjectID,
+		AgentID:      req.AgentID,
+		Title:        req.Title,
+		Provider:     req.Provider,
+		Model:        req.Model,
+		SystemPrompt: req.SystemPrompt,
+		Generation:   req.Generation,
+	}
+	if session.Provider == "" {
+		session.Provider = settings.DefaultProvider
+		if session.Model == "" {
+			session.Model = settings.DefaultModel
+		}
+	}
+
+	if err := s.store.Save(session); err != nil {
+		return nil, mapStoreError(err, session.ID)
+	}
+	s.notifyChanged(session)
+	return session, nil
+}
+
+// ListSessions returns all sessions without their message trees, most recently updated first.
+func (s *SessionService) ListSessions() ([]types.Session, error) {
+	sessions, err := s.store.List()
+	if err != nil {
+		return nil, mapStoreError(err, "")
+	}
+	for i := range sessions {
+		sessions[i].Messages = nil
+	}
+	return sessions, nil
+}
+
+// GetSession returns a session with its full message tree.
+func (s *SessionService) GetSession(id string) (*types.Session, error) {
+	session, err := s.store.Get(id)
+	if err != nil {
+		return nil, mapStoreError(err, id)
+	}
+	return session, nil
+}
+
+// DeleteSession removes a session.
+func (s *SessionService) DeleteSession(id string) error {
+	if err := s.store.Delete(id); err != nil {
+		return mapStoreError(err, id)
+	}
+	s.notifyDeleted(id)
+	return nil
+}
+
+// SendMessage appends a user message to the end of the active branch and generates a reply.
+// The user message is kept even if generation fails, so the reply can be retried with Regenerate.
+func (s *SessionService) SendMessage(ctx context.Context, sessionID string, content string, opts GenerateOptions) (*types.Session, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, &SessionServiceError{Code: ErrCodeInvalidMessage, Message: "Message content is required"}
+	}
+
+	var userID string
+	session, err := s.store.Update(sessionID, func(session *types.Session) error {
+		userID = appendMessage(session, session.ActiveLeafID, "user", content)
+		return nil
+	})
+	if err != nil {
+		return nil, mapStoreError(err, sessionID)
+	}
+	s.notifyChanged(session)
+
+	return s.generateReply(ctx, sessionID, userID, opts)
+}
+
+// ForkMessage creates an edited copy of an earlier user message as a sibling of the original,
+// makes it the active branch and generates a reply. The original branch is left untouched.
+func (s *SessionService) ForkMessage(ctx context.Context, sessionID string, messageID string, content string, opts GenerateOptions) (*types.Session, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, &SessionServiceError{Code: ErrCodeInvalidMessage, Message: "Message content is required"}
+	}
+
+	var userID string
+	session, err := s.store.Update(sessionID, func(session *types.Session) error {
+		original := findMessage(session, messageID)
+		if original == nil {
+			return messageNotFound(messageID)
+		}
+		if original.Role != "user" {
+			return &SessionServiceError{Code: ErrCodeInvalidMessage, Message: "Only user messages can be edited; use regenerate for assistant replies"}
+		}
+		userID = appendMessage(session, original.ParentID, "user", content)
+		return nil
+	})
+	if err != nil {
+		return nil, mapStoreError(err, sessionID)
+	}
+	s.notifyChanged(session)
+
+	return s.generateReply(ctx, sessionID, userID, opts)
+}
+
+// Regenerate produces an alternative reply for the last user message on the active branch.
+// If the branch ends in an assistant reply, the new reply becomes its sibling; if it ends in
+// a user message whose reply previously failed, the missing reply is generated.
+func (s *SessionService) Regenerate(ctx context.Context, sessionID string, opts GenerateOptions) (*types.Session, error) {
+	session, err := s.store.Get(sessionID)
+	if err != nil {
+		return nil, mapStoreError(err, sessionID)
+	}
+
+	leaf := findMessage(session, session.ActiveLeafID)
+	if leaf == nil {
+		return nil, &SessionServiceError{Code: ErrCodeNothingToRegenerate, Message: "Session has no messages to regenerate"}
+	}
+
+	parentID := leaf.ID
+	if leaf.Role == "assistant" {
+		parentID = leaf.ParentID
+	}
+	return s.generateReply(ctx, sessionID, parentID, opts)
+}
+
+// SwitchBranch makes the branch through messageID active. The active leaf becomes the most
+// recent descendant of messageID, so selecting a fork point resumes its latest continuation.
+func (s *SessionService) SwitchBranch(sessionID string, messageID string) (*types.Session, error) {
+	session, err := s.store.Update(sessionID, func(session *types.Session) error {
+		if findMessage(session, messageID) == nil {
+			return messageNotFound(messageID)
+		}
+		session.ActiveLeafID = latestLeaf(session, messageID)
+		session.UpdatedAt = types.Now()
+		return nil
+	})
+	if err != nil {
+		return nil, mapStoreError(err, sessionID)
+	}
+	return session, nil
+}
+
+// Siblings returns the alternatives sharing messageID's parent, in creation order.
+func (s *SessionService) Siblings(sessionID string, messageID string) (*types.MessageSiblingsResponse, error) {
+	session, err := s.store.Get(sessionID)
+	if err != nil {
+		return nil, mapStoreError(err, sessionID)
+	}
+
+	msg := findMessage(session, messageID)
+	if msg == nil {
+		return nil, messageNotFound(messageID)
+	}
+
+	siblings := childrenOf(session, msg.ParentID)
+	index := 0
+	for i, sib := range siblings {
+		if sib.ID == messageID {
+			index = i
+			break
+		}
+	}
+
+	return &types.MessageSiblingsResponse{
+		MessageID: messageID,
+		Index:     index,
+		Siblings:  siblings,
+	}, nil
+}
+
+// generateReply streams an assistant reply to the branch ending at parentID and stores it
+// as a new child of parentID, which becomes the active leaf.
+func (s *SessionService) generateReply(ctx context.Context, sessionID string, parentID string, opts GenerateOptions) (*types.Session, error) {
+	session, err := s.store.Get(sessionID)
+	if err != nil {
+		return nil, mapStoreError(err, sessionID)
+	}
+
+	settings := s.settings()
+	history, systemPrompt := contextFor(session, branchTo(session, parentID))
+
+	providerType := session.Provider
+	model := session.Model
+	if providerType == "" {
+		providerType = settings.DefaultProvider
+	}
+	if model == "" {
+		model = settings.DefaultModel
+	}
+	apiKey := opts.APIKey
+	if providerType == "ollama" && apiKey == "" {
+		apiKey = settings.OllamaEndpoint
+	}
+
+	ch, err := s.providerService.SendMessage(ctx, providerType, apiKey, providers.ChatRequest{
+		Messages:         history,
+		Model:            model,
+		MaxTokens:        defaultSessionMaxTokens,
+		SystemPrompt:     systemPrompt,
+		CachePrompt:      true,
+		GenerationParams: ResolveGenerationParams(settings, session.AgentID, session, opts.Generation),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	replyID := newSessionID("msg")
+	var content strings.Builder
+	var usage *types.TokenUsage
+	for chunk := range ch {
+		if opts.OnChunk != nil {
+			opts.OnChunk(replyID, chunk)
+		}
+		switch chunk.Type {
+		case "chunk":
+			content.WriteString(chunk.Content)
+		case "end":
+			if chunk.Usage != nil {
+				usage = &types.TokenUsage{
+					InputTokens:      chunk.Usage.InputTokens,
+					OutputTokens:     chunk.Usage.OutputTokens,
+					CacheReadTokens:  chunk.Usage.CacheReadTokens,
+					CacheWriteTokens: chunk.Usage.CacheWriteTokens,
+				}
+			}
+		case "error":
+			return nil, &providers.ProviderError{
+				Code:        "stream_error",
+				Message:     chunk.Content,
+				UserMessage: chunk.Content,
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	session, err = s.store.Update(sessionID, func(session *types.Session) error {
+		if parentID != "" && findMessage(session, parentID) == nil {
+			return messageNotFound(parentID)
+		}
+		now := types.Now()
+		session.Messages = append(session.Messages, types.SessionMessage{
+			ID:        replyID,
+			ParentID:  parentID,
+			Role:      "assistant",
+			Content:   content.String(),
+			Model:     model,
+			Usage:     usage,
+			CreatedAt: now,
+		})
+		session.ActiveLeafID = replyID
+		session.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, mapStoreError(err, sessionID)
+	}
+	s.notifyChanged(session)
+
+	s.maintainInBackground(session, settings, opts)
+	return session, nil
+}
+
+// NewSessionResponse builds the API response for a session, resolving its active branch.
+func NewSessionResponse(session *types.Session) *types.SessionResponse {
+	return &types.SessionResponse{
+		Session:      *session,
+		ActiveBranch: ActiveBranch(session),
+	}
+}
+
+// ActiveBranch returns the messages from the root to the active leaf, in conversation order.
+func ActiveBranch(session *types.Session) []types.SessionMessage {
+	return branchTo(session, session.ActiveLeafID)
+}
+
+// branchTo returns the messages from the root to messageID, in conversation order.
+func branchTo(session *types.Session, messageID string) []types.SessionMessage {
+	byID := make(map[string]types.SessionMessage, len(session.Messages))
+	for _, msg := range session.Messages {
+		byID[msg.ID] = msg
+	}
+
+	branch := []types.SessionMessage{}
+	for id := messageID; id != ""; {
+		msg, ok := byID[id]
+		if !ok {
+			break
+		}
+		branch = append(branch, msg)
+		id = msg.ParentID
+		if len(branch) > len(session.Messages) {
+			break // Guard against a corrupted file containing a parent cycle
+		}
+	}
+
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+	return branch
+}
+
+// appendMessage adds a message under parentID, makes it the active leaf and returns its ID.
+func appendMessage(session *types.Session, parentID string, role string, content string) string {
+	now := types.Now()
+	id := newSessionID("msg")
+	session.Messages = append(session.Messages, types.SessionMessage{
+		ID:        id,
+		ParentID:  parentID,
+		Role:      role,
+		Content:   content,
+		CreatedAt: now,
+	})
+	session.ActiveLeafID = id
+	session.UpdatedAt = now
+	return id
+}
+
+// findMessage returns a pointer to the message with the given ID, or nil.
+func findMessage(session *types.Session, id string) *types.SessionMessage {
+	if id == "" {
+		return nil
+	}
+	for i := range session.Messages {
+		if session.Messages[i].ID == id {
+			return &session.Messages[i]
+		}
+	}
+	return nil
+}
+
+// childrenOf returns the direct children of parentID in creation order.
+// An empty parentID returns the root messages.
+func childrenOf(session *types.Session, parentID string) []types.SessionMessage {
+	children := []types.SessionMessage{}
+	for _, msg := range session.Messages {
+		if msg.ParentID == parentID {
+			children = append(children, msg)
+		}
+	}
+	return children
+}
+
+// latestLeaf follows the most recently created child from messageID down to a leaf.
+func latestLeaf(session *types.Session, messageID string) string {
+	leaf := messageID
+	for depth := 0; depth <= len(session.Messages); depth++ {
+		children := childrenOf(session, leaf)
+		if len(children) == 0 {
+			break
+		}
+		leaf = children[len(children)-1].ID
+	}
+	return leaf
+}
+
+// messageNotFound builds the error returned for an unknown message ID.
+func messageNotFound(messageID string) *SessionServiceError {
+	return &SessionServiceError{
+		Code:    ErrCodeMessageNotFound,
+		Message: fmt.Sprintf("Message not found: %s", messageID),
+	}
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// fakeOllama is an Ollama /api/chat stand-in that numbers its replies and records
+// the conversation it was sent. Utility requests (titles, summaries) are counted separately.
+type fakeOllama struct {
+	mu                sync.Mutex
+	calls             int
+	titleCalls        int
+	summaryCalls      int
+	lastSent          []providers.Message
+	lastSystem        string
+	lastSummaryPrompt string
+}
+
+func (f *fakeOllama) handler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Messages []providers.Message `json:"messages"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	// Title and summary requests are answered separately so they don't shift reply numbering
+	if len(req.Messages) > 0 && req.Messages[0].Role == "system" {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		reply := "Generated Title"
+		if req.Messages[0].Content == summarySystemPrompt {
+			f.summaryCalls++
+			f.lastSummaryPrompt = req.Messages[1].Content
+			reply = fmt.Sprintf("summary %d", f.summaryCalls)
+		} else if req.Messages[0].Content == titleSystemPrompt {
+			f.titleCalls++
+		} else {
+			f.lastSystem = req.Messages[0].Content
+			f.lastSent = req.Messages[1:]
+			f.calls++
+			reply = fmt.Sprintf("reply %d", f.calls)
+		}
+		fmt.Fprintf(w, `{"model":"llama3.2","message":{"role":"assistant","content":%q},"done":false}`+"\n", reply)
+		fmt.Fprintln(w, `{"model":"llama3.2","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":7,"eval_count":2}`)
+		return
+	}
+
+	f.mu.Lock()
+	f.calls++
+	n := f.calls
+	f.lastSent = req.Messages
+	f.lastSystem = ""
+	f.mu.Unlock()
+
+	fmt.Fprintf(w, `{"model":"llama3.2","message":{"role":"assistant","content":"reply %d"},"done":false}`+"\n", n)
+	fmt.Fprintln(w, `{"model":"llama3.2","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":7,"eval_count":2}`)
+}
+
+func newTestSessionService(t *testing.T) (*SessionService, *fakeOllama, GenerateOptions) {
+	t.Helper()
+	fake := &fakeOllama{}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	t.Cleanup(server.Close)
+
+	svc := NewSessionService(storage.NewSessionStoreWithDir(t.TempDir()), NewProviderService(), nil)
+	t.Cleanup(svc.maintenance.Wait)
+	return svc, fake, GenerateOptions{APIKey: server.URL}
+}
+
+func createOllamaSession(t *testing.T, svc *SessionService) *types.Session {
+	t.Helper()
+	session, err := svc.CreateSession(CreateSessionRequest{AgentID: "pm", Provider: "ollama", Model: "llama3.2"})
+	if err != nil {
+		t.Fatalf("CreateSession error: %v", err)
+	}
+	return session
+}
+
+func branchContents(session *types.Session) []string {
+	var contents []string
+	for _, msg := range ActiveBranch(session) {
+		contents = append(contents, msg.Content)
+	}
+	return contents
+}
+
+func TestSessionService_CreateSession_UsesDefaults(t *testing.T) {
+	svc, _, _ := newTestSessionService(t)
+	session, err := svc.CreateSession(CreateSessionRequest{AgentID: "architect"})
+	if err != nil {
+		t.Fatalf("CreateSession error: %v", err)
+	}
+	defaults := storage.DefaultSettings()
+	if session.Provider != defaults.DefaultProvider || session.Model != defaults.DefaultModel {
+		t.Errorf("Expected default provider/model, got %s/%s", session.Provider, session.Model)
+	}
+	if session.ID == "" {
+		t.Error("Expected generated session ID")
+	}
+}
+
+func TestSessionService_SendMessage_BuildsBranch(t *testing.T) {
+	svc, fake, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+
+	session, err := svc.SendMessage(context.Background(), session.ID, "Draft the goals section", opts)
+	if err != nil {
+		t.Fatalf("SendMessage error: %v", err)
+	}
+	session, err = svc.SendMessage(context.Background(), session.ID, "Make it shorter", opts)
+	if err != nil {
+		t.Fatalf("SendMessage error: %v", err)
+	}
+
+	got := branchContents(session)
+	want := []string{"Draft the goals section", "reply 1", "Make it shorter", "reply 2"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Active branch = %v, want %v", got, want)
+	}
+	if len(fake.lastSent) != 3 {
+		t.Errorf("Expected full history (3 messages) sent to provider, got %d", len(fake.lastSent))
+	}
+
+	last := ActiveBranch(session)[3]
+	if last.Usage == nil || last.Usage.InputTokens != 7 || last.Usage.OutputTokens != 2 {
+		t.Errorf("Expected usage recorded on reply, got %+v", last.Usage)
+	}
+}
+
+func TestSessionService_ForkMessage_KeepsOriginalBranch(t *testing.T) {
+	svc, fake, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+
+	session, _ = svc.SendMessage(context.Background(), session.ID, "Version A", opts)
+	session, _ = svc.SendMessage(context.Background(), session.ID, "Follow-up", opts)
+	originalLeaf := session.ActiveLeafID
+	firstUser := ActiveBranch(session)[0]
+
+	session, err := svc.ForkMessage(context.Background(), session.ID, firstUser.ID, "Version B", opts)
+	if err != nil {
+		t.Fatalf("ForkMessage error: %v", err)
+	}
+
+	got := branchContents(session)
+	want := []string{"Version B", "reply 3"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Active branch after fork = %v, want %v", got, want)
+	}
+	if len(fake.lastSent) != 1 || fake.lastSent[0].Content != "Version B" {
+		t.Errorf("Expected only the edited message sent, got %+v", fake.lastSent)
+	}
+	if findMessage(session, originalLeaf) == nil {
+		t.Error("Original branch should be preserved")
+	}
+
+	siblings, err := svc.Siblings(session.ID, firstUser.ID)
+	if err != nil {
+		t.Fatalf("Siblings error: %v", err)
+	}
+	if len(siblings.Siblings) != 2 || siblings.Index != 0 {
+		t.Errorf("Expected 2 siblings with original at index 0, got %+v", siblings)
+	}
+
+	// Switching back to the original root resumes its latest continuation
+	session, err = svc.SwitchBranch(session.ID, firstUser.ID)
+	if err != nil {
+		t.Fatalf("SwitchBranch error: %v", err)
+	}
+	if session.ActiveLeafID != originalLeaf {
+		t.Errorf("ActiveLeafID = %s, want original leaf %s", session.ActiveLeafID, originalLeaf)
+	}
+}
+
+func TestSessionService_ForkMessage_RejectsAssistantMessage(t *testing.T) {
+	svc, _, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+	session, _ = svc.SendMessage(context.Background(), session.ID, "Hello", opts)
+
+	_, err := svc.ForkMessage(context.Background(), session.ID, session.ActiveLeafID, "Edited", opts)
+	svcErr, ok := err.(*SessionServiceError)
+	if !ok || svcErr.Code != ErrCodeInvalidMessage {
+		t.Errorf("Expected invalid_message error, got %v", err)
+	}
+}
+
+func TestSessionService_Regenerate_AddsSibling(t *testing.T) {
+	svc, _, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+	session, _ = svc.SendMessage(context.Background(), session.ID, "Hello", opts)
+	firstReply := session.ActiveLeafID
+
+	session, err := svc.Regenerate(context.Background(), session.ID, opts)
+	if err != nil {
+		t.Fatalf("Regenerate error: %v", err)
+	}
+
+	got := branchContents(session)
+	if fmt.Sprint(got) != fmt.Sprint([]string{"Hello", "reply 2"}) {
+		t.Errorf("Active branch = %v", got)
+	}
+
+	siblings, _ := svc.Siblings(session.ID, session.ActiveLeafID)
+	if len(siblings.Siblings) != 2 || siblings.Siblings[0].ID != firstReply || siblings.Index != 1 {
+		t.Errorf("Expected regenerated reply as second sibling, got %+v", siblings)
+	}
+}
+
+func TestSessionService_Regenerate_EmptySession(t *testing.T) {
+	svc, _, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+
+	_, err := svc.Regenerate(context.Background(), session.ID, opts)
+	svcErr, ok := err.(*SessionServiceError)
+	if !ok || svcErr.Code != ErrCodeNothingToRegenerate {
+		t.Errorf("Expected nothing_to_regenerate error, got %v", err)
+	}
+}
+
+func TestSessionService_SwitchBranch_UnknownMessage(t *testing.T) {
+	svc, _, _ := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+
+	_, err := svc.SwitchBranch(session.ID, "msg_missing")
+	svcErr, ok := err.(*SessionServiceError)
+	if !ok || svcErr.Code != ErrCodeMessageNotFound {
+		t.Errorf("Expected message_not_found error, got %v", err)
+	}
+}
+
+func TestSessionService_GetSession_NotFound(t *testing.T) {
+	svc, _, _ := newTestSessionService(t)
+
+	_, err := svc.GetSession("sess_missing")
+	svcErr, ok := err.(*SessionServiceError)
+	if !ok || svcErr.Code != ErrCodeSessionNotFound {
+		t.Errorf("Expected session_not_found error, got %v", err)
+	}
+}
+
+func TestSessionService_SendMessage_StreamsChunks(t *testing.T) {
+	svc, _, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+
+	var replyIDs []string
+	opts.OnChunk = func(messageID string, chunk providers.StreamChunk) {
+		replyIDs = append(replyIDs, messageID)
+	}
+	session, err := svc.SendMessage(context.Background(), session.ID, "Hello", opts)
+	if err != nil {
+		t.Fatalf("SendMessage error: %v", err)
+	}
+
+	if len(replyIDs) == 0 {
+		t.Fatal("Expected OnChunk to be called")
+	}
+	for _, id := range replyIDs {
+		if id != session.ActiveLeafID {
+			t.Errorf("Chunk message ID %s does not match stored reply %s", id, session.ActiveLeafID)
+		}
+	}
+}
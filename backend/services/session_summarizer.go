@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"bmad-studio/backend/providers"
+	"bmad-studio/backend/types"
+)
+
+// defaultSummarizeAfterTokens is the estimated branch size at which older turns are summarized.
+// It is well below the context window of every supported model so the summary is ready before it is needed.
+const defaultSummarizeAfterTokens = 24000
+
+// keepRecentMessages is the number of most recent messages never folded into a summary.
+const keepRecentMessages = 6
+
+// utilityTimeout bounds each title or summary request so a slow model never stalls a reply.
+const utilityTimeout = 30 * time.Second
+
+// maintenanceTimeout bounds a whole background pass, which makes at most a title and a summary request.
+const maintenanceTimeout = 2 * utilityTimeout
+
+// titleMaxLength caps generated titles; longer output is truncated on a word boundary.
+const titleMaxLength = 80
+
+// defaultUtilityModels maps a provider to its cheapest suitable model.
+// Ollama has no entry: the session's own local model is reused.
+var defaultUtilityModels = map[string]string{
+	"claude": "claude-haiku-4-5-20251001",
+	"openai": "gpt-4o-mini",
+}
+
+const titleSystemPrompt = "You write short titles for conversations. Reply with a title of at most six words that captures the topic. " +
+	"Do not use quotes or trailing punctuation."
+
+const summarySystemPrompt = "You maintain a running summary of a product-planning conversation. Write a concise summary that preserves " +
+	"decisions made, requirements, constraints, open questions and any document sections drafted. Reply with the summary only."
+
+// utilityModel resolves the provider, model and credential used for background tasks.
+// ok is false when no credential is available for the utility provider.
+func utilityModel(settings types.Settings, session *types.Session, opts GenerateOptions) (provider, model, apiKey string, ok bool) {
+	cfg := types.UtilityModelSettings{}
+	if settings.UtilityModel != nil {
+		cfg = *settings.UtilityModel
+	}
+
+	provider = cfg.Provider
+	if provider == "" {
+		provider = session.Provider
+	}
+	model = cfg.Model
+	if model == "" {
+		model = defaultUtilityModels[provider]
+	}
+	if model == "" && provider == session.Provider {
+		model = session.Model
+	}
+
+	apiKey = opts.UtilityAPIKey
+	if apiKey == "" && provider == session.Provider {
+		apiKey = opts.APIKey
+	}
+	if apiKey == "" && provider == "ollama" {
+		apiKey = settings.OllamaEndpoint
+	}
+
+	return provider, model, apiKey, model != "" && apiKey != ""
+}
+
+// summarizeAfterTokens returns the configured summarization threshold.
+func summarizeAfterTokens(settings types.Settings) int {
+	if settings.UtilityModel != nil && settings.UtilityModel.SummarizeAfterTokens > 0 {
+		return settings.UtilityModel.SummarizeAfterTokens
+	}
+	return defaultSummarizeAfterTokens
+}
+
+// estimateTokens approximates the token count of messages at four characters per token.
+func estimateTokens(messages []types.SessionMessage) int {
+	total := 0
+	for _, msg := range messages {
+		total += len(msg.Content)/4 + 4 // Per-message overhead for role markers
+	}
+	return total
+}
+
+// latestSummary returns the summary covering the longest prefix of branch and the index of
+// the last message it covers, or -1 if no summary applies.
+func latestSummary(session *types.Session, branch []types.SessionMessage) (*types.SessionSummary, int) {
+	position := make(map[string]int, len(branch))
+	for i, msg := range branch {
+		position[msg.ID] = i
+	}
+
+	var best *types.SessionSummary
+	bestIndex := -1
+	for i := range session.Summaries {
+		if idx, ok := position[session.Summaries[i].ThroughMessageID]; ok && idx > bestIndex {
+			best = &session.Summaries[i]
+			bestIndex = idx
+		}
+	}
+	return best, bestIndex
+}
+
+// contextFor returns the messages and system prompt sent to the provider for branch.
+// A branch without a summary is sent verbatim. Summaries are only written once the
+// unsummarized messages outgrow the threshold, so when one covers a prefix of branch
+// that prefix is replaced by the summary in the system prompt.
+func contextFor(session *types.Session, branch []types.SessionMessage) ([]providers.Message, string) {
+	systemPrompt := session.SystemPrompt
+	if summary, idx := latestSummary(session, branch); summary != nil && idx+1 < len(branch) {
+		branch = branch[idx+1:]
+		note := "Summary of the earlier conversation:\n" + summary.Content
+		if systemPrompt != "" {
+			systemPrompt += "\n\n" + note
+		} else {
+			systemPrompt = note
+		}
+	}
+
+	history := make([]providers.Message, 0, len(branch))
+	for _, msg := range branch {
+		history = append(history, providers.Message{Role: msg.Role, Content: msg.Content})
+	}
+	return history, systemPrompt
+}
+
+// maintainInBackground runs maintainSession without holding up the reply that triggered it.
+// The work gets its own context so a client disconnect doesn't cancel it, and listeners are
+// notified if it changes the session. A pass is skipped while another one for the same
+// session is still running; the next reply picks up whatever it missed.
+func (s *SessionService) maintainInBackground(session *types.Session, settings types.Settings, opts GenerateOptions) {
+	s.mu.Lock()
+	if s.maintaining[session.ID] {
+		s.mu.Unlock()
+		return
+	}
+	s.maintaining[session.ID] = true
+	s.mu.Unlock()
+
+	s.maintenance.Add(1)
+	go func() {
+		defer s.maintenance.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.maintaining, session.ID)
+			s.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
+		defer cancel()
+		if maintained := s.maintainSession(ctx, session, settings, opts); maintained != session {
+			s.notifyChanged(maintained)
+		}
+	}()
+}
+
+// maintainSession fills in a missing title after the first exchange and rolls older turns
+// into a running summary once the active branch grows past the threshold. Failures are
+// logged and never surface to the caller: the reply has already been stored.
+func (s *SessionService) maintainSession(ctx context.Context, session *types.Session, settings types.Settings, opts GenerateOptions) *types.Session {
+	provider, model, apiKey, ok := utilityModel(settings, session, opts)
+	if !ok {
+		return session
+	}
+	cfg := types.UtilityModelSettings{}
+	if settings.UtilityModel != nil {
+		cfg = *settings.UtilityModel
+	}
+
+	branch := ActiveBranch(session)
+
+	if session.Title == "" && !cfg.DisableTitles && len(branch) >= 2 {
+		if title, err := s.generateTitle(ctx, provider, model, apiKey, branch[:2]); err != nil {
+			log.Printf("Warning: Failed to generate title for session %s: %v", session.ID, err)
+		} else if title != "" {
+			if updated, err := s.store.Update(session.ID, func(current *types.Session) error {
+				if current.Title == "" {
+					current.Title = title
+				}
+				return nil
+			}); err == nil {
+				session = updated
+			}
+		}
+	}
+
+	if !cfg.DisableSummaries {
+		if summary, err := s.summarizeBranch(ctx, provider, model, apiKey, session, branch, summarizeAfterTokens(settings)); err != nil {
+			log.Printf("Warning: Failed to summarize session %s: %v", session.ID, err)
+		} else if summary != nil {
+			if updated, err := s.store.Update(session.ID, func(current *types.Session) error {
+				current.Summaries = append(current.Summaries, *summary)
+				return nil
+			}); err == nil {
+				session = updated
+			}
+		}
+	}
+
+	return session
+}
+
+// generateTitle asks the utility model for a title describing the opening exchange.
+func (s *SessionService) generateTitle(ctx context.Context, provider, model, apiKey string, exchange []types.SessionMessage) (string, error) {
+	prompt := fmt.Sprintf("User: %s\n\nAssistant: %s", exchange[0].Content, exchange[1].Content)
+	title, err := s.complete(ctx, provider, model, apiKey, titleSystemPrompt, prompt, 32)
+	if err != nil {
+		return "", err
+	}
+	return cleanTitle(title), nil
+}
+
+// summarizeBranch returns a new summary for branch, or nil if the messages after its latest
+// summary are not yet large enough. The most recent messages are kept verbatim, and the
+// summarized prefix always ends just before a user message so the remaining history still
+// starts with a user turn.
+func (s *SessionService) summarizeBranch(ctx context.Context, provider, model, apiKey string, session *types.Session, branch []types.SessionMessage, threshold int) (*types.SessionSummary, error) {
+	previous, start := latestSummary(session, branch)
+	start++
+	if estimateTokens(branch[start:]) <= threshold {
+		return nil, nil
+	}
+
+	cut := len(branch) - keepRecentMessages
+	for cut > start && branch[cut].Role != "user" {
+		cut--
+	}
+	if cut-start < 2 {
+		return nil, nil
+	}
+
+	var prompt strings.Builder
+	if previous != nil {
+		prompt.WriteString("Existing summary:\n")
+		prompt.WriteString(previous.Content)
+		prompt.WriteString("\n\nConversation since the summary:\n")
+	} else {
+		prompt.WriteString("Conversation:\n")
+	}
+	for _, msg := range branch[start:cut] {
+		role := "User"
+		if msg.Role == "assistant" {
+			role = "Assistant"
+		}
+		fmt.Fprintf(&prompt, "%s: %s\n\n", role, msg.Content)
+	}
+
+	content, err := s.complete(ctx, provider, model, apiKey, summarySystemPrompt, prompt.String(), 1024)
+	if err != nil {
+		return nil, err
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, nil
+	}
+
+	return &types.SessionSummary{
+		ThroughMessageID: branch[cut-1].ID,
+		Content:          content,
+		CreatedAt:        types.Now(),
+	}, nil
+}
+
+// complete sends a single-turn request to the utility model and returns the full reply.
+func (s *SessionService) complete(ctx context.Context, provider, model, apiKey, systemPrompt, prompt string, maxTokens int) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, utilityTimeout)
+	defer cancel()
+
+	ch, err := s.providerService.SendMessage(ctx, provider, apiKey, providers.ChatRequest{
+		Messages:     []providers.Message{{Role: "user", Content: prompt}},
+		Model:        model,
+		MaxTokens:    maxTokens,
+		SystemPrompt: systemPrompt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var reply strings.Builder
+	for chunk := range ch {
+		switch chunk.Type {
+		case "chunk":
+			reply.WriteString(chunk.Content)
+		case "error":
+			return "", fmt.Errorf("%s", chunk.Content)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return reply.String(), nil
+}
+
+// cleanTitle normalises model output into a single-line title.
+func cleanTitle(raw string) string {
+	title := strings.TrimSpace(raw)
+	if i := strings.IndexByte(title, '\n'); i != -1 {
+		title = strings.TrimSpace(title[:i])
+	}
+	title = strings.TrimPrefix(title, "Title:")
+	title = strings.Trim(title, " \"'`*#.")
+	if len(title) > titleMaxLength {
+		cut := strings.LastIndexByte(title[:titleMaxLength], ' ')
+		if cut <= 0 {
+			cut = titleMaxLength
+		}
+		title = strings.TrimSpace(title[:cut])
+	}
+	return title
+}
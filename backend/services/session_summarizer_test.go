@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// newSummarizingSessionService is newTestSessionService with utility settings applied.
+func newSummarizingSessionService(t *testing.T, utility types.UtilityModelSettings) (*SessionService, *fakeOllama, GenerateOptions) {
+	t.Helper()
+	fake := &fakeOllama{}
+	server := httptest.NewServer(http.HandlerFunc(fake.handler))
+	t.Cleanup(server.Close)
+
+	configStore := storage.NewConfigStoreWithPath(filepath.Join(t.TempDir(), "config.json"))
+	settings := storage.DefaultSettings()
+	settings.UtilityModel = &utility
+	if err := configStore.Save(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewSessionService(storage.NewSessionStoreWithDir(t.TempDir()), NewProviderService(), configStore)
+	t.Cleanup(svc.maintenance.Wait)
+	return svc, fake, GenerateOptions{APIKey: server.URL}
+}
+
+// sendAndSettle sends a message, waits for the background title and summary pass it
+// started, and returns the session as stored afterwards.
+func sendAndSettle(t *testing.T, svc *SessionService, sessionID, content string, opts GenerateOptions) *types.Session {
+	t.Helper()
+	if _, err := svc.SendMessage(context.Background(), sessionID, content, opts); err != nil {
+		t.Fatalf("SendMessage error: %v", err)
+	}
+	svc.maintenance.Wait()
+	session, err := svc.GetSession(sessionID)
+	if err != nil {
+		t.Fatalf("GetSession error: %v", err)
+	}
+	return session
+}
+
+func TestSessionService_GeneratesTitleOnce(t *testing.T) {
+	svc, fake, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+
+	reply, err := svc.SendMessage(context.Background(), session.ID, "Help me write the PRD goals", opts)
+	if err != nil {
+		t.Fatalf("SendMessage error: %v", err)
+	}
+	if reply.Title != "" {
+		t.Errorf("Expected the reply returned before the title is generated, got %q", reply.Title)
+	}
+	svc.maintenance.Wait()
+	session, _ = svc.GetSession(session.ID)
+	if session.Title != "Generated Title" {
+		t.Errorf("Title = %q, want generated title", session.Title)
+	}
+
+	sendAndSettle(t, svc, session.ID, "Add a metric", opts)
+	if fake.titleCalls != 1 {
+		t.Errorf("Expected exactly one title request, got %d", fake.titleCalls)
+	}
+}
+
+func TestSessionService_KeepsExplicitTitle(t *testing.T) {
+	svc, fake, opts := newTestSessionService(t)
+	session, _ := svc.CreateSession(CreateSessionRequest{Provider: "ollama", Model: "llama3.2", Title: "My title"})
+
+	session = sendAndSettle(t, svc, session.ID, "Hello", opts)
+	if session.Title != "My title" || fake.titleCalls != 0 {
+		t.Errorf("Expected explicit title kept without a title request, got %q (%d calls)", session.Title, fake.titleCalls)
+	}
+}
+
+func TestSessionService_DisableTitles(t *testing.T) {
+	svc, fake, opts := newSummarizingSessionService(t, types.UtilityModelSettings{DisableTitles: true})
+	session := createOllamaSession(t, svc)
+
+	session = sendAndSettle(t, svc, session.ID, "Hello", opts)
+	if session.Title != "" || fake.titleCalls != 0 {
+		t.Errorf("Expected no title when disabled, got %q (%d calls)", session.Title, fake.titleCalls)
+	}
+}
+
+func TestSessionService_RunningSummaryReplacesOlderTurns(t *testing.T) {
+	svc, fake, opts := newSummarizingSessionService(t, types.UtilityModelSettings{SummarizeAfterTokens: 100})
+	session := createOllamaSession(t, svc)
+
+	long := strings.Repeat("requirement detail ", 20) // ~95 estimated tokens
+	for i := 0; i < 4; i++ {
+		session = sendAndSettle(t, svc, session.ID, long, opts)
+	}
+
+	if len(session.Summaries) == 0 {
+		t.Fatal("Expected a running summary once the branch exceeded the threshold")
+	}
+	summary := session.Summaries[len(session.Summaries)-1]
+	branch := ActiveBranch(session)
+	for i, msg := range branch {
+		if msg.ID == summary.ThroughMessageID {
+			if i+1 >= len(branch) || branch[i+1].Role != "user" {
+				t.Errorf("Summary should end just before a user turn")
+			}
+			if len(branch)-(i+1) < keepRecentMessages {
+				t.Errorf("Expected at least %d recent messages kept verbatim, got %d", keepRecentMessages, len(branch)-(i+1))
+			}
+		}
+	}
+
+	// The next request replaces the summarized prefix with the summary
+	session, err := svc.SendMessage(context.Background(), session.ID, "Next step", opts)
+	if err != nil {
+		t.Fatalf("SendMessage error: %v", err)
+	}
+	if !strings.Contains(fake.lastSystem, "Summary of the earlier conversation") {
+		t.Errorf("Expected summary in system prompt, got %q", fake.lastSystem)
+	}
+	if len(fake.lastSent) >= len(ActiveBranch(session))-1 {
+		t.Errorf("Expected summarized turns to be dropped, sent %d messages", len(fake.lastSent))
+	}
+	if fake.lastSent[0].Role != "user" {
+		t.Errorf("Expected history to start with a user turn, got %s", fake.lastSent[0].Role)
+	}
+}
+
+func TestSessionService_NoSummaryRightAfterSummary(t *testing.T) {
+	svc, fake, opts := newSummarizingSessionService(t, types.UtilityModelSettings{SummarizeAfterTokens: 400})
+	session := createOllamaSession(t, svc)
+
+	long := strings.Repeat("requirement detail ", 10) // ~50 estimated tokens
+	for i := 0; i < 20 && len(session.Summaries) == 0; i++ {
+		session = sendAndSettle(t, svc, session.ID, long, opts)
+	}
+	if len(session.Summaries) != 1 {
+		t.Fatalf("Expected one summary once the branch outgrew the threshold, got %d", len(session.Summaries))
+	}
+
+	fake.mu.Lock()
+	calls := fake.summaryCalls
+	fake.mu.Unlock()
+	session = sendAndSettle(t, svc, session.ID, long, opts)
+	if len(session.Summaries) != 1 || fake.summaryCalls != calls {
+		t.Errorf("Expected no new summary while the messages since the last one fit, got %d summaries (%d calls)",
+			len(session.Summaries), fake.summaryCalls-calls)
+	}
+}
+
+func TestSessionService_NoSummaryBelowThreshold(t *testing.T) {
+	svc, fake, opts := newTestSessionService(t)
+	session := createOllamaSession(t, svc)
+
+	for i := 0; i < 5; i++ {
+		session = sendAndSettle(t, svc, session.ID, "short", opts)
+	}
+	if len(session.Summaries) != 0 || fake.summaryCalls != 0 {
+		t.Errorf("Expected no summaries for a short session, got %d", len(session.Summaries))
+	}
+}
+
+func TestUtilityModel(t *testing.T) {
+	settings := storage.DefaultSettings()
+	claudeSession := &types.Session{Provider: "claude", Model: "claude-opus-4-5-20251101"}
+
+	provider, model, apiKey, ok := utilityModel(settings, claudeSession, GenerateOptions{APIKey: "sk-test"})
+	if !ok || provider != "claude" || model != "claude-haiku-4-5-20251001" || apiKey != "sk-test" {
+		t.Errorf("Expected Haiku with the session key, got %s/%s ok=%v", provider, model, ok)
+	}
+
+	settings.UtilityModel = &types.UtilityModelSettings{Provider: "openai"}
+	if _, _, _, ok := utilityModel(settings, claudeSession, GenerateOptions{APIKey: "sk-test"}); ok {
+		t.Error("Expected utility tasks skipped without a key for a different provider")
+	}
+
+	settings.UtilityModel = &types.UtilityModelSettings{Provider: "ollama", Model: "qwen2.5:0.5b"}
+	provider, model, apiKey, ok = utilityModel(settings, claudeSession, GenerateOptions{APIKey: "sk-test"})
+	if !ok || provider != "ollama" || model != "qwen2.5:0.5b" || apiKey != settings.OllamaEndpoint {
+		t.Errorf("Expected local Ollama utility model on configured endpoint, got %s/%s %s ok=%v", provider, model, apiKey, ok)
+	}
+}
+
+func TestCleanTitle(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"PRD Goals Discussion", "PRD Goals Discussion"},
+		{"\"Architecture Review\".", "Architecture Review"},
+		{"Title: Sprint Planning\nExtra explanation", "Sprint Planning"},
+		{"  **Epic Breakdown**  ", "Epic Breakdown"},
+		{strings.Repeat("word ", 30), strings.TrimSpace(strings.Repeat("word ", 16))},
+	}
+	for _, tt := range tests {
+		if got := cleanTitle(tt.raw); got != tt.want {
+			t.Errorf("cleanTitle(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
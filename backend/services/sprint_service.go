@@ -0,0 +1,229 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"bmad-studio/backend/api/websocket"
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SprintServiceError represents a structured error from the sprint service
+type SprintServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *SprintServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for sprint service
+const (
+	ErrCodeSprintConfigNotLoaded  = "config_not_loaded"
+	ErrCodeSprintStatusNotFound   = "sprint_status_not_found"
+	ErrCodeStoryNotInSprint       = "story_not_in_sprint"
+	ErrCodeInvalidStoryStatus     = "invalid_story_status"
+	ErrCodeInvalidStoryTransition = "invalid_story_transition"
+	ErrCodeSprintWriteFailed      = "sprint_write_failed"
+)
+
+// storyTransitions lists the statuses a story may move to from each status: one step
+// forward along the BMAD flow, or one step back, e.g. when review sends a story back.
+var storyTransitions = map[string][]string{
+	types.StoryBacklog:     {types.StoryReadyForDev},
+	types.StoryReadyForDev: {types.StoryBacklog, types.StoryInProgress},
+	types.StoryInProgress:  {types.StoryReadyForDev, types.StoryReview},
+	types.StoryReview:      {types.StoryInProgress, types.StoryDone},
+	types.StoryDone:        {types.StoryReview},
+}
+
+// developmentStatusRegex matches the values a development_status entry may hold
+var developmentStatusRegex = regexp.MustCompile(`^[a-z][a-z_-]*$`)
+
+// SprintService moves stories across the sprint board. sprint-status.yaml is the source of
+// truth: a move rewrites its development_status entry with comments and key order intact,
+// then updates the Status line of the story file so the two agree.
+type SprintService struct {
+	mu                    sync.Mutex // Serialises moves
+	configService         *BMadConfigService
+	artifactService       *ArtifactService
+	workflowStatusService *WorkflowStatusService
+	storyService          *StoryService
+	hub                   *websocket.Hub
+}
+
+// NewSprintService creates a new SprintService.
+// hub may be nil, in which case no events are broadcast.
+func NewSprintService(configService *BMadConfigService, artifactService *ArtifactService, workflowStatusService *WorkflowStatusService, storyService *StoryService, hub *websocket.Hub) *SprintService {
+	return &SprintService{
+		configService:         configService,
+		artifactService:       artifactService,
+		workflowStatusService: workflowStatusService,
+		storyService:          storyService,
+		hub:                   hub,
+	}
+}
+
+// MoveStory sets the development status of the story with key to status and returns the updated story.
+func (s *SprintService) MoveStory(key, status string) (*types.Story, error) {
+	if _, known := storyTransitions[status]; !known {
+		return nil, &SprintServiceError{
+			Code:    ErrCodeInvalidStoryStatus,
+			Message: fmt.Sprintf("Invalid story status %q: must be backlog, ready-for-dev, in-progress, review or done", status),
+		}
+	}
+	if err := s.setStatus(key, status, true); err != nil {
+		return nil, err
+	}
+	return s.storyService.Story(key)
+}
+
+// SetDevelopmentStatus sets any development_status entry, story, epic or retrospective,
+// without the one-step transition rule of MoveStory. It is for changes made elsewhere,
+// such as in an issue tracker, that the board has to follow.
+func (s *SprintService) SetDevelopmentStatus(key, status string) error {
+	if !developmentStatusRegex.MatchString(status) {
+		return &SprintServiceError{
+			Code:    ErrCodeInvalidStoryStatus,
+			Message: fmt.Sprintf("Invalid development status %q", status),
+		}
+	}
+	return s.setStatus(key, status, false)
+}
+
+// setStatus writes development_status[key] and, for stories, the story file's Status line.
+// enforceTransition restricts stories to the moves of storyTransitions.
+func (s *SprintService) setStatus(key, status string, enforceTransition bool) error {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return &SprintServiceError{
+			Code:    ErrCodeSprintConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded",
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statusPath := filepath.Join(config.ImplementationArtifacts, "sprint-status.yaml")
+	original, err := os.ReadFile(statusPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &SprintServiceError{
+				Code:    ErrCodeSprintStatusNotFound,
+				Message: "The project has no sprint-status.yaml; run sprint planning first",
+			}
+		}
+		return &SprintServiceError{Code: ErrCodeSprintWriteFailed, Message: fmt.Sprintf("Failed to read sprint-status.yaml: %v", err)}
+	}
+	updated, from, err := setDevelopmentStatus(original, key, status)
+	if err != nil {
+		return err
+	}
+	if enforceTransition && !isAllowedTransition(from, status) {
+		return &SprintServiceError{
+			Code:    ErrCodeInvalidStoryTransition,
+			Message: fmt.Sprintf("Story %s cannot move from %s to %s; allowed: %s", key, from, status, strings.Join(storyTransitions[from], ", ")),
+		}
+	}
+	if !enforceTransition && from == status {
+		return nil
+	}
+
+	var story *types.Story
+	if _, _, _, isStory := parseStoryKey(key); isStory || enforceTransition {
+		if story, err = s.storyService.Story(key); err != nil {
+			return err
+		}
+	}
+
+	if err := writeFileAtomic(statusPath, updated); err != nil {
+		return &SprintServiceError{Code: ErrCodeSprintWriteFailed, Message: fmt.Sprintf("Failed to write sprint-status.yaml: %v", err)}
+	}
+	if story != nil && story.ArtifactID != nil {
+		if err := s.updateStoryFile(*story.ArtifactID, status); err != nil {
+			// Keep the board and the file consistent: undo the status change
+			if restoreErr := writeFileAtomic(statusPath, original); restoreErr != nil {
+				log.Printf("Warning: Failed to restore sprint-status.yaml: %v", restoreErr)
+			}
+			return err
+		}
+	}
+	if err := s.workflowStatusService.Reload(); err != nil {
+		log.Printf("Warning: Failed to reload sprint status: %v", err)
+	}
+
+	if s.hub != nil && story != nil {
+		s.hub.BroadcastEvent(types.NewSprintStoryMovedEvent(&types.SprintStoryMovedPayload{
+			Key:        key,
+			EpicNum:    story.EpicNum,
+			From:       from,
+			To:         status,
+			ArtifactID: story.ArtifactID,
+		}))
+	}
+	return nil
+}
+
+// updateStoryFile sets the Status line of a story file, guarded by its ETag so a concurrent edit is not overwritten.
+func (s *SprintService) updateStoryFile(id, status string) error {
+	content, etag, err := s.artifactService.GetRawContentWithETag(id)
+	if err != nil {
+		return err
+	}
+	updated := setStoryStatusLine(content, status)
+	if bytes.Equal(updated, content) {
+		return nil
+	}
+	_, err = s.artifactService.WriteContent(id, updated, etag)
+	return err
+}
+
+func isAllowedTransition(from, to string) bool {
+	for _, allowed := range storyTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// setDevelopmentStatus sets development_status[key] in a sprint-status.yaml document and returns
+// the new document and the old status. Only the value changes; see setYAMLScalar.
+func setDevelopmentStatus(content []byte, key, status string) ([]byte, string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, "", &SprintServiceError{
+			Code:    ErrCodeSprintWriteFailed,
+			Message: fmt.Sprintf("Failed to parse sprint-status.yaml: %v", err),
+		}
+	}
+
+	var entry *yaml.Node
+	if len(doc.Content) > 0 {
+		entry = yamlMappingValue(yamlMappingValue(doc.Content[0], "development_status"), key)
+	}
+	if entry == nil || entry.Kind != yaml.ScalarNode {
+		return nil, "", &SprintServiceError{
+			Code:    ErrCodeStoryNotInSprint,
+			Message: fmt.Sprintf("Story %s is not listed in sprint-status.yaml", key),
+		}
+	}
+
+	from := entry.Value
+	updated, err := setYAMLScalar(content, &doc, entry, status)
+	if err != nil {
+		return nil, "", &SprintServiceError{Code: ErrCodeSprintWriteFailed, Message: fmt.Sprintf("Failed to encode sprint-status.yaml: %v", err)}
+	}
+	return updated, from, nil
+}
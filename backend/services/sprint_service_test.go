@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+const sprintBoardFixture = `# generated: 2026-01-27
+# STATUS DEFINITIONS: backlog -> ready-for-dev -> in-progress -> review -> done
+
+project: test-project
+development_status:
+  epic-1: in-progress
+
+  1-1-setup: done
+  1-2-login: ready-for-dev   # picked up next
+  1-3-logout: 'backlog'
+`
+
+// setupSprintTest writes the board fixture and a story file for 1-2-login and returns the service and its folder.
+func setupSprintTest(t *testing.T) (*SprintService, string) {
+	t.Helper()
+	configService, tmpDir := setupArtifactTestConfig(t)
+	implDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	if err := os.MkdirAll(implDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"sprint-status.yaml": sprintBoardFixture,
+		"1-2-login.md":       "# Story 1.2: Login\n\nStatus: ready-for-dev\n\n## Story\n\nAs a user...\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(implDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	statusService := NewWorkflowStatusService(configService, nil)
+	if err := statusService.LoadStatus(); err != nil {
+		t.Fatal(err)
+	}
+	artifactService := NewArtifactService(configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	storyService := NewStoryService(artifactService, statusService)
+	return NewSprintService(configService, artifactService, statusService, storyService, nil), implDir
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestMoveStory_UpdatesSprintStatusAndStoryFile(t *testing.T) {
+	svc, implDir := setupSprintTest(t)
+
+	story, err := svc.MoveStory("1-2-login", types.StoryInProgress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if story.Status != types.StoryInProgress || story.FileStatus != types.StoryInProgress {
+		t.Errorf("expected the story in progress in both places, got %q/%q", story.Status, story.FileStatus)
+	}
+
+	want := strings.Replace(sprintBoardFixture, "1-2-login: ready-for-dev", "1-2-login: in-progress", 1)
+	if got := readTestFile(t, filepath.Join(implDir, "sprint-status.yaml")); got != want {
+		t.Errorf("expected only the entry to change, got:\n%s", got)
+	}
+	if got := readTestFile(t, filepath.Join(implDir, "1-2-login.md")); got != "# Story 1.2: Login\n\nStatus: in-progress\n\n## Story\n\nAs a user...\n" {
+		t.Errorf("unexpected story file:\n%s", got)
+	}
+}
+
+func TestMoveStory_StoryWithoutFileKeepsQuoting(t *testing.T) {
+	svc, implDir := setupSprintTest(t)
+
+	if _, err := svc.MoveStory("1-3-logout", types.StoryReadyForDev); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(implDir, "sprint-status.yaml")); !strings.Contains(got, "1-3-logout: 'ready-for-dev'\n") {
+		t.Errorf("expected the quoted value replaced, got:\n%s", got)
+	}
+}
+
+func TestMoveStory_RejectsInvalidMoves(t *testing.T) {
+	svc, implDir := setupSprintTest(t)
+
+	tests := []struct {
+		name   string
+		key    string
+		status string
+		code   string
+	}{
+		{"skips a step", "1-2-login", types.StoryDone, ErrCodeInvalidStoryTransition},
+		{"same status", "1-1-setup", types.StoryDone, ErrCodeInvalidStoryTransition},
+		{"unknown status", "1-2-login", "blocked", ErrCodeInvalidStoryStatus},
+		{"not on the board", "9-9-missing", types.StoryReview, ErrCodeStoryNotInSprint},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.MoveStory(tt.key, tt.status)
+			svcErr, ok := err.(*SprintServiceError)
+			if !ok || svcErr.Code != tt.code {
+				t.Errorf("expected %s, got %v", tt.code, err)
+			}
+		})
+	}
+
+	if got := readTestFile(t, filepath.Join(implDir, "sprint-status.yaml")); got != sprintBoardFixture {
+		t.Errorf("expected sprint-status.yaml unchanged, got:\n%s", got)
+	}
+}
+
+func TestMoveStory_WithoutSprintStatus(t *testing.T) {
+	svc, implDir := setupSprintTest(t)
+	if err := os.Remove(filepath.Join(implDir, "sprint-status.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := svc.MoveStory("1-2-login", types.StoryInProgress)
+	if svcErr, ok := err.(*SprintServiceError); !ok || svcErr.Code != ErrCodeSprintStatusNotFound {
+		t.Errorf("expected %s, got %v", ErrCodeSprintStatusNotFound, err)
+	}
+}
+
+func TestSetDevelopmentStatus_FallsBackToEncoding(t *testing.T) {
+	// A tagged value cannot be replaced in place, so the node tree is re-encoded
+	content := "# Board\ndevelopment_status:\n  1-1-setup: !!str review # almost\n"
+	out, from, err := setDevelopmentStatus([]byte(content), "1-1-setup", types.StoryDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != types.StoryReview {
+		t.Errorf("expected the old status review, got %q", from)
+	}
+	if got := string(out); got != "# Board\ndevelopment_status:\n  1-1-setup: done # almost\n" {
+		t.Errorf("unexpected document:\n%s", got)
+	}
+}
+
+func TestSprintSetDevelopmentStatus_SkipsTransitionRule(t *testing.T) {
+	svc, implDir := setupSprintTest(t)
+
+	if err := svc.SetDevelopmentStatus("1-2-login", types.StoryDone); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetDevelopmentStatus("epic-1", "done"); err != nil {
+		t.Fatal(err)
+	}
+	got := readTestFile(t, filepath.Join(implDir, "sprint-status.yaml"))
+	if !strings.Contains(got, "1-2-login: done   # picked up next") || !strings.Contains(got, "epic-1: done") {
+		t.Errorf("expected both entries set, got:\n%s", got)
+	}
+	if got := readTestFile(t, filepath.Join(implDir, "1-2-login.md")); !strings.Contains(got, "Status: done") {
+		t.Errorf("expected the story file to follow, got:\n%s", got)
+	}
+
+	var svcErr *SprintServiceError
+	if err := svc.SetDevelopmentStatus("1-3-logout", "Not Valid"); !errors.As(err, &svcErr) || svcErr.Code != ErrCodeInvalidStoryStatus {
+		t.Errorf("expected invalid_story_status, got %v", err)
+	}
+	if err := svc.SetDevelopmentStatus("9-9-missing", types.StoryDone); !errors.As(err, &svcErr) || svcErr.Code != ErrCodeStoryNotInSprint {
+		t.Errorf("expected story_not_in_sprint, got %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"bmad-studio/backend/types"
+)
+
+var (
+	// storyKeyRegex splits a story key such as "1-2-user-login" into epic number, story number and slug
+	storyKeyRegex = regexp.MustCompile(`^(\d+)-(\d+)-(.+)$`)
+
+	// epicKeyRegex and retrospectiveKeyRegex match the epic entries of development_status
+	epicKeyRegex          = regexp.MustCompile(`^epic-(\d+)$`)
+	retrospectiveKeyRegex = regexp.MustCompile(`^epic-(\d+)-retrospective$`)
+
+	// statusLineRegex matches a "Status: value" line, also written as "**Status:** value"
+	statusLineRegex = regexp.MustCompile(`(?im)^[ \t]*(?:\*\*)?status(?::\*\*|\*\*:|:)[ \t]*([a-z][a-z_-]*)`)
+
+	// storyHeadingPrefixRegex matches the "Story 1.2:" prefix of a story's title heading
+	storyHeadingPrefixRegex = regexp.MustCompile(`(?i)^story\s+\d+[.\-]\d+\s*[:.\-–—]?\s*`)
+
+	// epicHeadingRegex matches an "Epic 1: Title" heading in the epics document
+	epicHeadingRegex = regexp.MustCompile(`(?i)^epic\s+(\d+)\s*[:.\-–—]\s*(.+)$`)
+
+	checkboxRegex = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s*(.*)$`)
+	listItemRegex = regexp.MustCompile(`^ ?(?:\d+[.)]|[-*+])\s+(.*)$`)
+)
+
+// storyStatusLine returns the lowercased value of the first "Status:" line of a markdown body, or "".
+func storyStatusLine(body []byte) string {
+	m := statusLineRegex.FindSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	return strings.ToLower(string(m[1]))
+}
+
+// parseStoryKey returns the epic and story numbers of a story key.
+func parseStoryKey(key string) (epicNum, storyNum int, slug string, ok bool) {
+	m := storyKeyRegex.FindStringSubmatch(key)
+	if m == nil {
+		return 0, 0, "", false
+	}
+	epicNum, _ = strconv.Atoi(m[1])
+	storyNum, _ = strconv.Atoi(m[2])
+	return epicNum, storyNum, m[3], true
+}
+
+// newStory returns a story with only the fields derivable from its key.
+func newStory(key string) (*types.Story, bool) {
+	epicNum, storyNum, slug, ok := parseStoryKey(key)
+	if !ok {
+		return nil, false
+	}
+	return &types.Story{
+		Key:                key,
+		EpicNum:            epicNum,
+		StoryNum:           storyNum,
+		Title:              toTitleCase(strings.ReplaceAll(slug, "-", " ")),
+		AcceptanceCriteria: []string{},
+		Tasks:              []types.StoryTask{},
+	}, true
+}
+
+// parseStory parses a story file. The title comes from the first H1 with its "Story N.M:"
+// prefix removed; the Story, Acceptance Criteria, Tasks / Subtasks and Dev Notes sections
+// are read from their headings at any level.
+func parseStory(key string, content []byte) (*types.Story, bool) {
+	story, ok := newStory(key)
+	if !ok {
+		return nil, false
+	}
+
+	_, body := splitFrontmatter(content)
+	story.FileStatus = storyStatusLine(body)
+
+	sections := splitMarkdownSections(string(body))
+	var haveTitle, haveNarrative, haveCriteria, haveTasks, haveNotes bool
+	for i, sec := range sections {
+		heading := normalizeHeading(sec.Heading)
+		switch {
+		case sec.Level == 1 && !haveTitle:
+			haveTitle = true
+			if title := strings.TrimSpace(storyHeadingPrefixRegex.ReplaceAllString(sec.Heading, "")); title != "" {
+				story.Title = title
+			}
+		case (heading == "story" || heading == "user story") && !haveNarrative:
+			haveNarrative = true
+			story.Narrative = strings.TrimSpace(sectionWithSubsections(sections, i))
+		case strings.HasPrefix(heading, "acceptance criteria") && !haveCriteria:
+			haveCriteria = true
+			story.AcceptanceCriteria = parseAcceptanceCriteria(sectionWithSubsections(sections, i))
+		case strings.HasPrefix(heading, "tasks") && !haveTasks:
+			haveTasks = true
+			story.Tasks = parseStoryTasks(sectionWithSubsections(sections, i))
+		case heading == "dev notes" && !haveNotes:
+			haveNotes = true
+			story.DevNotes = strings.TrimSpace(sectionWithSubsections(sections, i))
+		}
+	}
+
+	for _, task := range story.Tasks {
+		story.TasksTotal++
+		if task.Done {
+			story.TasksCompleted++
+		}
+		for _, sub := range task.Subtasks {
+			story.SubtasksTotal++
+			if sub.Done {
+				story.SubtasksCompleted++
+			}
+		}
+	}
+	return story, true
+}
+
+// sectionWithSubsections returns the content of sections[i] followed by its subsections, heading lines included.
+func sectionWithSubsections(sections []markdownSection, i int) string {
+	parts := []string{sections[i].Content}
+	for _, sub := range sections[i+1:] {
+		if sub.Level <= sections[i].Level {
+			break
+		}
+		parts = append(parts, strings.Repeat("#", sub.Level)+" "+sub.Heading, sub.Content)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// parseAcceptanceCriteria returns the top-level list items of text, joining their continuation
+// lines. Criteria written as plain paragraphs are returned one per paragraph.
+func parseAcceptanceCriteria(text string) []string {
+	criteria := []string{}
+	var current []string
+	inList := false
+
+	flush := func() {
+		if len(current) > 0 {
+			criteria = append(criteria, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if m := listItemRegex.FindStringSubmatch(line); m != nil {
+			flush()
+			inList = true
+			current = append(current, strings.TrimSpace(m[1]))
+			continue
+		}
+		if trimmed == "" {
+			if !inList {
+				flush()
+			}
+			continue
+		}
+		current = append(current, trimmed)
+	}
+	flush()
+	return criteria
+}
+
+// parseStoryTasks parses the checkboxes of a Tasks / Subtasks section. Checkboxes at the
+// indentation of the first one are tasks; deeper ones are subtasks of the task above them.
+func parseStoryTasks(text string) []types.StoryTask {
+	tasks := []types.StoryTask{}
+	baseIndent := -1
+
+	for _, line := range strings.Split(text, "\n") {
+		m := checkboxRegex.FindStringSubmatch(strings.ReplaceAll(line, "\t", "    "))
+		if m == nil {
+			continue
+		}
+		indent := len(m[1])
+		item := types.StoryTask{Text: strings.TrimSpace(m[3]), Done: m[2] != " "}
+
+		if baseIndent == -1 {
+			baseIndent = indent
+		}
+		if indent > baseIndent && len(tasks) > 0 {
+			last := &tasks[len(tasks)-1]
+			last.Subtasks = append(last.Subtasks, item)
+			continue
+		}
+		tasks = append(tasks, item)
+	}
+	return tasks
+}
+
+// parseEpicTitles returns the titles of "Epic N: Title" headings in an epics document by epic number.
+func parseEpicTitles(content []byte) map[int]string {
+	_, body := splitFrontmatter(content)
+	titles := make(map[int]string)
+	for _, sec := range splitMarkdownSections(string(body)) {
+		m := epicHeadingRegex.FindStringSubmatch(sec.Heading)
+		if m == nil {
+			continue
+		}
+		num, _ := strconv.Atoi(m[1])
+		if _, seen := titles[num]; !seen {
+			titles[num] = strings.TrimSpace(m[2])
+		}
+	}
+	return titles
+}
+
+// setStoryStatusLine replaces the value of the first "Status:" line of a story file, keeping
+// its formatting. Files without one get a "Status:" line after their title heading.
+func setStoryStatusLine(content []byte, status string) []byte {
+	frontmatter := len(content)
+	_, body := splitFrontmatter(content)
+	frontmatter -= len(body)
+
+	if loc := statusLineRegex.FindSubmatchIndex(body); loc != nil {
+		start, end := frontmatter+loc[2], frontmatter+loc[3]
+		return append(append(append([]byte{}, content[:start]...), status...), content[end:]...)
+	}
+
+	newline := "\n"
+	if bytes.Contains(content, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+	insertAt := frontmatter
+	line := "Status: " + status + newline + newline
+	if loc := h1Regex.FindIndex(body); loc != nil {
+		insertAt = frontmatter + loc[1]
+		if rest := content[insertAt:]; bytes.HasPrefix(rest, []byte("\r\n")) {
+			insertAt += 2
+		} else if bytes.HasPrefix(rest, []byte("\n")) {
+			insertAt++
+		}
+		line = newline + "Status: " + status + newline
+	}
+	return append(append(append([]byte{}, content[:insertAt]...), line...), content[insertAt:]...)
+}
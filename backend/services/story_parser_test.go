@@ -0,0 +1,183 @@
+package services
+
+import (
+	"testing"
+)
+
+const sampleStory = `---
+story_id: "1.2"
+---
+# Story 1.2: Account Management
+
+**Status:** review
+
+## Story
+
+As a user,
+I want to manage my account,
+so that my details stay current.
+
+## Acceptance Criteria
+
+1. **Given** a signed-in user
+   **When** they open settings
+   **Then** their profile is shown
+2. Email changes require confirmation
+
+## Tasks / Subtasks
+
+- [x] Build the settings page (AC: #1)
+  - [x] Layout
+  - [ ] Validation
+- [ ] Send confirmation email (AC: #2)
+	- [x] Template
+
+## Dev Notes
+
+Use the existing form components.
+
+### References
+
+- architecture.md#forms
+
+## Dev Agent Record
+`
+
+func TestParseStory(t *testing.T) {
+	story, ok := parseStory("1-2-account-management", []byte(sampleStory))
+	if !ok {
+		t.Fatal("expected the key to parse")
+	}
+
+	if story.EpicNum != 1 || story.StoryNum != 2 {
+		t.Errorf("expected epic 1 story 2, got %d.%d", story.EpicNum, story.StoryNum)
+	}
+	if story.Title != "Account Management" {
+		t.Errorf("expected title %q, got %q", "Account Management", story.Title)
+	}
+	if story.FileStatus != "review" {
+		t.Errorf("expected file status review, got %q", story.FileStatus)
+	}
+	if story.Narrative != "As a user,\nI want to manage my account,\nso that my details stay current." {
+		t.Errorf("unexpected narrative %q", story.Narrative)
+	}
+
+	wantCriteria := []string{
+		"**Given** a signed-in user **When** they open settings **Then** their profile is shown",
+		"Email changes require confirmation",
+	}
+	if len(story.AcceptanceCriteria) != len(wantCriteria) {
+		t.Fatalf("expected %d criteria, got %q", len(wantCriteria), story.AcceptanceCriteria)
+	}
+	for i, want := range wantCriteria {
+		if story.AcceptanceCriteria[i] != want {
+			t.Errorf("criterion %d: expected %q, got %q", i, want, story.AcceptanceCriteria[i])
+		}
+	}
+
+	if len(story.Tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %+v", story.Tasks)
+	}
+	if story.Tasks[0].Text != "Build the settings page (AC: #1)" || !story.Tasks[0].Done {
+		t.Errorf("unexpected first task %+v", story.Tasks[0])
+	}
+	if len(story.Tasks[1].Subtasks) != 1 || !story.Tasks[1].Subtasks[0].Done {
+		t.Errorf("expected the tab-indented subtask under the second task, got %+v", story.Tasks[1])
+	}
+	if story.TasksTotal != 2 || story.TasksCompleted != 1 {
+		t.Errorf("expected 1/2 tasks, got %d/%d", story.TasksCompleted, story.TasksTotal)
+	}
+	if story.SubtasksTotal != 3 || story.SubtasksCompleted != 2 {
+		t.Errorf("expected 2/3 subtasks, got %d/%d", story.SubtasksCompleted, story.SubtasksTotal)
+	}
+
+	if story.DevNotes != "Use the existing form components.\n\n### References\n\n- architecture.md#forms" {
+		t.Errorf("expected dev notes with their subsections, got %q", story.DevNotes)
+	}
+}
+
+func TestParseStory_WithoutSections(t *testing.T) {
+	story, ok := parseStory("2-10-data-export", []byte("Nothing here yet\n"))
+	if !ok {
+		t.Fatal("expected the key to parse")
+	}
+	if story.Title != "Data Export" {
+		t.Errorf("expected the title from the key, got %q", story.Title)
+	}
+	if story.StoryNum != 10 {
+		t.Errorf("expected story 10, got %d", story.StoryNum)
+	}
+	if story.AcceptanceCriteria == nil || story.Tasks == nil {
+		t.Error("expected empty, non-nil criteria and tasks")
+	}
+
+	if _, ok := parseStory("epic-2", nil); ok {
+		t.Error("expected a non-story key to be rejected")
+	}
+}
+
+func TestStoryStatusLine(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"Status: done\n", "done"},
+		{"# Story\n\nStatus: Ready-For-Dev\n", "ready-for-dev"},
+		{"**Status:** in-progress\n", "in-progress"},
+		{"The Status: done note is inline\n", ""},
+		{"No status\n", ""},
+	}
+	for _, tt := range tests {
+		if got := storyStatusLine([]byte(tt.body)); got != tt.want {
+			t.Errorf("storyStatusLine(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestParseEpicTitles(t *testing.T) {
+	content := "# Epics\n\n## Epic 1: Foundation\n\n### Story 1.1: Setup\n\n## Epic 2 - Accounts\n\n## Epic 1: Duplicate\n"
+	titles := parseEpicTitles([]byte(content))
+
+	if len(titles) != 2 {
+		t.Fatalf("expected 2 epics, got %v", titles)
+	}
+	if titles[1] != "Foundation" || titles[2] != "Accounts" {
+		t.Errorf("unexpected titles %v", titles)
+	}
+}
+
+func TestSetStoryStatusLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "replaces the value",
+			content: "# Story 1.1: Setup\n\nStatus: backlog\n",
+			want:    "# Story 1.1: Setup\n\nStatus: review\n",
+		},
+		{
+			name:    "keeps bold formatting",
+			content: "---\nstatus: draft\n---\n# Setup\n\n**Status:** in-progress\n",
+			want:    "---\nstatus: draft\n---\n# Setup\n\n**Status:** review\n",
+		},
+		{
+			name:    "adds a line after the title",
+			content: "# Story 1.1: Setup\n\n## Story\n",
+			want:    "# Story 1.1: Setup\n\nStatus: review\n\n## Story\n",
+		},
+		{
+			name:    "adds a line without a title",
+			content: "Some notes\n",
+			want:    "Status: review\n\nSome notes\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(setStoryStatusLine([]byte(tt.content), "review")); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"bmad-studio/backend/types"
+)
+
+// StoryServiceError represents a structured error from the story service
+type StoryServiceError struct {
+	Code    string
+	Message string
+}
+
+func (e *StoryServiceError) Error() string {
+	return e.Message
+}
+
+// Error codes for story service
+const (
+	ErrCodeStoryNotFound = "story_not_found"
+)
+
+// StoryService builds the epic and story model from story files and the epics document,
+// joined with the development_status of sprint-status.yaml. Files are parsed on each call,
+// so the model always reflects the artifacts on disk.
+type StoryService struct {
+	artifactService       *ArtifactService
+	workflowStatusService *WorkflowStatusService
+}
+
+// NewStoryService creates a new StoryService. workflowStatusService may be nil, in which
+// case stories carry the status of their files only.
+func NewStoryService(artifactService *ArtifactService, workflowStatusService *WorkflowStatusService) *StoryService {
+	return &StoryService{
+		artifactService:       artifactService,
+		workflowStatusService: workflowStatusService,
+	}
+}
+
+// Stories returns every story, ordered by epic and story number.
+func (s *StoryService) Stories() ([]types.Story, error) {
+	stories, _, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return stories, nil
+}
+
+// Story returns the story with the given key.
+func (s *StoryService) Story(key string) (*types.Story, error) {
+	stories, err := s.Stories()
+	if err != nil {
+		return nil, err
+	}
+	for i := range stories {
+		if stories[i].Key == key {
+			return &stories[i], nil
+		}
+	}
+	return nil, &StoryServiceError{
+		Code:    ErrCodeStoryNotFound,
+		Message: fmt.Sprintf("Story not found: %s", key),
+	}
+}
+
+// Epics returns every epic named by the epics document, development_status or a story, ordered by number.
+func (s *StoryService) Epics() ([]types.Epic, error) {
+	stories, titles, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	devStatus := s.developmentStatus()
+
+	epics := make(map[int]*types.Epic)
+	epic := func(num int) *types.Epic {
+		if e, ok := epics[num]; ok {
+			return e
+		}
+		e := &types.Epic{
+			Key:       "epic-" + strconv.Itoa(num),
+			Number:    num,
+			Title:     titles[num],
+			StoryKeys: []string{},
+		}
+		epics[num] = e
+		return e
+	}
+
+	for num := range titles {
+		epic(num)
+	}
+	for key, value := range devStatus {
+		if m := epicKeyRegex.FindStringSubmatch(key); m != nil {
+			num, _ := strconv.Atoi(m[1])
+			epic(num).Status = value
+		} else if m := retrospectiveKeyRegex.FindStringSubmatch(key); m != nil {
+			num, _ := strconv.Atoi(m[1])
+			epic(num).RetrospectiveStatus = value
+		}
+	}
+	for _, story := range stories {
+		e := epic(story.EpicNum)
+		e.StoryKeys = append(e.StoryKeys, story.Key)
+		e.StoriesTotal++
+		if story.Status == types.StoryDone {
+			e.StoriesDone++
+		}
+	}
+
+	result := make([]types.Epic, 0, len(epics))
+	for _, e := range epics {
+		if e.Status == "" {
+			e.Status = derivedEpicStatus(e, stories)
+		}
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Number < result[j].Number })
+	return result, nil
+}
+
+// derivedEpicStatus infers the status of an epic development_status does not list:
+// done once all its stories are, in progress once any has started, backlog otherwise.
+func derivedEpicStatus(epic *types.Epic, stories []types.Story) string {
+	if epic.StoriesTotal > 0 && epic.StoriesDone == epic.StoriesTotal {
+		return types.StoryDone
+	}
+	for _, story := range stories {
+		if story.EpicNum == epic.Number && story.Status != "" && story.Status != types.StoryBacklog && story.Status != types.StoryReadyForDev {
+			return types.StoryInProgress
+		}
+	}
+	return types.StoryBacklog
+}
+
+// load parses the story files and epics documents and joins the stories with development_status.
+// It returns the stories in order and the epic titles by number.
+func (s *StoryService) load() ([]types.Story, map[int]string, error) {
+	artifacts, err := s.artifactService.GetArtifacts()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byKey := make(map[string]*types.Story)
+	titles := make(map[int]string)
+	for i := range artifacts {
+		artifact := &artifacts[i]
+		if artifact.Type != types.ArtifactTypeStories && artifact.Type != types.ArtifactTypeEpics {
+			continue
+		}
+		content, err := s.artifactService.ReadContent(artifact)
+		if err != nil {
+			log.Printf("Warning: Failed to read %s for stories: %v", artifact.ID, err)
+			continue
+		}
+
+		if artifact.Type == types.ArtifactTypeEpics {
+			for num, title := range parseEpicTitles(content) {
+				if _, seen := titles[num]; !seen {
+					titles[num] = title
+				}
+			}
+			continue
+		}
+
+		key := strings.TrimSuffix(path.Base(artifact.Path), ".md")
+		story, ok := parseStory(key, content)
+		if !ok {
+			continue
+		}
+		id := artifact.ID
+		story.ArtifactID = &id
+		story.Path = artifact.Path
+		story.Status = story.FileStatus
+		if story.Status == "" {
+			story.Status = types.StoryBacklog
+		}
+		byKey[key] = story
+	}
+
+	for key, value := range s.developmentStatus() {
+		story, ok := byKey[key]
+		if !ok {
+			if story, ok = newStory(key); !ok {
+				continue // Epic and retrospective entries
+			}
+			byKey[key] = story
+		}
+		story.Status = value
+	}
+
+	stories := make([]types.Story, 0, len(byKey))
+	for _, story := range byKey {
+		stories = append(stories, *story)
+	}
+	sort.Slice(stories, func(i, j int) bool {
+		if stories[i].EpicNum != stories[j].EpicNum {
+			return stories[i].EpicNum < stories[j].EpicNum
+		}
+		if stories[i].StoryNum != stories[j].StoryNum {
+			return stories[i].StoryNum < stories[j].StoryNum
+		}
+		return stories[i].Key < stories[j].Key
+	})
+	return stories, titles, nil
+}
+
+// developmentStatus returns the development_status of sprint-status.yaml, or nil without one.
+func (s *StoryService) developmentStatus() map[string]string {
+	if s.workflowStatusService == nil {
+		return nil
+	}
+	if status := s.workflowStatusService.GetSprintStatus(); status != nil {
+		return status.DevelopmentStatus
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+// setupStoryTest loads the given files under the implementation artifacts folder and
+// returns a StoryService joined with the sprint-status.yaml among them.
+func setupStoryTest(t *testing.T, files map[string]string) *StoryService {
+	t.Helper()
+	configService, tmpDir := setupArtifactTestConfig(t)
+	implDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	if err := os.MkdirAll(implDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(implDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	statusService := NewWorkflowStatusService(configService, nil)
+	if err := statusService.LoadStatus(); err != nil {
+		t.Fatal(err)
+	}
+	artifactService := NewArtifactService(configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	return NewStoryService(artifactService, statusService)
+}
+
+const sprintStatusFixture = `project: test-project
+development_status:
+  epic-1: in-progress
+  1-1-project-setup: done
+  1-2-account-management: review
+  epic-1-retrospective: optional
+  epic-2: backlog
+  2-1-data-export: backlog
+`
+
+func TestStoryService_JoinsSprintStatus(t *testing.T) {
+	svc := setupStoryTest(t, map[string]string{
+		"sprint-status.yaml":        sprintStatusFixture,
+		"1-1-project-setup.md":      "# Story 1.1: Project Setup\n\nStatus: in-progress\n",
+		"1-2-account-management.md": "# Story 1.2: Account Management\n\nStatus: review\n",
+		"epics.md":                  "# Epics\n\n## Epic 1: Foundation\n\n## Epic 2: Data\n\n## Epic 3: Reporting\n",
+	})
+
+	stories, err := svc.Stories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := make([]string, len(stories))
+	for i, s := range stories {
+		keys[i] = s.Key
+	}
+	want := []string{"1-1-project-setup", "1-2-account-management", "2-1-data-export"}
+	if len(keys) != len(want) {
+		t.Fatalf("expected stories %v, got %v", want, keys)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("expected stories %v, got %v", want, keys)
+		}
+	}
+
+	setup := stories[0]
+	if setup.Status != types.StoryDone || setup.FileStatus != types.StoryInProgress {
+		t.Errorf("expected sprint status done over file status in-progress, got %q/%q", setup.Status, setup.FileStatus)
+	}
+	if setup.ArtifactID == nil || setup.Path == "" {
+		t.Error("expected the story file's artifact ID and path")
+	}
+	if export := stories[2]; export.ArtifactID != nil || export.Title != "Data Export" || export.Status != types.StoryBacklog {
+		t.Errorf("expected a file-less backlog story titled from its key, got %+v", export)
+	}
+
+	if _, err := svc.Story("9-9-missing"); err == nil {
+		t.Error("expected an error for an unknown story")
+	} else if svcErr, ok := err.(*StoryServiceError); !ok || svcErr.Code != ErrCodeStoryNotFound {
+		t.Errorf("expected %s, got %v", ErrCodeStoryNotFound, err)
+	}
+}
+
+func TestStoryService_Epics(t *testing.T) {
+	svc := setupStoryTest(t, map[string]string{
+		"sprint-status.yaml":        sprintStatusFixture,
+		"1-1-project-setup.md":      "# Story 1.1: Project Setup\n",
+		"1-2-account-management.md": "# Story 1.2: Account Management\n",
+		"epics.md":                  "# Epics\n\n## Epic 1: Foundation\n\n## Epic 2: Data\n\n## Epic 3: Reporting\n",
+	})
+
+	epics, err := svc.Epics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(epics) != 3 {
+		t.Fatalf("expected 3 epics, got %+v", epics)
+	}
+
+	first := epics[0]
+	if first.Key != "epic-1" || first.Title != "Foundation" || first.Status != types.StoryInProgress {
+		t.Errorf("unexpected epic 1: %+v", first)
+	}
+	if first.RetrospectiveStatus != "optional" {
+		t.Errorf("expected retrospective status optional, got %q", first.RetrospectiveStatus)
+	}
+	if first.StoriesTotal != 2 || first.StoriesDone != 1 {
+		t.Errorf("expected 1/2 stories done, got %d/%d", first.StoriesDone, first.StoriesTotal)
+	}
+
+	// Epic 3 is only in the epics document, so its status is derived
+	if third := epics[2]; third.Title != "Reporting" || third.Status != types.StoryBacklog || len(third.StoryKeys) != 0 {
+		t.Errorf("unexpected epic 3: %+v", third)
+	}
+}
+
+func TestStoryService_WithoutSprintStatus(t *testing.T) {
+	svc := setupStoryTest(t, map[string]string{
+		"3-1-reports.md": "# Story 3.1: Reports\n\nStatus: done\n",
+	})
+
+	stories, err := svc.Stories()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stories) != 1 || stories[0].Status != types.StoryDone {
+		t.Fatalf("expected the file status, got %+v", stories)
+	}
+
+	epics, err := svc.Epics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(epics) != 1 || epics[0].Status != types.StoryDone {
+		t.Errorf("expected epic 3 derived as done, got %+v", epics)
+	}
+}
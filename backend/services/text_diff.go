@@ -0,0 +1,294 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"bmad-studio/backend/types"
+)
+
+// diffContextLines is the number of unchanged lines shown around each hunk of a unified diff
+const diffContextLines = 3
+
+// maxDiffEdits bounds the edit distance searched by Myers' algorithm. Inputs that differ by more
+// are diffed as a single replacement, which keeps memory bounded for complete rewrites.
+const maxDiffEdits = 1000
+
+// diffEdit is one step of an edit script turning a into b.
+type diffEdit struct {
+	op   byte // '=' unchanged, '-' deleted from a, '+' inserted from b
+	text string
+}
+
+// diffTokens returns a shortest edit script between two token sequences.
+// The common prefix and suffix are matched directly; the middle uses Myers' O(ND) algorithm.
+func diffTokens(a, b []string) []diffEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]diffEdit, 0, len(a)+len(b)-prefix-suffix)
+	for _, t := range a[:prefix] {
+		edits = append(edits, diffEdit{op: '=', text: t})
+	}
+	edits = append(edits, myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, t := range a[len(a)-suffix:] {
+		edits = append(edits, diffEdit{op: '=', text: t})
+	}
+	return edits
+}
+
+// myersDiff implements Myers' greedy diff, keeping the frontier of every round for backtracking.
+func myersDiff(a, b []string) []diffEdit {
+	n, m := len(a), len(b)
+	if n+m == 0 {
+		return nil
+	}
+	limit := n + m
+	if limit > maxDiffEdits {
+		limit = maxDiffEdits
+	}
+
+	offset := n + m
+	v := make([]int, 2*(n+m)+2)
+	var trace [][]int // trace[d] holds v[-d..d] as it was when round d started
+	for d := 0; d <= limit; d++ {
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // Move down: insertion
+			} else {
+				x = v[offset+k-1] + 1 // Move right: deletion
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackMyers(trace, a, b, d)
+			}
+		}
+	}
+
+	edits := make([]diffEdit, 0, n+m)
+	for _, t := range a {
+		edits = append(edits, diffEdit{op: '-', text: t})
+	}
+	for _, t := range b {
+		edits = append(edits, diffEdit{op: '+', text: t})
+	}
+	return edits
+}
+
+// backtrackMyers walks the saved frontiers from (len(a), len(b)) back to the origin.
+func backtrackMyers(trace [][]int, a, b []string, depth int) []diffEdit {
+	x, y := len(a), len(b)
+	var reversed []diffEdit
+
+	for d := depth; d > 0; d-- {
+		prev := trace[d]
+		at := func(k int) int { return prev[k+d] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, diffEdit{op: '=', text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			reversed = append(reversed, diffEdit{op: '+', text: b[prevY]})
+		} else {
+			reversed = append(reversed, diffEdit{op: '-', text: a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, diffEdit{op: '=', text: a[x-1]})
+		x--
+		y--
+	}
+
+	edits := make([]diffEdit, len(reversed))
+	for i, e := range reversed {
+		edits[len(reversed)-1-i] = e
+	}
+	return edits
+}
+
+// splitLinesKeepEnds splits text into lines, each keeping its trailing newline.
+func splitLinesKeepEnds(text string) []string {
+	var lines []string
+	for len(text) > 0 {
+		i := strings.IndexByte(text, '\n')
+		if i == -1 {
+			lines = append(lines, text)
+			break
+		}
+		lines = append(lines, text[:i+1])
+		text = text[i+1:]
+	}
+	return lines
+}
+
+// unifiedDiff renders a line diff between a and b in unified format with diffContextLines of context.
+// It returns an empty string when the texts are identical.
+func unifiedDiff(fromLabel, toLabel, a, b string) (diff string, additions, deletions int) {
+	edits := diffTokens(splitLinesKeepEnds(a), splitLinesKeepEnds(b))
+
+	// Line positions in a and b before each edit is applied
+	aPos := make([]int, len(edits)+1)
+	bPos := make([]int, len(edits)+1)
+	var changes []int
+	for i, e := range edits {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if e.op != '+' {
+			aPos[i+1]++
+		}
+		if e.op != '-' {
+			bPos[i+1]++
+		}
+		switch e.op {
+		case '+':
+			additions++
+			changes = append(changes, i)
+		case '-':
+			deletions++
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return "", 0, 0
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromLabel, toLabel)
+
+	for c := 0; c < len(changes); {
+		// Extend the hunk while the gap to the next change fits inside both contexts
+		last := c
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*diffContextLines+1 {
+			last++
+		}
+		start := changes[c] - diffContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := changes[last] + diffContextLines + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		aCount, bCount := aPos[end]-aPos[start], bPos[end]-bPos[start]
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aPos[start], aCount), hunkRange(bPos[start], bCount))
+		for _, e := range edits[start:end] {
+			prefix := " "
+			if e.op != '=' {
+				prefix = string(e.op)
+			}
+			out.WriteString(prefix + strings.TrimSuffix(e.text, "\n") + "\n")
+			if !strings.HasSuffix(e.text, "\n") {
+				out.WriteString("\\ No newline at end of file\n")
+			}
+		}
+		c = last + 1
+	}
+
+	return out.String(), additions, deletions
+}
+
+// hunkRange formats the start,count pair of a hunk header; empty ranges point at the line before.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// wordDiff diffs a and b line by line, then re-diffs each changed block by words,
+// so edits within a paragraph show up as a few changed words rather than whole lines.
+func wordDiff(a, b string) []types.DiffSegment {
+	var segments []types.DiffSegment
+	add := func(op, text string) {
+		if text == "" {
+			return
+		}
+		if n := len(segments); n > 0 && segments[n-1].Op == op {
+			segments[n-1].Text += text
+			return
+		}
+		segments = append(segments, types.DiffSegment{Op: op, Text: text})
+	}
+
+	edits := diffTokens(splitLinesKeepEnds(a), splitLinesKeepEnds(b))
+	for i := 0; i < len(edits); {
+		if edits[i].op == '=' {
+			add(types.DiffOpEqual, edits[i].text)
+			i++
+			continue
+		}
+
+		var deleted, inserted strings.Builder
+		for ; i < len(edits) && edits[i].op != '='; i++ {
+			if edits[i].op == '-' {
+				deleted.WriteString(edits[i].text)
+			} else {
+				inserted.WriteString(edits[i].text)
+			}
+		}
+		for _, e := range diffTokens(wordTokens(deleted.String()), wordTokens(inserted.String())) {
+			switch e.op {
+			case '=':
+				add(types.DiffOpEqual, e.text)
+			case '-':
+				add(types.DiffOpDelete, e.text)
+			case '+':
+				add(types.DiffOpInsert, e.text)
+			}
+		}
+	}
+	return segments
+}
+
+// wordTokens splits text into runs of letters and digits, runs of whitespace,
+// and single punctuation characters. Concatenating the tokens gives back text.
+func wordTokens(text string) []string {
+	var tokens []string
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]):
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+func TestUnifiedDiff_Hunks(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+	b := "one\ntwo\nTHREE\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\n"
+
+	diff, additions, deletions := unifiedDiff("old", "new", a, b)
+	want := `--- old
++++ new
+@@ -1,6 +1,6 @@
+ one
+ two
+-three
++THREE
+ four
+ five
+ six
+@@ -8,3 +8,4 @@
+ eight
+ nine
+ ten
++eleven
+`
+	if diff != want {
+		t.Errorf("unifiedDiff =\n%s\nwant\n%s", diff, want)
+	}
+	if additions != 2 || deletions != 1 {
+		t.Errorf("additions/deletions = %d/%d, want 2/1", additions, deletions)
+	}
+}
+
+func TestUnifiedDiff_IdenticalAndEmpty(t *testing.T) {
+	if diff, _, _ := unifiedDiff("a", "b", "same\n", "same\n"); diff != "" {
+		t.Errorf("Expected no diff for identical text, got %q", diff)
+	}
+	diff, _, _ := unifiedDiff("a", "b", "", "x")
+	if !strings.Contains(diff, "@@ -0,0 +1,1 @@\n+x\n\\ No newline at end of file\n") {
+		t.Errorf("Unexpected diff from empty text:\n%s", diff)
+	}
+}
+
+func TestWordDiff_WithinChangedLines(t *testing.T) {
+	segments := wordDiff("The quick brown fox.\nSame line\n", "The slow brown fox!\nSame line\n")
+
+	var deleted, inserted []string
+	var rebuiltOld, rebuiltNew strings.Builder
+	for _, s := range segments {
+		switch s.Op {
+		case types.DiffOpDelete:
+			deleted = append(deleted, s.Text)
+			rebuiltOld.WriteString(s.Text)
+		case types.DiffOpInsert:
+			inserted = append(inserted, s.Text)
+			rebuiltNew.WriteString(s.Text)
+		default:
+			rebuiltOld.WriteString(s.Text)
+			rebuiltNew.WriteString(s.Text)
+		}
+	}
+	if strings.Join(deleted, "|") != "quick|." || strings.Join(inserted, "|") != "slow|!" {
+		t.Errorf("Unexpected word changes: -%v +%v", deleted, inserted)
+	}
+	if rebuiltOld.String() != "The quick brown fox.\nSame line\n" || rebuiltNew.String() != "The slow brown fox!\nSame line\n" {
+		t.Error("Segments do not reassemble into the original texts")
+	}
+}
+
+func TestDiffTokens_FallsBackToReplacement(t *testing.T) {
+	var a, b []string
+	for i := 0; i < maxDiffEdits; i++ {
+		a = append(a, "a")
+		b = append(b, "b")
+	}
+
+	edits := diffTokens(a, b)
+	if len(edits) != 2*maxDiffEdits || edits[0].op != '-' || edits[len(edits)-1].op != '+' {
+		t.Errorf("Expected a full replacement, got %d edits", len(edits))
+	}
+}
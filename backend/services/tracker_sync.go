@@ -0,0 +1,411 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"bmad-studio/backend/types"
+)
+
+// IssueTracker is an external issue tracker that epics and stories are synced with.
+// Implementations map their own workflow states to the development_status vocabulary.
+type IssueTracker interface {
+	// ListIssues returns the issues of a project that carry a development_status key
+	ListIssues(ctx context.Context, projectKey string) ([]types.TrackerIssue, error)
+	// CreateIssue creates an issue and returns it with its tracker ID set
+	CreateIssue(ctx context.Context, projectKey string, issue types.TrackerIssue) (*types.TrackerIssue, error)
+	// UpdateIssueStatus sets the status of an issue
+	UpdateIssueStatus(ctx context.Context, projectKey, issueID, status string) error
+}
+
+// TrackerSyncError represents a structured error from the tracker sync service
+type TrackerSyncError struct {
+	Code    string
+	Message string
+}
+
+func (e *TrackerSyncError) Error() string {
+	return e.Message
+}
+
+// Error codes for tracker sync
+const (
+	ErrCodeTrackerConfigNotLoaded = "config_not_loaded"
+	ErrCodeTrackerNotConfigured   = "tracker_not_configured"
+	ErrCodeInvalidResolution      = "invalid_resolution"
+	ErrCodeTrackerRequestFailed   = "tracker_request_failed"
+	ErrCodeTrackerStateFailed     = "tracker_state_failed"
+)
+
+// TrackerSyncService keeps development_status and an external issue tracker in step. The
+// tracker is chosen by the tracking_system of sprint-status.yaml and its project by
+// project_key. Every epic and story is linked to an issue; the status both sides agreed
+// on at the last sync is kept in tracker-sync.json beside sprint-status.yaml, so a sync
+// can tell which side changed: local changes are pushed, tracker changes are pulled and
+// keys changed on both sides are reported as conflicts until resolved.
+type TrackerSyncService struct {
+	mu                    sync.Mutex // Serialises syncs
+	configService         *BMadConfigService
+	workflowStatusService *WorkflowStatusService
+	storyService          *StoryService
+	sprintService         *SprintService
+	trackers              map[string]IssueTracker // By tracking_system
+}
+
+// NewTrackerSyncService creates a new TrackerSyncService with no trackers registered
+func NewTrackerSyncService(configService *BMadConfigService, workflowStatusService *WorkflowStatusService, storyService *StoryService, sprintService *SprintService) *TrackerSyncService {
+	return &TrackerSyncService{
+		configService:         configService,
+		workflowStatusService: workflowStatusService,
+		storyService:          storyService,
+		sprintService:         sprintService,
+		trackers:              make(map[string]IssueTracker),
+	}
+}
+
+// Register makes tracker the tracker of projects whose tracking_system is trackingSystem
+func (s *TrackerSyncService) Register(trackingSystem string, tracker IssueTracker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trackers[trackingSystem] = tracker
+}
+
+// Status returns the tracker settings of the project and the links of the last sync
+func (s *TrackerSyncService) Status() (*types.TrackerStatusResponse, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &TrackerSyncError{Code: ErrCodeTrackerConfigNotLoaded, Message: "BMadConfigService has no config loaded"}
+	}
+	sprint := s.workflowStatusService.GetSprintStatus()
+	if sprint == nil {
+		return nil, &SprintServiceError{
+			Code:    ErrCodeSprintStatusNotFound,
+			Message: "The project has no sprint-status.yaml; run sprint planning first",
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.loadState(config, sprint)
+	if err != nil {
+		return nil, err
+	}
+	_, supported := s.trackers[sprint.TrackingSystem]
+	return &types.TrackerStatusResponse{
+		TrackingSystem: sprint.TrackingSystem,
+		ProjectKey:     sprint.ProjectKey,
+		Supported:      supported,
+		LastSyncedAt:   state.LastSyncedAt,
+		Links:          state.Links,
+	}, nil
+}
+
+// Sync runs a bidirectional status sync with the project's tracker. Epics and stories
+// without an issue get one, matched by key when the tracker already has it. Conflicts
+// are left unchanged unless req resolves them.
+func (s *TrackerSyncService) Sync(ctx context.Context, req types.TrackerSyncRequest) (*types.TrackerSyncResponse, error) {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return nil, &TrackerSyncError{Code: ErrCodeTrackerConfigNotLoaded, Message: "BMadConfigService has no config loaded"}
+	}
+	for key, resolution := range req.Resolutions {
+		if resolution != types.TrackerResolveLocal && resolution != types.TrackerResolveRemote {
+			return nil, &TrackerSyncError{
+				Code:    ErrCodeInvalidResolution,
+				Message: fmt.Sprintf("Invalid resolution %q for %s: must be local or remote", resolution, key),
+			}
+		}
+	}
+	sprint := s.workflowStatusService.GetSprintStatus()
+	if sprint == nil {
+		return nil, &SprintServiceError{
+			Code:    ErrCodeSprintStatusNotFound,
+			Message: "The project has no sprint-status.yaml; run sprint planning first",
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tracker, ok := s.trackers[sprint.TrackingSystem]
+	if !ok {
+		return nil, &TrackerSyncError{
+			Code:    ErrCodeTrackerNotConfigured,
+			Message: fmt.Sprintf("No issue tracker is available for tracking_system %q", sprint.TrackingSystem),
+		}
+	}
+	if sprint.ProjectKey == "" {
+		return nil, &TrackerSyncError{Code: ErrCodeTrackerNotConfigured, Message: "sprint-status.yaml has no project_key"}
+	}
+
+	state, err := s.loadState(config, sprint)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := tracker.ListIssues(ctx, sprint.ProjectKey)
+	if err != nil {
+		return nil, &TrackerSyncError{Code: ErrCodeTrackerRequestFailed, Message: fmt.Sprintf("Failed to list tracker issues: %v", err)}
+	}
+
+	run := &trackerSyncRun{
+		ctx:        ctx,
+		tracker:    tracker,
+		sprint:     s.sprintService,
+		projectKey: sprint.ProjectKey,
+		dryRun:     req.DryRun,
+		links:      state.Links,
+		titles:     s.titles(),
+		issuesByID: make(map[string]*types.TrackerIssue, len(issues)),
+		issueByKey: make(map[string]*types.TrackerIssue, len(issues)),
+		result: &types.TrackerSyncResponse{
+			DryRun:    req.DryRun,
+			Created:   []types.TrackerChange{},
+			Linked:    []types.TrackerChange{},
+			Pushed:    []types.TrackerChange{},
+			Pulled:    []types.TrackerChange{},
+			Conflicts: []types.TrackerConflict{},
+			Unmapped:  []string{},
+		},
+	}
+	for i := range issues {
+		issue := &issues[i]
+		run.issuesByID[issue.ID] = issue
+		if _, seen := run.issueByKey[issue.Key]; !seen && issue.Key != "" {
+			run.issueByKey[issue.Key] = issue
+		}
+	}
+
+	keys := syncedKeys(sprint.DevelopmentStatus)
+	var syncErr error
+	for _, key := range keys {
+		if syncErr = run.syncKey(key, sprint.DevelopmentStatus[key], req.Resolutions[key]); syncErr != nil {
+			break
+		}
+	}
+
+	for _, issue := range issues {
+		if _, listed := sprint.DevelopmentStatus[issue.Key]; !listed {
+			run.result.Unmapped = append(run.result.Unmapped, issue.ID)
+		}
+	}
+	sort.Strings(run.result.Unmapped)
+
+	if req.DryRun {
+		if syncErr != nil {
+			return nil, syncErr
+		}
+		return run.result, nil
+	}
+	// Keep the links made before a failure so a retry does not create duplicate issues
+	now := types.Timestamp(time.Now())
+	state.LastSyncedAt = &now
+	if err := s.saveState(config, state); err != nil {
+		return nil, err
+	}
+	if syncErr != nil {
+		return nil, syncErr
+	}
+	return run.result, nil
+}
+
+// trackerSyncRun holds the working state of one sync
+type trackerSyncRun struct {
+	ctx        context.Context
+	tracker    IssueTracker
+	sprint     *SprintService
+	projectKey string
+	dryRun     bool
+	links      map[string]types.TrackerLink
+	titles     map[string]string
+	issuesByID map[string]*types.TrackerIssue
+	issueByKey map[string]*types.TrackerIssue
+	result     *types.TrackerSyncResponse
+}
+
+// syncKey links key to an issue and reconciles their statuses against the last synced status.
+func (r *trackerSyncRun) syncKey(key, local, resolution string) error {
+	link, linked := r.links[key]
+	issue := r.issuesByID[link.IssueID]
+	if !linked || issue == nil {
+		// Unlinked, or the linked issue was deleted: match by key, else create one
+		if issue = r.issueByKey[key]; issue == nil {
+			return r.create(key, local)
+		}
+		link = types.TrackerLink{IssueID: issue.ID}
+		r.result.Linked = append(r.result.Linked, types.TrackerChange{Key: key, IssueID: issue.ID, To: issue.Status})
+	}
+
+	remote := issue.Status
+	switch {
+	case local == remote:
+		link.SyncedStatus = local
+	case link.SyncedStatus != "" && remote == link.SyncedStatus:
+		if err := r.push(key, issue.ID, remote, local); err != nil {
+			return err
+		}
+		link.SyncedStatus = local
+	case link.SyncedStatus != "" && local == link.SyncedStatus:
+		if err := r.pull(key, issue.ID, local, remote); err != nil {
+			return err
+		}
+		link.SyncedStatus = remote
+	case resolution == types.TrackerResolveLocal:
+		if err := r.push(key, issue.ID, remote, local); err != nil {
+			return err
+		}
+		link.SyncedStatus = local
+	case resolution == types.TrackerResolveRemote:
+		if err := r.pull(key, issue.ID, local, remote); err != nil {
+			return err
+		}
+		link.SyncedStatus = remote
+	default:
+		// Both sides changed, or a newly linked issue disagrees: leave both as they are
+		r.result.Conflicts = append(r.result.Conflicts, types.TrackerConflict{
+			Key:          key,
+			IssueID:      issue.ID,
+			SyncedStatus: link.SyncedStatus,
+			LocalStatus:  local,
+			RemoteStatus: remote,
+		})
+	}
+	if !r.dryRun {
+		r.links[key] = link
+	}
+	return nil
+}
+
+// create creates the issue of an unlinked epic or story.
+func (r *trackerSyncRun) create(key, status string) error {
+	issue := types.TrackerIssue{Key: key, Kind: types.TrackerIssueEpic, Title: r.titles[key], Status: status}
+	if epicNum, _, _, isStory := parseStoryKey(key); isStory {
+		issue.Kind = types.TrackerIssueStory
+		issue.EpicKey = fmt.Sprintf("epic-%d", epicNum)
+	}
+	if issue.Title == "" {
+		issue.Title = key
+	}
+	if r.dryRun {
+		r.result.Created = append(r.result.Created, types.TrackerChange{Key: key, To: status})
+		return nil
+	}
+
+	created, err := r.tracker.CreateIssue(r.ctx, r.projectKey, issue)
+	if err != nil {
+		return &TrackerSyncError{Code: ErrCodeTrackerRequestFailed, Message: fmt.Sprintf("Failed to create tracker issue for %s: %v", key, err)}
+	}
+	r.links[key] = types.TrackerLink{IssueID: created.ID, SyncedStatus: status}
+	r.result.Created = append(r.result.Created, types.TrackerChange{Key: key, IssueID: created.ID, To: status})
+	return nil
+}
+
+// push sets the tracker status of an issue to the local status.
+func (r *trackerSyncRun) push(key, issueID, from, to string) error {
+	if !r.dryRun {
+		if err := r.tracker.UpdateIssueStatus(r.ctx, r.projectKey, issueID, to); err != nil {
+			return &TrackerSyncError{Code: ErrCodeTrackerRequestFailed, Message: fmt.Sprintf("Failed to update tracker issue %s: %v", issueID, err)}
+		}
+	}
+	r.result.Pushed = append(r.result.Pushed, types.TrackerChange{Key: key, IssueID: issueID, From: from, To: to})
+	return nil
+}
+
+// pull sets the development status of key to the tracker status.
+func (r *trackerSyncRun) pull(key, issueID, from, to string) error {
+	if !r.dryRun {
+		if err := r.sprint.SetDevelopmentStatus(key, to); err != nil {
+			return err
+		}
+	}
+	r.result.Pulled = append(r.result.Pulled, types.TrackerChange{Key: key, IssueID: issueID, From: from, To: to})
+	return nil
+}
+
+// syncedKeys returns the epic and story keys of development_status, epics first so
+// stories can name an existing parent. Retrospectives stay local.
+func syncedKeys(developmentStatus map[string]string) []string {
+	var epics, stories []string
+	for key := range developmentStatus {
+		switch {
+		case epicKeyRegex.MatchString(key):
+			epics = append(epics, key)
+		case storyKeyRegex.MatchString(key):
+			stories = append(stories, key)
+		}
+	}
+	sort.Strings(epics)
+	sort.Strings(stories)
+	return append(epics, stories...)
+}
+
+// titles returns epic and story titles by key for new issues. Missing titles fall back to the key.
+func (s *TrackerSyncService) titles() map[string]string {
+	titles := make(map[string]string)
+	if s.storyService == nil {
+		return titles
+	}
+	if stories, err := s.storyService.Stories(); err == nil {
+		for _, story := range stories {
+			titles[story.Key] = story.Title
+		}
+	}
+	if epics, err := s.storyService.Epics(); err == nil {
+		for _, epic := range epics {
+			titles[epic.Key] = epic.Title
+		}
+	}
+	return titles
+}
+
+// trackerStatePath returns the path of tracker-sync.json
+func trackerStatePath(config *types.BMadConfig) string {
+	return filepath.Join(config.ImplementationArtifacts, "tracker-sync.json")
+}
+
+// loadState reads tracker-sync.json. Links made for another tracker or project are dropped.
+func (s *TrackerSyncService) loadState(config *types.BMadConfig, sprint *types.SprintStatusFile) (*types.TrackerSyncState, error) {
+	fresh := &types.TrackerSyncState{
+		TrackingSystem: sprint.TrackingSystem,
+		ProjectKey:     sprint.ProjectKey,
+		Links:          make(map[string]types.TrackerLink),
+	}
+
+	data, err := os.ReadFile(trackerStatePath(config))
+	if errors.Is(err, os.ErrNotExist) {
+		return fresh, nil
+	}
+	if err != nil {
+		return nil, &TrackerSyncError{Code: ErrCodeTrackerStateFailed, Message: fmt.Sprintf("Failed to read tracker-sync.json: %v", err)}
+	}
+	var state types.TrackerSyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, &TrackerSyncError{Code: ErrCodeTrackerStateFailed, Message: fmt.Sprintf("Failed to parse tracker-sync.json: %v", err)}
+	}
+	if state.TrackingSystem != sprint.TrackingSystem || state.ProjectKey != sprint.ProjectKey {
+		return fresh, nil
+	}
+	if state.Links == nil {
+		state.Links = make(map[string]types.TrackerLink)
+	}
+	return &state, nil
+}
+
+// saveState writes tracker-sync.json
+func (s *TrackerSyncService) saveState(config *types.BMadConfig, state *types.TrackerSyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return &TrackerSyncError{Code: ErrCodeTrackerStateFailed, Message: fmt.Sprintf("Failed to encode tracker-sync.json: %v", err)}
+	}
+	if err := writeFileAtomic(trackerStatePath(config), append(data, '\n')); err != nil {
+		return &TrackerSyncError{Code: ErrCodeTrackerStateFailed, Message: fmt.Sprintf("Failed to write tracker-sync.json: %v", err)}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+// setupTrackerSyncTest points the sprint board fixture at a FileTracker project "PROJ".
+func setupTrackerSyncTest(t *testing.T) (*TrackerSyncService, *FileTracker, string) {
+	t.Helper()
+	sprintService, implDir := setupSprintTest(t)
+	board := strings.Replace(sprintBoardFixture, "project: test-project\n", "project: test-project\ntracking_system: file-tracker\nproject_key: PROJ\n", 1)
+	if err := os.WriteFile(filepath.Join(implDir, "sprint-status.yaml"), []byte(board), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sprintService.workflowStatusService.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := NewFileTrackerWithDir(t.TempDir())
+	svc := NewTrackerSyncService(sprintService.configService, sprintService.workflowStatusService, sprintService.storyService, sprintService)
+	svc.Register(FileTrackerSystem, tracker)
+	return svc, tracker, implDir
+}
+
+// issueStatuses returns the tracker status of each issue by key
+func issueStatuses(t *testing.T, tracker *FileTracker) map[string]string {
+	t.Helper()
+	issues, err := tracker.ListIssues(context.Background(), "PROJ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := make(map[string]string)
+	for _, issue := range issues {
+		statuses[issue.Key] = issue.Status
+	}
+	return statuses
+}
+
+// setIssueStatus changes an issue as if in the tracker
+func setIssueStatus(t *testing.T, tracker *FileTracker, key, status string) {
+	t.Helper()
+	issues, err := tracker.ListIssues(context.Background(), "PROJ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		if issue.Key == key {
+			if err := tracker.UpdateIssueStatus(context.Background(), "PROJ", issue.ID, status); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no issue for %s", key)
+}
+
+func TestTrackerSync_CreatesIssuesThenIsIdempotent(t *testing.T) {
+	svc, tracker, implDir := setupTrackerSyncTest(t)
+
+	result, err := svc.Sync(context.Background(), types.TrackerSyncRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 4 || result.Created[0].Key != "epic-1" {
+		t.Fatalf("expected epic-1 then three stories created, got %+v", result.Created)
+	}
+
+	issues, err := tracker.ListIssues(context.Background(), "PROJ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		switch issue.Key {
+		case "epic-1":
+			if issue.Kind != types.TrackerIssueEpic || issue.ID != "PROJ-1" {
+				t.Errorf("unexpected epic issue %+v", issue)
+			}
+		case "1-2-login":
+			if issue.Kind != types.TrackerIssueStory || issue.EpicKey != "epic-1" || issue.Title != "Login" || issue.Status != types.StoryReadyForDev {
+				t.Errorf("unexpected story issue %+v", issue)
+			}
+		}
+	}
+
+	var state types.TrackerSyncState
+	if err := json.Unmarshal([]byte(readTestFile(t, filepath.Join(implDir, "tracker-sync.json"))), &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Links["1-3-logout"].SyncedStatus != types.StoryBacklog || state.LastSyncedAt == nil {
+		t.Errorf("unexpected sync state %+v", state)
+	}
+
+	again, err := svc.Sync(context.Background(), types.TrackerSyncRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Created)+len(again.Linked)+len(again.Pushed)+len(again.Pulled)+len(again.Conflicts) != 0 {
+		t.Errorf("expected nothing to do on a second sync, got %+v", again)
+	}
+}
+
+func TestTrackerSync_PushesPullsAndReportsConflicts(t *testing.T) {
+	svc, tracker, implDir := setupTrackerSyncTest(t)
+	if _, err := svc.Sync(context.Background(), types.TrackerSyncRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1-2-login moves on the board, 1-3-logout in the tracker, 1-1-setup in both
+	if _, err := svc.sprintService.MoveStory("1-2-login", types.StoryInProgress); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.sprintService.SetDevelopmentStatus("1-1-setup", types.StoryReview); err != nil {
+		t.Fatal(err)
+	}
+	setIssueStatus(t, tracker, "1-3-logout", types.StoryReadyForDev)
+	setIssueStatus(t, tracker, "1-1-setup", types.StoryInProgress)
+
+	result, err := svc.Sync(context.Background(), types.TrackerSyncRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Pushed) != 1 || result.Pushed[0].Key != "1-2-login" || result.Pushed[0].To != types.StoryInProgress {
+		t.Errorf("expected 1-2-login pushed, got %+v", result.Pushed)
+	}
+	if len(result.Pulled) != 1 || result.Pulled[0].Key != "1-3-logout" || result.Pulled[0].To != types.StoryReadyForDev {
+		t.Errorf("expected 1-3-logout pulled, got %+v", result.Pulled)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0] != (types.TrackerConflict{
+		Key: "1-1-setup", IssueID: "PROJ-2", SyncedStatus: types.StoryDone, LocalStatus: types.StoryReview, RemoteStatus: types.StoryInProgress,
+	}) {
+		t.Errorf("expected a 1-1-setup conflict, got %+v", result.Conflicts)
+	}
+
+	if got := issueStatuses(t, tracker); got["1-2-login"] != types.StoryInProgress || got["1-1-setup"] != types.StoryInProgress {
+		t.Errorf("unexpected tracker statuses %v", got)
+	}
+	if got := readTestFile(t, filepath.Join(implDir, "sprint-status.yaml")); !strings.Contains(got, "1-3-logout: 'ready-for-dev'") || !strings.Contains(got, "1-1-setup: review") {
+		t.Errorf("expected 1-3-logout pulled and 1-1-setup left alone, got:\n%s", got)
+	}
+
+	resolved, err := svc.Sync(context.Background(), types.TrackerSyncRequest{Resolutions: map[string]string{"1-1-setup": types.TrackerResolveRemote}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved.Conflicts) != 0 || len(resolved.Pulled) != 1 || resolved.Pulled[0].To != types.StoryInProgress {
+		t.Errorf("expected the conflict resolved from the tracker, got %+v", resolved)
+	}
+	if got := svc.workflowStatusService.GetSprintStatus().DevelopmentStatus["1-1-setup"]; got != types.StoryInProgress {
+		t.Errorf("expected 1-1-setup in-progress, got %q", got)
+	}
+}
+
+func TestTrackerSync_LinksExistingIssuesAndReportsUnmapped(t *testing.T) {
+	svc, tracker, _ := setupTrackerSyncTest(t)
+	ctx := context.Background()
+	for _, issue := range []types.TrackerIssue{
+		{Key: "1-1-setup", Kind: types.TrackerIssueStory, Title: "Setup", Status: types.StoryDone},
+		{Key: "1-2-login", Kind: types.TrackerIssueStory, Title: "Login", Status: types.StoryReview},
+		{Key: "", Kind: types.TrackerIssueStory, Title: "Unrelated", Status: types.StoryBacklog},
+	} {
+		if _, err := tracker.CreateIssue(ctx, "PROJ", issue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := svc.Sync(ctx, types.TrackerSyncRequest{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Linked) != 2 || len(result.Created) != 2 || len(result.Conflicts) != 1 || result.Conflicts[0].Key != "1-2-login" {
+		t.Errorf("expected two links, two creations and a 1-2-login conflict, got %+v", result)
+	}
+	if len(result.Unmapped) != 1 || result.Unmapped[0] != "PROJ-3" {
+		t.Errorf("expected PROJ-3 unmapped, got %v", result.Unmapped)
+	}
+	if got := issueStatuses(t, tracker); len(got) != 3 {
+		t.Errorf("expected a dry run to create nothing, got %v", got)
+	}
+	status, err := svc.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Supported || status.ProjectKey != "PROJ" || status.LastSyncedAt != nil || len(status.Links) != 0 {
+		t.Errorf("expected no recorded sync after a dry run, got %+v", status)
+	}
+}
+
+func TestTrackerSync_Errors(t *testing.T) {
+	svc, _, _ := setupTrackerSyncTest(t)
+
+	var syncErr *TrackerSyncError
+	_, err := svc.Sync(context.Background(), types.TrackerSyncRequest{Resolutions: map[string]string{"1-1-setup": "both"}})
+	if !errors.As(err, &syncErr) || syncErr.Code != ErrCodeInvalidResolution {
+		t.Errorf("expected invalid_resolution, got %v", err)
+	}
+
+	svc.trackers = map[string]IssueTracker{}
+	_, err = svc.Sync(context.Background(), types.TrackerSyncRequest{})
+	if !errors.As(err, &syncErr) || syncErr.Code != ErrCodeTrackerNotConfigured {
+		t.Errorf("expected tracker_not_configured, got %v", err)
+	}
+}
+
+func TestFileTracker_RejectsUnsafeProjectKeys(t *testing.T) {
+	tracker := NewFileTrackerWithDir(t.TempDir())
+	if _, err := tracker.ListIssues(context.Background(), "../escape"); err == nil {
+		t.Error("expected an error for a project key with a path")
+	}
+	if err := tracker.UpdateIssueStatus(context.Background(), "PROJ", "PROJ-1", "done"); err == nil {
+		t.Error("expected an error for a missing issue")
+	}
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlErrorLineRegex extracts the line number yaml.v3 reports in parse errors
+var yamlErrorLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// frontmatterSchemaRule checks that the frontmatter parses and that the keys the studio
+// reads have the shapes it expects.
+type frontmatterSchemaRule struct{}
+
+func (frontmatterSchemaRule) Name() string { return "frontmatter-schema" }
+
+func (r frontmatterSchemaRule) Check(doc *ValidationDocument) []types.ArtifactDiagnostic {
+	diag := func(severity, field string, line int, format string, args ...interface{}) types.ArtifactDiagnostic {
+		return types.ArtifactDiagnostic{Severity: severity, Field: field, Line: line, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if doc.FrontmatterError != nil {
+		line := 1
+		if m := yamlErrorLineRegex.FindStringSubmatch(doc.FrontmatterError.Error()); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			line = n + 1 // The YAML starts on the line after the opening ---
+		}
+		return []types.ArtifactDiagnostic{diag(types.DiagnosticSeverityError, "", line, "Frontmatter is not valid YAML: %v", doc.FrontmatterError)}
+	}
+	if doc.Frontmatter == nil {
+		if doc.BodyLine == 1 && strings.HasPrefix(string(doc.Content), "---") {
+			return []types.ArtifactDiagnostic{diag(types.DiagnosticSeverityError, "", 1, "Frontmatter block is not closed with ---")}
+		}
+		return nil
+	}
+	fm := doc.Frontmatter
+	if fm.Kind != yaml.MappingNode {
+		return []types.ArtifactDiagnostic{diag(types.DiagnosticSeverityError, "", fm.Line+1, "Frontmatter must be a mapping of keys to values")}
+	}
+
+	var diagnostics []types.ArtifactDiagnostic
+	fields := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(fm.Content); i += 2 {
+		fields[fm.Content[i].Value] = fm.Content[i+1]
+	}
+
+	if steps, ok := fields["stepsCompleted"]; ok && !isNullNode(steps) {
+		if steps.Kind != yaml.SequenceNode {
+			diagnostics = append(diagnostics, diag(types.DiagnosticSeverityError, "stepsCompleted", steps.Line+1,
+				"stepsCompleted must be a list of step names or numbers"))
+		} else {
+			for _, item := range steps.Content {
+				if item.Kind != yaml.ScalarNode || (item.Tag != "!!str" && item.Tag != "!!int") {
+					diagnostics = append(diagnostics, diag(types.DiagnosticSeverityError, "stepsCompleted", item.Line+1,
+						"stepsCompleted entries must be strings or integers, found %s", describeNode(item)))
+				}
+			}
+		}
+	}
+
+	if inputs, ok := fields["inputDocuments"]; ok && !isNullNode(inputs) {
+		if inputs.Kind != yaml.SequenceNode {
+			diagnostics = append(diagnostics, diag(types.DiagnosticSeverityError, "inputDocuments", inputs.Line+1,
+				"inputDocuments must be a list of paths"))
+		} else {
+			for _, item := range inputs.Content {
+				if item.Kind != yaml.ScalarNode || item.Tag != "!!str" {
+					diagnostics = append(diagnostics, diag(types.DiagnosticSeverityError, "inputDocuments", item.Line+1,
+						"inputDocuments entries must be paths, found %s", describeNode(item)))
+				}
+			}
+		}
+	}
+
+	if wt, ok := fields["workflowType"]; !ok || strings.TrimSpace(wt.Value) == "" {
+		diagnostics = append(diagnostics, diag(types.DiagnosticSeverityWarning, "workflowType", fm.Line+1,
+			"workflowType is missing, so the artifact cannot be linked to the workflow that produced it"))
+	}
+
+	status, hasStatus := fields["status"]
+	completedAt, hasCompletedAt := fields["completedAt"]
+	if hasStatus {
+		switch strings.ToLower(status.Value) {
+		case "complete", "completed", "done":
+		case "in-progress", "in_progress", "inprogress":
+			if hasCompletedAt && !isNullNode(completedAt) && completedAt.Value != "" {
+				diagnostics = append(diagnostics, diag(types.DiagnosticSeverityWarning, "completedAt", completedAt.Line+1,
+					"completedAt is set but status is %q", status.Value))
+			}
+		default:
+			diagnostics = append(diagnostics, diag(types.DiagnosticSeverityInfo, "status", status.Line+1,
+				"Status %q is not recognised and is treated as not started", status.Value))
+		}
+	}
+
+	return diagnostics
+}
+
+// isNullNode reports whether a YAML node is an explicit or implicit null.
+func isNullNode(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// describeNode names a YAML value for diagnostics.
+func describeNode(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	return fmt.Sprintf("%q (%s)", n.Value, strings.TrimPrefix(n.Tag, "!!"))
+}
+
+// requiredSectionsRule checks that an artifact has a heading for each required section.
+// Missing sections are errors once the artifact is complete and warnings while it is being written.
+type requiredSectionsRule struct {
+	name     string
+	sections [][]string // Each entry lists accepted heading prefixes, lowercase
+}
+
+// prdRequiredSectionsRule covers the sections later workflows read from a PRD
+var prdRequiredSectionsRule = requiredSectionsRule{
+	name: "prd-required-sections",
+	sections: [][]string{
+		{"goals", "executive summary", "overview"},
+		{"functional requirements"},
+		{"non-functional requirements", "non functional requirements", "nfrs", "nonfunctional requirements"},
+	},
+}
+
+func (r requiredSectionsRule) Name() string { return r.name }
+
+func (r requiredSectionsRule) Check(doc *ValidationDocument) []types.ArtifactDiagnostic {
+	entries, _ := buildOutline(doc.Content)
+	var headings []string
+	for _, e := range entries {
+		headings = append(headings, normalizeHeading(e.section.Heading))
+	}
+
+	severity := types.DiagnosticSeverityWarning
+	if doc.Artifact.Status == types.ArtifactStatusComplete {
+		severity = types.DiagnosticSeverityError
+	}
+
+	var diagnostics []types.ArtifactDiagnostic
+	for _, accepted := range r.sections {
+		if !anyHeadingHasPrefix(headings, accepted) {
+			diagnostics = append(diagnostics, types.ArtifactDiagnostic{
+				Severity: severity,
+				Message:  fmt.Sprintf("Missing required section %q", toTitleCase(accepted[0])),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// normalizeHeading lowercases a heading and drops leading numbering such as "2." or "3.1".
+func normalizeHeading(heading string) string {
+	h := strings.ToLower(strings.TrimSpace(heading))
+	return strings.TrimSpace(strings.TrimLeftFunc(h, func(r rune) bool {
+		return unicode.IsDigit(r) || r == '.' || r == ')'
+	}))
+}
+
+func anyHeadingHasPrefix(headings, prefixes []string) bool {
+	for _, h := range headings {
+		for _, p := range prefixes {
+			if strings.HasPrefix(h, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// markdownLink is a link target found in a markdown body.
+type markdownLink struct {
+	target string
+	line   int // 1-based file line
+}
+
+var (
+	// inlineLinkRegex matches [text](target "title") and ![alt](target), capturing the target
+	inlineLinkRegex = regexp.MustCompile(`!?\[(?:[^\]\\]|\\.)*\]\(\s*(<[^>]*>|[^)\s]+)(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)`)
+	// referenceLinkRegex matches a [label]: target definition
+	referenceLinkRegex = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*(<[^>]*>|\S+)`)
+	// inlineCodeRegex matches code spans, whose contents are not links
+	inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+)
+
+// markdownLinks returns the inline and reference link targets in the body of content,
+// skipping fenced code blocks and code spans.
+func markdownLinks(content []byte, bodyLine int) []markdownLink {
+	_, body := splitFrontmatter(content)
+	var links []markdownLink
+	fence := ""
+	for i, line := range strings.Split(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		line = inlineCodeRegex.ReplaceAllString(line, "")
+		for _, m := range inlineLinkRegex.FindAllStringSubmatch(line, -1) {
+			links = append(links, markdownLink{target: strings.Trim(m[1], "<>"), line: bodyLine + i})
+		}
+		if m := referenceLinkRegex.FindStringSubmatch(line); m != nil {
+			links = append(links, markdownLink{target: strings.Trim(m[1], "<>"), line: bodyLine + i})
+		}
+	}
+	return links
+}
+
+// isExternalLink reports whether a link target leaves the project, e.g. a URL or mail address.
+func isExternalLink(target string) bool {
+	if i := strings.Index(target, ":"); i > 0 {
+		scheme := target[:i]
+		for _, r := range scheme {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '-' && r != '.' {
+				return false
+			}
+		}
+		return len(scheme) > 1 // Single letters are Windows drive letters
+	}
+	return strings.HasPrefix(target, "//")
+}
+
+// splitLinkTarget separates a relative link into its unescaped path and fragment.
+func splitLinkTarget(target string) (string, string) {
+	fragment := ""
+	if i := strings.Index(target, "#"); i != -1 {
+		target, fragment = target[:i], target[i+1:]
+	}
+	if i := strings.Index(target, "?"); i != -1 {
+		target = target[:i]
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	return target, fragment
+}
+
+// brokenLinksRule reports relative links to files that do not exist and
+// in-page anchors that match no heading.
+type brokenLinksRule struct{}
+
+func (brokenLinksRule) Name() string { return "broken-links" }
+
+func (brokenLinksRule) Check(doc *ValidationDocument) []types.ArtifactDiagnostic {
+	var diagnostics []types.ArtifactDiagnostic
+	var anchors map[string]bool
+
+	for _, link := range markdownLinks(doc.Content, doc.BodyLine) {
+		if isExternalLink(link.target) {
+			continue
+		}
+		target, fragment := splitLinkTarget(link.target)
+
+		if target == "" {
+			if fragment == "" {
+				continue
+			}
+			if anchors == nil {
+				anchors = make(map[string]bool)
+				entries, _ := buildOutline(doc.Content)
+				for _, e := range entries {
+					anchors[e.section.ID] = true
+				}
+			}
+			if !anchors[fragment] {
+				diagnostics = append(diagnostics, types.ArtifactDiagnostic{
+					Severity: types.DiagnosticSeverityWarning,
+					Line:     link.line,
+					Message:  fmt.Sprintf("Link to #%s matches no heading in this document", fragment),
+				})
+			}
+			continue
+		}
+
+		if doc.ProjectRoot == "" {
+			continue
+		}
+		var rel string
+		if strings.HasPrefix(target, "/") {
+			rel = path.Clean(strings.TrimPrefix(target, "/"))
+		} else {
+			rel = path.Join(path.Dir(doc.Artifact.Path), target)
+		}
+		if _, err := os.Stat(filepath.Join(doc.ProjectRoot, filepath.FromSlash(rel))); err != nil {
+			diagnostics = append(diagnostics, types.ArtifactDiagnostic{
+				Severity: types.DiagnosticSeverityError,
+				Line:     link.line,
+				Message:  fmt.Sprintf("Link target %s does not exist", link.target),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// orphanShardRule reports shards of a sharded artifact that its index.md does not link to,
+// which readers navigating from the index will never reach.
+type orphanShardRule struct{}
+
+func (orphanShardRule) Name() string { return "orphan-shard" }
+
+func (orphanShardRule) Check(doc *ValidationDocument) []types.ArtifactDiagnostic {
+	if doc.Artifact.ParentID == nil || doc.ParentContent == nil {
+		return nil
+	}
+
+	name := path.Base(doc.Artifact.Path)
+	_, parentBody := splitFrontmatter(doc.ParentContent)
+	parentBodyLine := strings.Count(string(doc.ParentContent[:len(doc.ParentContent)-len(parentBody)]), "\n") + 1
+	for _, link := range markdownLinks(doc.ParentContent, parentBodyLine) {
+		if isExternalLink(link.target) {
+			continue
+		}
+		target, _ := splitLinkTarget(link.target)
+		if target != "" && path.Clean(strings.TrimPrefix(target, "./")) == name {
+			return nil
+		}
+	}
+
+	return []types.ArtifactDiagnostic{{
+		Severity: types.DiagnosticSeverityWarning,
+		Message:  fmt.Sprintf("Shard %s is not linked from its index.md", name),
+	}}
+}
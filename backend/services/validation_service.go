@@ -0,0 +1,168 @@
+package services
+
+import (
+	"bytes"
+	"log"
+	"sort"
+	"sync"
+
+	"bmad-studio/backend/api/websocket"
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationRule checks one aspect of an artifact and reports what it finds.
+// Rules must treat the document as read-only.
+type ValidationRule interface {
+	Name() string
+	Check(doc *ValidationDocument) []types.ArtifactDiagnostic
+}
+
+// ValidationDocument is the parsed artifact a rule inspects.
+type ValidationDocument struct {
+	Artifact         *types.ArtifactResponse
+	Content          []byte
+	Frontmatter      *yaml.Node // Mapping node of the frontmatter; nil if absent or invalid
+	FrontmatterError error      // YAML error in a frontmatter block that is present
+	BodyLine         int        // 1-based file line the markdown body starts on
+	ProjectRoot      string
+	ParentContent    []byte // Content of the sharded parent's index.md, for shards only
+}
+
+// registeredRule is a rule and the artifact types it applies to; empty means all types.
+type registeredRule struct {
+	rule          ValidationRule
+	artifactTypes map[string]bool
+}
+
+// ValidationService lints artifacts with a set of rules registered per artifact type.
+// As a watcher listener it re-validates every changed artifact and broadcasts the
+// result as an artifact:diagnostics event.
+type ValidationService struct {
+	mu              sync.RWMutex
+	configService   *BMadConfigService
+	artifactService *ArtifactService
+	hub             *websocket.Hub
+	rules           []registeredRule
+}
+
+// NewValidationService creates a ValidationService with the built-in rules registered.
+// hub may be nil, in which case no events are broadcast.
+func NewValidationService(configService *BMadConfigService, artifactService *ArtifactService, hub *websocket.Hub) *ValidationService {
+	s := &ValidationService{
+		configService:   configService,
+		artifactService: artifactService,
+		hub:             hub,
+	}
+	s.Register(frontmatterSchemaRule{})
+	s.Register(brokenLinksRule{})
+	s.Register(orphanShardRule{})
+	s.Register(prdRequiredSectionsRule, types.ArtifactTypePRD)
+	return s
+}
+
+// Register adds a rule for the given artifact types, or for every type if none are given.
+func (s *ValidationService) Register(rule ValidationRule, artifactTypes ...string) {
+	r := registeredRule{rule: rule}
+	if len(artifactTypes) > 0 {
+		r.artifactTypes = make(map[string]bool, len(artifactTypes))
+		for _, t := range artifactTypes {
+			r.artifactTypes[t] = true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules = append(s.rules, r)
+}
+
+// Validate runs every rule that applies to the artifact and returns the diagnostics
+// ordered by line.
+func (s *ValidationService) Validate(id string) (*types.ArtifactDiagnosticsResponse, error) {
+	artifact, err := s.artifactService.GetArtifact(id)
+	if err != nil {
+		return nil, err
+	}
+	content, err := s.artifactService.GetRawContent(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.validate(artifact, content), nil
+}
+
+// ArtifactChanged validates the artifact and broadcasts its diagnostics.
+func (s *ValidationService) ArtifactChanged(artifact *types.ArtifactResponse) {
+	content, err := s.artifactService.ReadContent(artifact)
+	if err != nil {
+		log.Printf("Warning: Failed to read artifact %s for validation: %v", artifact.ID, err)
+		return
+	}
+	result := s.validate(artifact, content)
+	if s.hub != nil {
+		s.hub.BroadcastEvent(types.NewArtifactDiagnosticsEvent(result))
+	}
+}
+
+// ArtifactRemoved is a no-op; a deleted artifact has nothing left to validate.
+func (s *ValidationService) ArtifactRemoved(artifact *types.ArtifactResponse) {}
+
+// validate builds the document for an artifact and runs the applicable rules over it.
+func (s *ValidationService) validate(artifact *types.ArtifactResponse, content []byte) *types.ArtifactDiagnosticsResponse {
+	doc := &ValidationDocument{Artifact: artifact, Content: content}
+	if config := s.configService.GetConfig(); config != nil {
+		doc.ProjectRoot = config.ProjectRoot
+	}
+
+	raw, body := splitFrontmatter(content)
+	doc.BodyLine = bytes.Count(content[:len(content)-len(body)], []byte("\n")) + 1
+	if raw != nil {
+		var root yaml.Node
+		if err := yaml.Unmarshal(raw, &root); err != nil {
+			doc.FrontmatterError = err
+		} else if len(root.Content) > 0 {
+			doc.Frontmatter = root.Content[0]
+		}
+	}
+	if artifact.ParentID != nil {
+		if parent, err := s.artifactService.GetRawContent(*artifact.ParentID); err == nil {
+			doc.ParentContent = parent
+		}
+	}
+
+	s.mu.RLock()
+	rules := make([]ValidationRule, 0, len(s.rules))
+	for _, r := range s.rules {
+		if r.artifactTypes == nil || r.artifactTypes[artifact.Type] {
+			rules = append(rules, r.rule)
+		}
+	}
+	s.mu.RUnlock()
+
+	result := &types.ArtifactDiagnosticsResponse{
+		ArtifactID:  artifact.ID,
+		Path:        artifact.Path,
+		Diagnostics: []types.ArtifactDiagnostic{},
+	}
+	for _, rule := range rules {
+		for _, d := range rule.Check(doc) {
+			if d.Rule == "" {
+				d.Rule = rule.Name()
+			}
+			result.Diagnostics = append(result.Diagnostics, d)
+		}
+	}
+
+	sort.SliceStable(result.Diagnostics, func(i, j int) bool {
+		return result.Diagnostics[i].Line < result.Diagnostics[j].Line
+	})
+	for _, d := range result.Diagnostics {
+		switch d.Severity {
+		case types.DiagnosticSeverityError:
+			result.Errors++
+		case types.DiagnosticSeverityWarning:
+			result.Warnings++
+		}
+	}
+	return result
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+func validateContent(t *testing.T, content string) *types.ArtifactDiagnosticsResponse {
+	t.Helper()
+	svc, id := setupContentTest(t, content)
+	result, err := NewValidationService(svc.configService, svc, nil).Validate(id)
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	return result
+}
+
+// diagnosticsFor returns the diagnostics reported by one rule.
+func diagnosticsFor(result *types.ArtifactDiagnosticsResponse, rule string) []types.ArtifactDiagnostic {
+	var found []types.ArtifactDiagnostic
+	for _, d := range result.Diagnostics {
+		if d.Rule == rule {
+			found = append(found, d)
+		}
+	}
+	return found
+}
+
+const completePRD = `---
+status: complete
+workflowType: prd
+completedAt: "2026-01-27"
+---
+# PRD
+
+## Goals
+
+## Functional Requirements
+
+## Non-Functional Requirements
+`
+
+func TestValidate_CleanArtifactHasNoDiagnostics(t *testing.T) {
+	result := validateContent(t, completePRD)
+	if len(result.Diagnostics) != 0 || result.Errors != 0 || result.Warnings != 0 {
+		t.Errorf("Expected no diagnostics, got %+v", result)
+	}
+}
+
+func TestValidate_FrontmatterSchema(t *testing.T) {
+	result := validateContent(t, `---
+status: in-progress
+completedAt: "2026-01-27"
+stepsCompleted: [1, "two", {step: 3}]
+inputDocuments: prd.md
+---
+# PRD
+`)
+
+	diags := diagnosticsFor(result, "frontmatter-schema")
+	byField := make(map[string]types.ArtifactDiagnostic)
+	for _, d := range diags {
+		byField[d.Field] = d
+	}
+	if d, ok := byField["stepsCompleted"]; !ok || d.Severity != types.DiagnosticSeverityError || d.Line != 4 {
+		t.Errorf("Expected an error on the mapping step at line 4, got %+v", d)
+	}
+	if d, ok := byField["inputDocuments"]; !ok || d.Severity != types.DiagnosticSeverityError {
+		t.Errorf("Expected an error for a scalar inputDocuments, got %+v", d)
+	}
+	if d, ok := byField["workflowType"]; !ok || d.Severity != types.DiagnosticSeverityWarning {
+		t.Errorf("Expected a warning for the missing workflowType, got %+v", d)
+	}
+	if d, ok := byField["completedAt"]; !ok || d.Line != 3 {
+		t.Errorf("Expected a warning for completedAt on an in-progress doc, got %+v", d)
+	}
+	if len(diags) != 4 {
+		t.Errorf("Expected 4 frontmatter diagnostics, got %+v", diags)
+	}
+	if result.Errors != 2 || result.Warnings < 2 {
+		t.Errorf("Unexpected counts: %d errors, %d warnings", result.Errors, result.Warnings)
+	}
+}
+
+func TestValidate_ReportsInvalidYAML(t *testing.T) {
+	result := validateContent(t, "---\nstatus: complete\nworkflowType: [prd\n---\n# PRD\n")
+
+	diags := diagnosticsFor(result, "frontmatter-schema")
+	if len(diags) != 1 || diags[0].Severity != types.DiagnosticSeverityError || !strings.Contains(diags[0].Message, "not valid YAML") {
+		t.Fatalf("Expected one YAML error, got %+v", diags)
+	}
+	if diags[0].Line < 2 {
+		t.Errorf("Expected the error on a frontmatter line, got line %d", diags[0].Line)
+	}
+}
+
+func TestValidate_ReportsUnclosedFrontmatter(t *testing.T) {
+	result := validateContent(t, "---\nstatus: complete\n# PRD\n")
+
+	diags := diagnosticsFor(result, "frontmatter-schema")
+	if len(diags) != 1 || diags[0].Line != 1 || !strings.Contains(diags[0].Message, "not closed") {
+		t.Errorf("Expected an unclosed frontmatter error, got %+v", diags)
+	}
+}
+
+func TestValidate_PRDRequiredSections(t *testing.T) {
+	complete := validateContent(t, "---\nstatus: complete\nworkflowType: prd\n---\n# PRD\n\n## 1. Goals\n\n## Non-Functional Requirements\n")
+	diags := diagnosticsFor(complete, "prd-required-sections")
+	if len(diags) != 1 || diags[0].Severity != types.DiagnosticSeverityError || !strings.Contains(diags[0].Message, "Functional Requirements") {
+		t.Errorf("Expected Functional Requirements missing as an error, got %+v", diags)
+	}
+
+	draft := validateContent(t, "---\nstatus: in-progress\nworkflowType: prd\n---\n# PRD\n")
+	diags = diagnosticsFor(draft, "prd-required-sections")
+	if len(diags) != 3 || diags[0].Severity != types.DiagnosticSeverityWarning {
+		t.Errorf("Expected three missing sections as warnings on a draft, got %+v", diags)
+	}
+}
+
+func TestValidate_BrokenLinks(t *testing.T) {
+	svc, id := setupContentTest(t, `---
+workflowType: prd
+---
+# PRD
+
+See [architecture](architecture.md), [brief](./brief%20v2.md#scope) and [the site](https://example.com).
+Jump to [goals](#goals) or [nowhere](#nowhere).
+
+`+"```"+`
+[not a link](missing-in-code.md)
+`+"```"+`
+
+[ref]: ../missing/notes.md
+
+## Goals
+`)
+	planningDir := filepath.Join(svc.configService.GetConfig().ProjectRoot, "_bmad-output", "planning-artifacts")
+	if err := os.WriteFile(filepath.Join(planningDir, "brief v2.md"), []byte("# Brief\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := NewValidationService(svc.configService, svc, nil).Validate(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := diagnosticsFor(result, "broken-links")
+	if len(diags) != 3 {
+		t.Fatalf("Expected 3 broken links, got %+v", diags)
+	}
+	if !strings.Contains(diags[0].Message, "architecture.md") || diags[0].Line != 6 || diags[0].Severity != types.DiagnosticSeverityError {
+		t.Errorf("Unexpected first diagnostic %+v", diags[0])
+	}
+	if !strings.Contains(diags[1].Message, "#nowhere") || diags[1].Line != 7 {
+		t.Errorf("Unexpected anchor diagnostic %+v", diags[1])
+	}
+	if !strings.Contains(diags[2].Message, "../missing/notes.md") || diags[2].Line != 13 {
+		t.Errorf("Unexpected reference diagnostic %+v", diags[2])
+	}
+}
+
+func TestValidate_OrphanShard(t *testing.T) {
+	configService, tmpDir := setupArtifactTestConfig(t)
+	shardDir := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts", "architecture")
+	if err := os.MkdirAll(shardDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"index.md":      "---\nworkflowType: architecture\n---\n# Architecture\n\n- [Data model](./data-model.md)\n",
+		"data-model.md": "---\nworkflowType: architecture\n---\n# Data Model\n",
+		"security.md":   "---\nworkflowType: architecture\n---\n# Security\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(shardDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	artifactService := NewArtifactService(configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewValidationService(configService, artifactService, nil)
+
+	linked, err := svc.Validate("_bmad-output-planning-artifacts-architecture-data-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := diagnosticsFor(linked, "orphan-shard"); len(diags) != 0 {
+		t.Errorf("Expected the linked shard to pass, got %+v", diags)
+	}
+
+	orphan, err := svc.Validate("_bmad-output-planning-artifacts-architecture-security")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := diagnosticsFor(orphan, "orphan-shard"); len(diags) != 1 || !strings.Contains(diags[0].Message, "security.md") {
+		t.Errorf("Expected the unlinked shard reported, got %+v", diags)
+	}
+}
+
+// staticRule reports one fixed diagnostic, for testing rule registration.
+type staticRule struct{}
+
+func (staticRule) Name() string { return "static" }
+
+func (staticRule) Check(doc *ValidationDocument) []types.ArtifactDiagnostic {
+	return []types.ArtifactDiagnostic{{Severity: types.DiagnosticSeverityInfo, Message: "checked " + doc.Artifact.ID}}
+}
+
+func TestValidate_RulesApplyOnlyToRegisteredTypes(t *testing.T) {
+	svc, id := setupContentTest(t, completePRD)
+	validation := NewValidationService(svc.configService, svc, nil)
+	validation.Register(staticRule{}, types.ArtifactTypeArchitecture)
+
+	result, err := validation.Validate(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := diagnosticsFor(result, "static"); len(diags) != 0 {
+		t.Errorf("Expected an architecture rule to skip the PRD, got %+v", diags)
+	}
+
+	validation.Register(staticRule{}, types.ArtifactTypePRD)
+	result, err = validation.Validate(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := diagnosticsFor(result, "static"); len(diags) != 1 {
+		t.Errorf("Expected the PRD rule to run once, got %+v", diags)
+	}
+}
+
+func TestValidate_UnknownArtifact(t *testing.T) {
+	svc, _ := setupContentTest(t, completePRD)
+	_, err := NewValidationService(svc.configService, svc, nil).Validate("missing")
+	if !isArtifactErr(err, ErrCodeArtifactNotFound) {
+		t.Errorf("Expected artifact_not_found, got %v", err)
+	}
+}
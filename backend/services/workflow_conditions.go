@@ -0,0 +1,345 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// conditionResult is the three-valued outcome of a workflow condition. Conditions that
+// reference unknown facts or cannot be parsed are unknown rather than false, so a
+// workflow is only ruled out when the project facts actually say it does not apply.
+type conditionResult int
+
+const (
+	conditionUnknown conditionResult = iota
+	conditionFalse
+	conditionTrue
+)
+
+func conditionOf(b bool) conditionResult {
+	if b {
+		return conditionTrue
+	}
+	return conditionFalse
+}
+
+// conditionFacts holds the project facts workflow conditions are evaluated against
+type conditionFacts struct {
+	values        map[string]string // Config fields, project_type, field_type, ...; keys lowercased
+	artifactTypes map[string]bool   // Types of existing artifacts; nil when artifacts are not known
+	projectRoot   string            // Root for exists() globs; "" disables them
+}
+
+// lookup returns the value of a fact. has_<type> facts report whether an artifact of that
+// type exists, unless a config field of the same name overrides it.
+func (f *conditionFacts) lookup(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if value, ok := f.values[name]; ok {
+		return value, true
+	}
+	if artifactType, ok := strings.CutPrefix(name, "has_"); ok && f.artifactTypes != nil {
+		if f.artifactTypes[artifactType] {
+			return "true", true
+		}
+		// has_ui and has_ux follow the UX design document. Without one they stay unknown:
+		// the document is what the UI workflows produce, so its absence says nothing about
+		// whether the project has a UI.
+		if artifactType == "ui" || artifactType == "ux" {
+			if f.artifactTypes["ux_design"] {
+				return "true", true
+			}
+			return "", false
+		}
+		return "false", true
+	}
+	return "", false
+}
+
+// evaluateCondition evaluates the conditional of a workflow against facts.
+//
+// Conditions are either the "if_<fact>" shorthand BMAD path files use, true when the fact
+// is truthy, or expressions over facts:
+//
+//	field_type == brownfield && (has_ui || project_type != "api")
+//	not exists('docs/architecture*.md')
+//
+// Operators are ==, !=, !, &&, || and their word forms not, and, or. Literals are bare
+// words or quoted strings; exists(glob) matches paths relative to the project root.
+func evaluateCondition(condition string, facts *conditionFacts) conditionResult {
+	condition = strings.TrimSpace(condition)
+	if fact, ok := strings.CutPrefix(condition, "if_"); ok && isConditionIdent(fact) {
+		value, known := facts.lookup(fact)
+		if !known {
+			return conditionUnknown
+		}
+		return conditionOf(isTruthy(value))
+	}
+
+	tokens, err := tokenizeCondition(condition)
+	if err != nil {
+		return conditionUnknown
+	}
+	p := &conditionParser{tokens: tokens, facts: facts}
+	result, err := p.parseOr()
+	if err != nil || p.pos != len(p.tokens) {
+		return conditionUnknown
+	}
+	return result
+}
+
+// isTruthy reports whether a fact value reads as true
+func isTruthy(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "no", "off", "0", "none", "null":
+		return false
+	}
+	return true
+}
+
+type conditionTokenKind int
+
+const (
+	tokenIdent conditionTokenKind = iota
+	tokenString
+	tokenOperator
+)
+
+type conditionToken struct {
+	kind  conditionTokenKind
+	value string
+}
+
+// tokenizeCondition splits a condition into identifiers, quoted strings and operators
+func tokenizeCondition(condition string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	runes := []rune(condition)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, conditionToken{tokenString, string(runes[i+1 : end])})
+			i = end + 1
+		case i+1 < len(runes) && isTwoCharOperator(string(runes[i:i+2])):
+			tokens = append(tokens, conditionToken{tokenOperator, string(runes[i : i+2])})
+			i += 2
+		case strings.ContainsRune("!()", r):
+			tokens = append(tokens, conditionToken{tokenOperator, string(r)})
+			i++
+		case isConditionIdentRune(r):
+			end := i
+			for end < len(runes) && isConditionIdentRune(runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			switch strings.ToLower(word) {
+			case "and":
+				tokens = append(tokens, conditionToken{tokenOperator, "&&"})
+			case "or":
+				tokens = append(tokens, conditionToken{tokenOperator, "||"})
+			case "not":
+				tokens = append(tokens, conditionToken{tokenOperator, "!"})
+			default:
+				tokens = append(tokens, conditionToken{tokenIdent, word})
+			}
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+func isTwoCharOperator(op string) bool {
+	return op == "==" || op == "!=" || op == "&&" || op == "||"
+}
+
+func isConditionIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./*", r)
+}
+
+func isConditionIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// conditionParser is a recursive descent parser that evaluates as it parses
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+	facts  *conditionFacts
+}
+
+func (p *conditionParser) peekOperator(op string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOperator && p.tokens[p.pos].value == op
+}
+
+func (p *conditionParser) expect(op string) error {
+	if !p.peekOperator(op) {
+		return fmt.Errorf("expected %q", op)
+	}
+	p.pos++
+	return nil
+}
+
+// parseOr parses "and-expression (|| and-expression)*"
+func (p *conditionParser) parseOr() (conditionResult, error) {
+	result, err := p.parseAnd()
+	if err != nil {
+		return conditionUnknown, err
+	}
+	for p.peekOperator("||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return conditionUnknown, err
+		}
+		switch {
+		case result == conditionTrue || right == conditionTrue:
+			result = conditionTrue
+		case result == conditionFalse && right == conditionFalse:
+			result = conditionFalse
+		default:
+			result = conditionUnknown
+		}
+	}
+	return result, nil
+}
+
+// parseAnd parses "unary (&& unary)*"
+func (p *conditionParser) parseAnd() (conditionResult, error) {
+	result, err := p.parseUnary()
+	if err != nil {
+		return conditionUnknown, err
+	}
+	for p.peekOperator("&&") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return conditionUnknown, err
+		}
+		switch {
+		case result == conditionFalse || right == conditionFalse:
+			result = conditionFalse
+		case result == conditionTrue && right == conditionTrue:
+			result = conditionTrue
+		default:
+			result = conditionUnknown
+		}
+	}
+	return result, nil
+}
+
+// parseUnary parses "! unary", "( or-expression )", "exists(glob)" and comparisons
+func (p *conditionParser) parseUnary() (conditionResult, error) {
+	if p.peekOperator("!") {
+		p.pos++
+		result, err := p.parseUnary()
+		switch result {
+		case conditionTrue:
+			return conditionFalse, err
+		case conditionFalse:
+			return conditionTrue, err
+		}
+		return conditionUnknown, err
+	}
+	if p.peekOperator("(") {
+		p.pos++
+		result, err := p.parseOr()
+		if err != nil {
+			return conditionUnknown, err
+		}
+		return result, p.expect(")")
+	}
+	if p.pos >= len(p.tokens) {
+		return conditionUnknown, fmt.Errorf("unexpected end of condition")
+	}
+
+	tok := p.tokens[p.pos]
+	if tok.kind == tokenOperator {
+		return conditionUnknown, fmt.Errorf("unexpected %q", tok.value)
+	}
+	p.pos++
+
+	if tok.kind == tokenIdent && strings.EqualFold(tok.value, "exists") && p.peekOperator("(") {
+		return p.parseExists()
+	}
+
+	if p.peekOperator("==") || p.peekOperator("!=") {
+		op := p.tokens[p.pos].value
+		p.pos++
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind == tokenOperator {
+			return conditionUnknown, fmt.Errorf("expected a value after %q", op)
+		}
+		right := p.tokens[p.pos]
+		p.pos++
+
+		left, known := p.operandValue(tok, false)
+		rightValue, rightKnown := p.operandValue(right, true)
+		if !known || !rightKnown {
+			return conditionUnknown, nil
+		}
+		equal := strings.EqualFold(left, rightValue)
+		return conditionOf(equal == (op == "==")), nil
+	}
+
+	// A lone operand is a truthiness test
+	value, known := p.operandValue(tok, false)
+	if !known {
+		return conditionUnknown, nil
+	}
+	return conditionOf(isTruthy(value)), nil
+}
+
+// parseExists parses the "(glob)" of exists(glob)
+func (p *conditionParser) parseExists() (conditionResult, error) {
+	if err := p.expect("("); err != nil {
+		return conditionUnknown, err
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind == tokenOperator {
+		return conditionUnknown, fmt.Errorf("exists() needs a path")
+	}
+	pattern := p.tokens[p.pos].value
+	p.pos++
+	if err := p.expect(")"); err != nil {
+		return conditionUnknown, err
+	}
+
+	if p.facts.projectRoot == "" || filepath.IsAbs(pattern) || strings.Contains(pattern, "..") {
+		return conditionUnknown, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(p.facts.projectRoot, filepath.FromSlash(pattern)))
+	if err != nil {
+		return conditionUnknown, nil
+	}
+	return conditionOf(len(matches) > 0), nil
+}
+
+// operandValue resolves an operand to its value. An identifier that names no fact is read
+// as a bare literal when literal is set, as on the right-hand side of "field_type == brownfield".
+func (p *conditionParser) operandValue(tok conditionToken, literal bool) (string, bool) {
+	if tok.kind == tokenString {
+		return tok.value, true
+	}
+	if value, ok := p.facts.lookup(tok.value); ok {
+		return value, true
+	}
+	return tok.value, literal
+}
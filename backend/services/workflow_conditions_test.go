@@ -0,0 +1,88 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "docs", "architecture.md"), []byte("# Architecture\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	facts := &conditionFacts{
+		values: map[string]string{
+			"field_type":   "brownfield",
+			"project_type": "web",
+			"has_ui":       "false",
+			"tea_use_mcp":  "true",
+		},
+		artifactTypes: map[string]bool{"prd": true, "ux_design": false},
+		projectRoot:   root,
+	}
+
+	tests := []struct {
+		condition string
+		want      conditionResult
+	}{
+		{"if_has_prd", conditionTrue},
+		{"if_has_ux_design", conditionFalse},
+		{"if_has_ui", conditionFalse}, // config overrides artifacts
+		{"if_tea_use_mcp", conditionTrue},
+		{"if_regulated", conditionUnknown},
+		{"field_type == brownfield", conditionTrue},
+		{"field_type == 'Brownfield'", conditionTrue},
+		{"field_type != brownfield", conditionFalse},
+		{"project_type == web && has_prd", conditionTrue},
+		{"project_type == api or has_ui", conditionFalse},
+		{"!has_ui", conditionTrue},
+		{"not (has_ui || has_ux_design)", conditionTrue},
+		{"regulated", conditionUnknown},
+		{"regulated && has_ui", conditionFalse}, // false regardless of the unknown fact
+		{"regulated || has_prd", conditionTrue},
+		{"regulated || has_ui", conditionUnknown},
+		{"exists('docs/arch*.md')", conditionTrue},
+		{"exists(docs/missing.md)", conditionFalse},
+		{"exists('../outside.md')", conditionUnknown},
+		{"field_type ==", conditionUnknown},
+		{"(has_prd", conditionUnknown},
+		{"has_prd = true", conditionUnknown},
+		{"'unterminated", conditionUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			if got := evaluateCondition(tt.condition, facts); got != tt.want {
+				t.Errorf("evaluateCondition(%q) = %d, want %d", tt.condition, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateCondition_UnknownArtifacts(t *testing.T) {
+	facts := &conditionFacts{values: map[string]string{}}
+
+	if got := evaluateCondition("if_has_ux_design", facts); got != conditionUnknown {
+		t.Errorf("Expected has_ux_design to be unknown without artifacts, got %d", got)
+	}
+	if got := evaluateCondition("exists('docs/*.md')", facts); got != conditionUnknown {
+		t.Errorf("Expected exists() to be unknown without a project root, got %d", got)
+	}
+
+	// A missing UX design doesn't rule out a UI
+	facts.artifactTypes = map[string]bool{"prd": true}
+	if got := evaluateCondition("if_has_ui", facts); got != conditionUnknown {
+		t.Errorf("Expected has_ui to be unknown without a UX design, got %d", got)
+	}
+	facts.artifactTypes["ux_design"] = true
+	if got := evaluateCondition("if_has_ux", facts); got != conditionTrue {
+		t.Errorf("Expected has_ux to follow the UX design, got %d", got)
+	}
+	if got := evaluateCondition("exists('docs/*.md')", facts); got != conditionUnknown {
+		t.Errorf("Expected exists() to be unknown without a project root, got %d", got)
+	}
+}
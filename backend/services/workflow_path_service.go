@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -119,7 +120,7 @@ func (s *WorkflowPathService) LoadPaths() error {
 	return nil
 }
 
-// loadSelectedTrack reads the track from bmm-workflow-status.yaml or returns default
+// loadSelectedTrack reads selected_track (or the legacy track key) from bmm-workflow-status.yaml or returns default
 func (s *WorkflowPathService) loadSelectedTrack(config *types.BMadConfig) string {
 	statusPath := filepath.Join(config.PlanningArtifacts, "bmm-workflow-status.yaml")
 
@@ -135,11 +136,34 @@ func (s *WorkflowPathService) loadSelectedTrack(config *types.BMadConfig) string
 		return "bmad-method"
 	}
 
-	if status.Track == "" {
-		return "bmad-method"
+	if status.SelectedTrack != "" {
+		return status.SelectedTrack
+	}
+	if status.Track != "" {
+		return status.Track
+	}
+	return "bmad-method"
+}
+
+// ReloadSelectedTrack re-reads the selected track from bmm-workflow-status.yaml
+func (s *WorkflowPathService) ReloadSelectedTrack() {
+	config := s.configService.GetConfig()
+	if config == nil {
+		return
 	}
+	selectedTrack := s.loadSelectedTrack(config)
+
+	s.mu.Lock()
+	s.selectedTrack = selectedTrack
+	s.mu.Unlock()
+}
 
-	return status.Track
+// HasTrack reports whether a path definition is loaded for track
+func (s *WorkflowPathService) HasTrack(track string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.pathDefs[track]
+	return ok
 }
 
 // GetSelectedTrack returns the currently selected track name
@@ -173,6 +197,69 @@ func (s *WorkflowPathService) GetPhases() (*types.PhasesResponse, error) {
 	return s.toResponse(pathDef), nil
 }
 
+// GetTracks returns a summary of every loaded path definition, ordered by track name
+func (s *WorkflowPathService) GetTracks() (*types.TracksResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.pathDefs) == 0 {
+		return nil, &WorkflowPathError{
+			Code:    ErrCodePathFilesNotFound,
+			Message: "No path definitions loaded. Call LoadPaths() first.",
+		}
+	}
+
+	tracks := make([]types.TrackSummary, 0, len(s.pathDefs))
+	for track, pathDef := range s.pathDefs {
+		summary := types.TrackSummary{
+			Track:       track,
+			MethodName:  pathDef.MethodName,
+			FieldType:   pathDef.FieldType,
+			Description: pathDef.Description,
+			Selected:    track == s.selectedTrack,
+			PhaseCount:  len(pathDef.Phases),
+		}
+		for _, phase := range pathDef.Phases {
+			for _, wf := range phase.Workflows {
+				summary.WorkflowCount++
+				if wf.Required && !wf.Optional && wf.Conditional == "" {
+					summary.RequiredCount++
+				}
+			}
+		}
+		tracks = append(tracks, summary)
+	}
+	sort.Slice(tracks, func(i, j int) bool { return tracks[i].Track < tracks[j].Track })
+
+	return &types.TracksResponse{
+		SelectedTrack: s.selectedTrack,
+		Tracks:        tracks,
+	}, nil
+}
+
+// GetTrackPhases returns the phases of any loaded track
+func (s *WorkflowPathService) GetTrackPhases(track string) (*types.PhasesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.pathDefs) == 0 {
+		return nil, &WorkflowPathError{
+			Code:    ErrCodePathFilesNotFound,
+			Message: "No path definitions loaded. Call LoadPaths() first.",
+		}
+	}
+
+	pathDef, ok := s.pathDefs[track]
+	if !ok {
+		return nil, &WorkflowPathError{
+			Code:    ErrCodeTrackNotFound,
+			Message: fmt.Sprintf("Track '%s' not found in loaded path definitions", track),
+		}
+	}
+
+	return s.toResponse(pathDef), nil
+}
+
 // resolveWorkflowVariables replaces {project-root} in workflow exec and workflow paths
 func (s *WorkflowPathService) resolveWorkflowVariables(pathDef *types.PathDefinition, projectRoot string) {
 	for i := range pathDef.Phases {
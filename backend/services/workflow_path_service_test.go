@@ -731,3 +731,38 @@ phases: []
 		t.Errorf("Expected error code '%s', got '%s'", ErrCodeTrackNotFound, pathErr.Code)
 	}
 }
+
+func TestGetTracks_ListsAllTracksAndTrackPhases(t *testing.T) {
+	_, _, pathService, _ := setupStatusWriterTest(t, "")
+
+	resp, err := pathService.GetTracks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.SelectedTrack != "bmad-method" {
+		t.Errorf("Expected selected track 'bmad-method', got '%s'", resp.SelectedTrack)
+	}
+	if len(resp.Tracks) != 2 {
+		t.Fatalf("Expected 2 tracks, got %+v", resp.Tracks)
+	}
+	if tr := resp.Tracks[0]; tr.Track != "bmad-method" || tr.MethodName != "BMAD Method" || !tr.Selected || tr.PhaseCount != 2 || tr.RequiredCount != 2 {
+		t.Errorf("Unexpected first track %+v", tr)
+	}
+	if tr := resp.Tracks[1]; tr.Track != "quick-flow" || tr.Selected || tr.WorkflowCount != 1 {
+		t.Errorf("Unexpected second track %+v", tr)
+	}
+
+	phases, err := pathService.GetTrackPhases("quick-flow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if phases.Track != "quick-flow" || len(phases.Phases) != 1 || phases.Phases[0].Workflows[0].ID != "tech-spec" {
+		t.Errorf("Unexpected quick-flow phases %+v", phases)
+	}
+
+	_, err = pathService.GetTrackPhases("enterprise")
+	pathErr, ok := err.(*WorkflowPathError)
+	if !ok || pathErr.Code != ErrCodeTrackNotFound {
+		t.Errorf("Expected track_not_found error, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -29,6 +30,12 @@ const (
 	ErrCodePathsNotLoaded    = "paths_not_loaded"
 )
 
+// StatusChangeListener is notified after the status files have been (re)loaded.
+// Callbacks run on the goroutine that reloaded and must not block for long.
+type StatusChangeListener interface {
+	StatusChanged()
+}
+
 // WorkflowStatusService manages loading and accessing BMAD workflow status
 type WorkflowStatusService struct {
 	mu             sync.RWMutex
@@ -36,6 +43,10 @@ type WorkflowStatusService struct {
 	pathService    *WorkflowPathService
 	workflowStatus *types.WorkflowStatusFile // Parsed bmm-workflow-status.yaml
 	sprintStatus   *types.SprintStatusFile   // Parsed sprint-status.yaml (may be nil)
+	configFacts    map[string]string         // Top-level scalars of config.yaml, for workflow conditions
+
+	artifactService *ArtifactService // Existing artifacts, for has_<type> conditions (may be nil)
+	listeners       []StatusChangeListener
 }
 
 // NewWorkflowStatusService creates a new WorkflowStatusService instance
@@ -80,14 +91,118 @@ func (s *WorkflowStatusService) LoadStatus() error {
 		sprintStatus = nil
 	}
 
+	configFacts := readConfigFacts(config.ProjectRoot)
+
 	s.mu.Lock()
 	s.workflowStatus = workflowStatus
 	s.sprintStatus = sprintStatus
+	s.configFacts = configFacts
 	s.mu.Unlock()
 
+	// Keep the path service on the track the file selects
+	if s.pathService != nil {
+		s.pathService.ReloadSelectedTrack()
+	}
+
+	s.mu.RLock()
+	listeners := s.listeners
+	s.mu.RUnlock()
+	for _, l := range listeners {
+		l.StatusChanged()
+	}
+
 	return nil
 }
 
+// AddListener registers a listener for status file reloads.
+func (s *WorkflowStatusService) AddListener(l StatusChangeListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, l)
+}
+
+// UseArtifacts lets workflow conditions test which artifact types exist (has_prd, has_ux_design, ...).
+// The artifact service is created after this one, so it is attached once it exists.
+func (s *WorkflowStatusService) UseArtifacts(as *ArtifactService) {
+	s.mu.Lock()
+	s.artifactService = as
+	s.mu.Unlock()
+}
+
+// readConfigFacts returns the top-level scalar fields of config.yaml, including ones
+// BMadConfig does not model, such as a project's own "has_ui: true".
+func readConfigFacts(projectRoot string) map[string]string {
+	facts := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(projectRoot, "_bmad", "bmm", "config.yaml"))
+	if err != nil {
+		return facts
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return facts
+	}
+	for key, value := range raw {
+		switch value.(type) {
+		case string, bool, int, float64:
+			facts[strings.ToLower(key)] = fmt.Sprint(value)
+		}
+	}
+	return facts
+}
+
+// existingArtifactTypes returns the set of artifact types the project has, or nil without an artifact service
+func (s *WorkflowStatusService) existingArtifactTypes() map[string]bool {
+	s.mu.RLock()
+	as := s.artifactService
+	s.mu.RUnlock()
+	if as == nil {
+		return nil
+	}
+
+	artifacts, err := as.GetArtifacts()
+	if err != nil {
+		return nil
+	}
+	artifactTypes := make(map[string]bool)
+	for _, artifact := range artifacts {
+		artifactTypes[artifact.Type] = true
+	}
+	return artifactTypes
+}
+
+// conditionFacts gathers the facts workflow conditions of a track are evaluated against.
+// Caller must hold s.mu.
+func (s *WorkflowStatusService) conditionFacts(phases *types.PhasesResponse, artifactTypes map[string]bool) *conditionFacts {
+	facts := &conditionFacts{
+		values:        make(map[string]string, len(s.configFacts)+4),
+		artifactTypes: artifactTypes,
+	}
+	for key, value := range s.configFacts {
+		facts.values[key] = value
+	}
+	if config := s.configService.GetConfig(); config != nil {
+		facts.projectRoot = config.ProjectRoot
+	}
+
+	if s.workflowStatus != nil {
+		if s.workflowStatus.Project != "" {
+			facts.values["project"] = s.workflowStatus.Project
+		}
+		if s.workflowStatus.ProjectType != "" {
+			facts.values["project_type"] = s.workflowStatus.ProjectType
+		}
+		if s.workflowStatus.FieldType != "" {
+			facts.values["field_type"] = s.workflowStatus.FieldType
+		}
+	}
+	// The track's own field type wins, so a comparison sees the target track as selected
+	if phases.FieldType != "" {
+		facts.values["field_type"] = phases.FieldType
+	}
+	facts.values["track"] = phases.Track
+	return facts
+}
+
 // parseWorkflowStatus reads and parses bmm-workflow-status.yaml
 func (s *WorkflowStatusService) parseWorkflowStatus(path string) (*types.WorkflowStatusFile, error) {
 	data, err := os.ReadFile(path)
@@ -152,16 +267,20 @@ func (s *WorkflowStatusService) isComplete(statusValue string) bool {
 	return false
 }
 
-// isWorkflowRequired determines if a workflow is required (not optional and not conditional)
-func (s *WorkflowStatusService) isWorkflowRequired(wf types.WorkflowResponse) bool {
-	return wf.Required && !wf.Optional && wf.Conditional == nil
+// isWorkflowRequired determines if a workflow is required: not optional, and either
+// unconditional or with a condition the project facts meet
+func (s *WorkflowStatusService) isWorkflowRequired(wf types.WorkflowResponse, facts *conditionFacts) bool {
+	if !wf.Required || wf.Optional {
+		return false
+	}
+	return wf.Conditional == nil || evaluateCondition(*wf.Conditional, facts) == conditionTrue
 }
 
 // computeCurrentPhase finds the first phase with an incomplete required workflow
-func (s *WorkflowStatusService) computeCurrentPhase(phases []types.PhaseResponse, statuses map[string]string) (int, string) {
+func (s *WorkflowStatusService) computeCurrentPhase(phases []types.PhaseResponse, statuses map[string]string, facts *conditionFacts) (int, string) {
 	for _, phase := range phases {
 		for _, wf := range phase.Workflows {
-			if s.isWorkflowRequired(wf) {
+			if s.isWorkflowRequired(wf, facts) {
 				status, exists := statuses[wf.ID]
 				if !exists || !s.isComplete(status) {
 					return phase.PhaseNum, phase.Name
@@ -178,10 +297,10 @@ func (s *WorkflowStatusService) computeCurrentPhase(phases []types.PhaseResponse
 }
 
 // computeNextWorkflow finds the first incomplete required workflow and its agent
-func (s *WorkflowStatusService) computeNextWorkflow(phases []types.PhaseResponse, statuses map[string]string) (*string, *string) {
+func (s *WorkflowStatusService) computeNextWorkflow(phases []types.PhaseResponse, statuses map[string]string, facts *conditionFacts) (*string, *string) {
 	for _, phase := range phases {
 		for _, wf := range phase.Workflows {
-			if s.isWorkflowRequired(wf) {
+			if s.isWorkflowRequired(wf, facts) {
 				status, exists := statuses[wf.ID]
 				if !exists || !s.isComplete(status) {
 					wfID := wf.ID
@@ -196,7 +315,7 @@ func (s *WorkflowStatusService) computeNextWorkflow(phases []types.PhaseResponse
 }
 
 // computePhaseCompletion calculates completion stats for each phase
-func (s *WorkflowStatusService) computePhaseCompletion(phases []types.PhaseResponse, statuses map[string]string) []types.PhaseCompletionStatus {
+func (s *WorkflowStatusService) computePhaseCompletion(phases []types.PhaseResponse, statuses map[string]string, facts *conditionFacts) []types.PhaseCompletionStatus {
 	result := make([]types.PhaseCompletionStatus, 0, len(phases))
 
 	for _, phase := range phases {
@@ -204,7 +323,7 @@ func (s *WorkflowStatusService) computePhaseCompletion(phases []types.PhaseRespo
 		completed := 0
 
 		for _, wf := range phase.Workflows {
-			if s.isWorkflowRequired(wf) {
+			if s.isWorkflowRequired(wf, facts) {
 				totalRequired++
 				status, exists := statuses[wf.ID]
 				if exists && s.isComplete(status) {
@@ -234,7 +353,7 @@ func (s *WorkflowStatusService) computePhaseCompletion(phases []types.PhaseRespo
 }
 
 // computeWorkflowStatuses builds the workflow status map for the response
-func (s *WorkflowStatusService) computeWorkflowStatuses(phases []types.PhaseResponse, statuses map[string]string) map[string]types.WorkflowCompletionStatus {
+func (s *WorkflowStatusService) computeWorkflowStatuses(phases []types.PhaseResponse, statuses map[string]string, facts *conditionFacts) map[string]types.WorkflowCompletionStatus {
 	result := make(map[string]types.WorkflowCompletionStatus)
 
 	for _, phase := range phases {
@@ -244,17 +363,28 @@ func (s *WorkflowStatusService) computeWorkflowStatuses(phases []types.PhaseResp
 			var status string
 			var artifactPath *string
 			var isComplete bool
+			var conditionMet *bool
+
+			isApplicable := true
+			if wf.Conditional != nil {
+				if result := evaluateCondition(*wf.Conditional, facts); result != conditionUnknown {
+					met := result == conditionTrue
+					conditionMet = &met
+					isApplicable = met
+				}
+			}
 
-			if !exists {
-				status = types.StatusNotStarted
-				isComplete = false
-			} else if s.isComplete(statusValue) {
+			if exists && s.isComplete(statusValue) {
 				status = types.StatusComplete
 				isComplete = true
 				// If it's a file path, set artifact path
 				if looksLikeFilePath(statusValue) {
 					artifactPath = &statusValue
 				}
+			} else if !isApplicable {
+				status = types.StatusNotApplicable
+			} else if !exists {
+				status = types.StatusNotStarted
 			} else {
 				status = statusValue
 				isComplete = false
@@ -265,8 +395,10 @@ func (s *WorkflowStatusService) computeWorkflowStatuses(phases []types.PhaseResp
 				Status:       status,
 				ArtifactPath: artifactPath,
 				IsComplete:   isComplete,
-				IsRequired:   s.isWorkflowRequired(wf),
+				IsRequired:   s.isWorkflowRequired(wf, facts),
 				IsOptional:   wf.Optional,
+				IsApplicable: isApplicable,
+				ConditionMet: conditionMet,
 			}
 		}
 	}
@@ -280,6 +412,129 @@ func (s *WorkflowStatusService) Reload() error {
 	return s.LoadStatus()
 }
 
+// GetWorkflowStatuses returns a copy of the raw workflow_status entries of bmm-workflow-status.yaml,
+// or nil if the project has no such file
+func (s *WorkflowStatusService) GetWorkflowStatuses() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.workflowStatus == nil {
+		return nil
+	}
+	statuses := make(map[string]string, len(s.workflowStatus.WorkflowStatus))
+	for id, value := range s.workflowStatus.WorkflowStatus {
+		statuses[id] = value
+	}
+	return statuses
+}
+
+// GetSprintStatus returns a copy of the parsed sprint-status.yaml, or nil if the project has none
+func (s *WorkflowStatusService) GetSprintStatus() *types.SprintStatusFile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.sprintStatus == nil {
+		return nil
+	}
+	status := *s.sprintStatus
+	status.DevelopmentStatus = make(map[string]string, len(s.sprintStatus.DevelopmentStatus))
+	for key, value := range s.sprintStatus.DevelopmentStatus {
+		status.DevelopmentStatus[key] = value
+	}
+	return &status
+}
+
+// CompareTrack previews the selected track's progress carried over to another track
+func (s *WorkflowStatusService) CompareTrack(track string) (*types.TrackComparisonResponse, error) {
+	// Get phases from path service OUTSIDE the lock (I/O operation)
+	current, err := s.pathService.GetPhases()
+	if err != nil {
+		return nil, &WorkflowStatusError{
+			Code:    ErrCodePathsNotLoaded,
+			Message: fmt.Sprintf("Failed to get phases: %v", err),
+		}
+	}
+	target, err := s.pathService.GetTrackPhases(track)
+	if err != nil {
+		return nil, err
+	}
+	artifactTypes := s.existingArtifactTypes()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	currentFacts := s.conditionFacts(current, artifactTypes)
+	targetFacts := s.conditionFacts(target, artifactTypes)
+
+	workflowStatuses := make(map[string]string)
+	if s.workflowStatus != nil && s.workflowStatus.WorkflowStatus != nil {
+		workflowStatuses = s.workflowStatus.WorkflowStatus
+	}
+	complete := func(id string) bool {
+		status, exists := workflowStatuses[id]
+		return exists && s.isComplete(status)
+	}
+
+	currentRequired := make(map[string]bool)
+	for _, phase := range current.Phases {
+		for _, wf := range phase.Workflows {
+			if s.isWorkflowRequired(wf, currentFacts) {
+				currentRequired[wf.ID] = true
+			}
+		}
+	}
+
+	response := &types.TrackComparisonResponse{
+		FromTrack:         current.Track,
+		ToTrack:           target.Track,
+		CarriedOver:       []string{},
+		NotInTarget:       []string{},
+		NewlyRequired:     []string{},
+		RemainingRequired: []string{},
+	}
+
+	inTarget := make(map[string]bool)
+	for _, phase := range target.Phases {
+		for _, wf := range phase.Workflows {
+			inTarget[wf.ID] = true
+			switch {
+			case complete(wf.ID):
+				response.CarriedOver = append(response.CarriedOver, wf.ID)
+			case s.isWorkflowRequired(wf, targetFacts):
+				response.RemainingRequired = append(response.RemainingRequired, wf.ID)
+				if !currentRequired[wf.ID] {
+					response.NewlyRequired = append(response.NewlyRequired, wf.ID)
+				}
+			}
+		}
+	}
+
+	// Completed work the target drops: current track order first, then entries of no track
+	seen := make(map[string]bool)
+	for _, phase := range current.Phases {
+		for _, wf := range phase.Workflows {
+			seen[wf.ID] = true
+			if complete(wf.ID) && !inTarget[wf.ID] {
+				response.NotInTarget = append(response.NotInTarget, wf.ID)
+			}
+		}
+	}
+	var others []string
+	for id := range workflowStatuses {
+		if !seen[id] && !inTarget[id] && complete(id) {
+			others = append(others, id)
+		}
+	}
+	sort.Strings(others)
+	response.NotInTarget = append(response.NotInTarget, others...)
+
+	response.CurrentPhase, response.CurrentPhaseName = s.computeCurrentPhase(target.Phases, workflowStatuses, targetFacts)
+	response.NextWorkflowID, response.NextWorkflowAgent = s.computeNextWorkflow(target.Phases, workflowStatuses, targetFacts)
+	response.PhaseCompletion = s.computePhaseCompletion(target.Phases, workflowStatuses, targetFacts)
+
+	return response, nil
+}
+
 // GetStatus returns the computed status response
 func (s *WorkflowStatusService) GetStatus() (*types.StatusResponse, error) {
 	// Get phases from path service OUTSIDE the lock (I/O operation)
@@ -291,6 +546,8 @@ func (s *WorkflowStatusService) GetStatus() (*types.StatusResponse, error) {
 		}
 	}
 
+	artifactTypes := s.existingArtifactTypes()
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -299,10 +556,11 @@ func (s *WorkflowStatusService) GetStatus() (*types.StatusResponse, error) {
 	if s.workflowStatus != nil && s.workflowStatus.WorkflowStatus != nil {
 		workflowStatuses = s.workflowStatus.WorkflowStatus
 	}
+	facts := s.conditionFacts(phasesResp, artifactTypes)
 
 	// Compute current phase and next workflow
-	currentPhase, currentPhaseName := s.computeCurrentPhase(phasesResp.Phases, workflowStatuses)
-	nextWorkflowID, nextWorkflowAgent := s.computeNextWorkflow(phasesResp.Phases, workflowStatuses)
+	currentPhase, currentPhaseName := s.computeCurrentPhase(phasesResp.Phases, workflowStatuses, facts)
+	nextWorkflowID, nextWorkflowAgent := s.computeNextWorkflow(phasesResp.Phases, workflowStatuses, facts)
 
 	// Build response
 	response := &types.StatusResponse{
@@ -310,8 +568,8 @@ func (s *WorkflowStatusService) GetStatus() (*types.StatusResponse, error) {
 		CurrentPhaseName:  currentPhaseName,
 		NextWorkflowID:    nextWorkflowID,
 		NextWorkflowAgent: nextWorkflowAgent,
-		PhaseCompletion:   s.computePhaseCompletion(phasesResp.Phases, workflowStatuses),
-		WorkflowStatuses:  s.computeWorkflowStatuses(phasesResp.Phases, workflowStatuses),
+		PhaseCompletion:   s.computePhaseCompletion(phasesResp.Phases, workflowStatuses, facts),
+		WorkflowStatuses:  s.computeWorkflowStatuses(phasesResp.Phases, workflowStatuses, facts),
 	}
 
 	// Add story statuses if sprint status exists
@@ -3,6 +3,7 @@ package services
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"bmad-studio/backend/types"
@@ -1214,3 +1215,231 @@ func strPtrEqual(a, b *string) bool {
 	}
 	return *a == *b
 }
+
+func TestWorkflowStatusService_CompareTrack(t *testing.T) {
+	_, statusService, pathService, root := setupStatusWriterTest(t, `workflow_status:
+  product-brief: _bmad-output/planning-artifacts/brief.md
+  prd: required
+  research: docs/research.md
+`)
+
+	enterprise := `method_name: "Enterprise Method"
+track: "enterprise"
+phases:
+  - phase: 1
+    name: "Planning"
+    workflows:
+      - id: "prd"
+        required: true
+      - id: "security-review"
+        required: true
+  - phase: 2
+    name: "Solutioning"
+    workflows:
+      - id: "product-brief"
+        optional: true
+      - id: "threat-model"
+        required: true
+        conditional: "if_regulated"
+`
+	pathsDir := filepath.Join(root, "_bmad", "bmm", "workflows", "workflow-status", "paths")
+	if err := os.WriteFile(filepath.Join(pathsDir, "enterprise.yaml"), []byte(enterprise), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pathService.LoadPaths(); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := statusService.CompareTrack("enterprise")
+	if err != nil {
+		t.Fatalf("CompareTrack failed: %v", err)
+	}
+	if resp.FromTrack != "bmad-method" || resp.ToTrack != "enterprise" {
+		t.Errorf("Unexpected tracks %s -> %s", resp.FromTrack, resp.ToTrack)
+	}
+
+	check := func(name string, got, want []string) {
+		t.Helper()
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Expected %s %v, got %v", name, want, got)
+		}
+	}
+	check("carried_over", resp.CarriedOver, []string{"product-brief"})
+	check("not_in_target", resp.NotInTarget, []string{"research"})
+	check("newly_required", resp.NewlyRequired, []string{"security-review"})
+	check("remaining_required", resp.RemainingRequired, []string{"prd", "security-review"})
+
+	if resp.CurrentPhase != 1 || resp.NextWorkflowID == nil || *resp.NextWorkflowID != "prd" {
+		t.Errorf("Expected phase 1 with next workflow prd, got %d/%v", resp.CurrentPhase, resp.NextWorkflowID)
+	}
+	if len(resp.PhaseCompletion) != 2 || resp.PhaseCompletion[0].TotalRequired != 2 || resp.PhaseCompletion[1].PercentComplete != 100 {
+		t.Errorf("Unexpected phase completion %+v", resp.PhaseCompletion)
+	}
+	if pathService.GetSelectedTrack() != "bmad-method" {
+		t.Error("Expected comparison to leave the selected track unchanged")
+	}
+
+	_, err = statusService.CompareTrack("unknown")
+	if pathErr, ok := err.(*WorkflowPathError); !ok || pathErr.Code != ErrCodeTrackNotFound {
+		t.Errorf("Expected track_not_found error, got %v", err)
+	}
+}
+
+func TestWorkflowStatusService_EvaluatesConditionalWorkflows(t *testing.T) {
+	_, statusService, pathService, root := setupStatusWriterTest(t, `project_type: web
+workflow_status:
+  prd: _bmad-output/planning-artifacts/prd.md
+`)
+
+	pathDef := `method_name: "BMAD Method"
+track: "bmad-method"
+field_type: "greenfield"
+phases:
+  - phase: 1
+    name: "Planning"
+    workflows:
+      - id: "prd"
+        required: true
+      - id: "create-ux-design"
+        required: true
+        conditional: "if_has_ui"
+      - id: "threat-model"
+        required: true
+        conditional: "project_type == regulated"
+      - id: "migration-plan"
+        required: true
+        conditional: "if_legacy_system"
+`
+	pathsDir := filepath.Join(root, "_bmad", "bmm", "workflows", "workflow-status", "paths")
+	if err := os.WriteFile(filepath.Join(pathsDir, "method-greenfield.yaml"), []byte(pathDef), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pathService.LoadPaths(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without UX artifacts or a has_ui config field, has_ui is undecidable
+	status, err := statusService.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ux := status.WorkflowStatuses["create-ux-design"]; ux.IsRequired || !ux.IsApplicable || ux.ConditionMet != nil {
+		t.Errorf("Expected undecidable create-ux-design to stay optional, got %+v", ux)
+	}
+	threat := status.WorkflowStatuses["threat-model"]
+	if threat.Status != types.StatusNotApplicable || threat.IsApplicable || threat.ConditionMet == nil || *threat.ConditionMet {
+		t.Errorf("Expected threat-model not applicable, got %+v", threat)
+	}
+	if status.PhaseCompletion[0].TotalRequired != 1 || status.PhaseCompletion[0].PercentComplete != 100 {
+		t.Errorf("Expected only prd required, got %+v", status.PhaseCompletion[0])
+	}
+
+	// A config field makes the condition true, so the workflow becomes required and blocks
+	configPath := filepath.Join(root, "_bmad", "bmm", "config.yaml")
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, append(config, "has_ui: true\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := statusService.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err = statusService.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ux := status.WorkflowStatuses["create-ux-design"]
+	if !ux.IsRequired || ux.Status != types.StatusNotStarted || ux.ConditionMet == nil || !*ux.ConditionMet {
+		t.Errorf("Expected create-ux-design required, got %+v", ux)
+	}
+	if status.NextWorkflowID == nil || *status.NextWorkflowID != "create-ux-design" {
+		t.Errorf("Expected next workflow create-ux-design, got %v", status.NextWorkflowID)
+	}
+	if status.PhaseCompletion[0].TotalRequired != 2 || status.PhaseCompletion[0].PercentComplete != 50 {
+		t.Errorf("Expected prd and create-ux-design required, got %+v", status.PhaseCompletion[0])
+	}
+}
+
+func TestWorkflowStatusService_ConditionsUseExistingArtifacts(t *testing.T) {
+	_, statusService, pathService, root := setupStatusWriterTest(t, "")
+
+	pathDef := `method_name: "BMAD Method"
+track: "bmad-method"
+phases:
+  - phase: 1
+    name: "Planning"
+    workflows:
+      - id: "validate-prd"
+        required: true
+        conditional: "if_has_prd"
+      - id: "review-ux"
+        required: true
+        conditional: "has_ux_design"
+`
+	pathsDir := filepath.Join(root, "_bmad", "bmm", "workflows", "workflow-status", "paths")
+	if err := os.WriteFile(filepath.Join(pathsDir, "method-greenfield.yaml"), []byte(pathDef), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pathService.LoadPaths(); err != nil {
+		t.Fatal(err)
+	}
+
+	artifactService := NewArtifactService(statusService.configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	statusService.UseArtifacts(artifactService)
+
+	status, err := statusService.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.WorkflowStatuses["validate-prd"].IsRequired {
+		t.Errorf("Expected validate-prd required with a PRD present, got %+v", status.WorkflowStatuses["validate-prd"])
+	}
+	if review := status.WorkflowStatuses["review-ux"]; review.Status != types.StatusNotApplicable {
+		t.Errorf("Expected review-ux not applicable without a UX design, got %+v", review)
+	}
+}
+
+func TestWorkflowStatusService_UIConditionsWithArtifactsAttached(t *testing.T) {
+	_, statusService, pathService, root := setupStatusWriterTest(t, "")
+
+	pathDef := `method_name: "BMAD Method"
+track: "bmad-method"
+phases:
+  - phase: 1
+    name: "Planning"
+    workflows:
+      - id: "prd"
+        required: true
+      - id: "create-ux-design"
+        required: true
+        conditional: "if_has_ui"
+`
+	pathsDir := filepath.Join(root, "_bmad", "bmm", "workflows", "workflow-status", "paths")
+	if err := os.WriteFile(filepath.Join(pathsDir, "method-greenfield.yaml"), []byte(pathDef), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := pathService.LoadPaths(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The project has artifacts but no UX design yet, as when the workflow is still to be run
+	artifactService := NewArtifactService(statusService.configService, nil)
+	if err := artifactService.LoadArtifacts(); err != nil {
+		t.Fatal(err)
+	}
+	statusService.UseArtifacts(artifactService)
+
+	status, err := statusService.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ux := status.WorkflowStatuses["create-ux-design"]; !ux.IsApplicable || ux.Status == types.StatusNotApplicable || ux.ConditionMet != nil {
+		t.Errorf("Expected create-ux-design to stay applicable without a UX design, got %+v", ux)
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"bmad-studio/backend/api/websocket"
+	"bmad-studio/backend/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error codes for workflow status writes
+const (
+	ErrCodeWorkflowNotFound      = "workflow_not_found"
+	ErrCodeInvalidWorkflowStatus = "invalid_workflow_status"
+	ErrCodeStatusWriteFailed     = "status_write_failed"
+)
+
+// settableWorkflowStatuses are the keywords a workflow_status entry may be set to besides an artifact path
+var settableWorkflowStatuses = []string{
+	types.StatusRequired,
+	types.StatusOptional,
+	types.StatusRecommended,
+	types.StatusConditional,
+	types.StatusSkipped,
+	types.StatusNotStarted,
+}
+
+// WorkflowStatusWriter updates bmm-workflow-status.yaml: the workflow_status entries and the
+// selected track. Only the changed values are rewritten, so comments and layout are kept.
+type WorkflowStatusWriter struct {
+	mu                    sync.Mutex // Serialises writes
+	configService         *BMadConfigService
+	pathService           *WorkflowPathService
+	workflowStatusService *WorkflowStatusService
+	hub                   *websocket.Hub
+}
+
+// NewWorkflowStatusWriter creates a new WorkflowStatusWriter.
+// hub may be nil, in which case no events are broadcast.
+func NewWorkflowStatusWriter(configService *BMadConfigService, pathService *WorkflowPathService, workflowStatusService *WorkflowStatusService, hub *websocket.Hub) *WorkflowStatusWriter {
+	return &WorkflowStatusWriter{
+		configService:         configService,
+		pathService:           pathService,
+		workflowStatusService: workflowStatusService,
+		hub:                   hub,
+	}
+}
+
+// SetWorkflowStatus sets the workflow_status entry of a workflow of the selected track, either
+// to a status keyword or to the path of the artifact that completed it, and returns the new status.
+func (w *WorkflowStatusWriter) SetWorkflowStatus(workflowID string, req types.WorkflowStatusUpdateRequest) (*types.StatusResponse, error) {
+	config := w.configService.GetConfig()
+	if config == nil {
+		return nil, &WorkflowStatusError{
+			Code:    ErrCodeConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded (can't determine paths)",
+		}
+	}
+
+	value, err := workflowStatusValue(config.ProjectRoot, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.checkWorkflow(workflowID); err != nil {
+		return nil, err
+	}
+
+	if err := w.write(config, value, "workflow_status", workflowID); err != nil {
+		return nil, err
+	}
+	return w.reload()
+}
+
+// SetSelectedTrack selects the path definition track of the project and returns the new status.
+// Files still using the legacy track key have it updated too.
+func (w *WorkflowStatusWriter) SetSelectedTrack(track string) (*types.StatusResponse, error) {
+	config := w.configService.GetConfig()
+	if config == nil {
+		return nil, &WorkflowStatusError{
+			Code:    ErrCodeConfigNotLoaded,
+			Message: "BMadConfigService has no config loaded (can't determine paths)",
+		}
+	}
+	if !w.pathService.HasTrack(track) {
+		return nil, &WorkflowStatusError{
+			Code:    ErrCodeTrackNotFound,
+			Message: fmt.Sprintf("Track '%s' not found in loaded path definitions", track),
+		}
+	}
+
+	if err := w.write(config, track, "selected_track"); err != nil {
+		return nil, err
+	}
+	if legacy := w.legacyTrack(config); legacy != "" && legacy != track {
+		if err := w.write(config, track, "track"); err != nil {
+			return nil, err
+		}
+	}
+	return w.reload()
+}
+
+// workflowStatusValue validates an update request and returns the value to store.
+func workflowStatusValue(projectRoot string, req types.WorkflowStatusUpdateRequest) (string, error) {
+	if (req.Status == "") == (req.ArtifactPath == "") {
+		return "", &WorkflowStatusError{
+			Code:    ErrCodeInvalidWorkflowStatus,
+			Message: "Exactly one of status and artifact_path must be set",
+		}
+	}
+
+	if req.Status != "" {
+		for _, keyword := range settableWorkflowStatuses {
+			if req.Status == keyword {
+				return req.Status, nil
+			}
+		}
+		return "", &WorkflowStatusError{
+			Code:    ErrCodeInvalidWorkflowStatus,
+			Message: fmt.Sprintf("Invalid workflow status %q: must be %s or an artifact_path", req.Status, strings.Join(settableWorkflowStatuses, ", ")),
+		}
+	}
+
+	path := filepath.ToSlash(req.ArtifactPath)
+	clean := filepath.ToSlash(filepath.Clean(req.ArtifactPath))
+	if filepath.IsAbs(req.ArtifactPath) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", &WorkflowStatusError{
+			Code:    ErrCodeInvalidWorkflowStatus,
+			Message: fmt.Sprintf("Artifact path %q must be relative to the project root", req.ArtifactPath),
+		}
+	}
+	if !looksLikeFilePath(path) {
+		return "", &WorkflowStatusError{
+			Code:    ErrCodeInvalidWorkflowStatus,
+			Message: fmt.Sprintf("Artifact path %q must name a .md or .yaml file or contain a directory", req.ArtifactPath),
+		}
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(clean))); err != nil {
+		return "", &WorkflowStatusError{
+			Code:    ErrCodeInvalidWorkflowStatus,
+			Message: fmt.Sprintf("Artifact path %q does not exist", req.ArtifactPath),
+		}
+	}
+	return path, nil
+}
+
+// checkWorkflow returns an error unless workflowID is a workflow of the selected track.
+func (w *WorkflowStatusWriter) checkWorkflow(workflowID string) error {
+	phases, err := w.pathService.GetPhases()
+	if err != nil {
+		return &WorkflowStatusError{
+			Code:    ErrCodePathsNotLoaded,
+			Message: fmt.Sprintf("Failed to get phases: %v", err),
+		}
+	}
+	for _, phase := range phases.Phases {
+		for _, wf := range phase.Workflows {
+			if wf.ID == workflowID {
+				return nil
+			}
+		}
+	}
+	return &WorkflowStatusError{
+		Code:    ErrCodeWorkflowNotFound,
+		Message: fmt.Sprintf("Workflow '%s' not found in track '%s'", workflowID, phases.Track),
+	}
+}
+
+// write sets the value at path in bmm-workflow-status.yaml, creating the file if the project has none.
+func (w *WorkflowStatusWriter) write(config *types.BMadConfig, value string, path ...string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	statusPath := filepath.Join(config.PlanningArtifacts, "bmm-workflow-status.yaml")
+	content, err := os.ReadFile(statusPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return &WorkflowStatusError{Code: ErrCodeStatusWriteFailed, Message: fmt.Sprintf("Failed to read bmm-workflow-status.yaml: %v", err)}
+	}
+
+	updated, _, err := setYAMLValue(content, value, path...)
+	if err != nil {
+		return &WorkflowStatusError{Code: ErrCodeStatusWriteFailed, Message: fmt.Sprintf("Failed to update bmm-workflow-status.yaml: %v", err)}
+	}
+	if err := os.MkdirAll(config.PlanningArtifacts, 0755); err != nil {
+		return &WorkflowStatusError{Code: ErrCodeStatusWriteFailed, Message: fmt.Sprintf("Failed to create planning artifacts folder: %v", err)}
+	}
+	if err := writeFileAtomic(statusPath, updated); err != nil {
+		return &WorkflowStatusError{Code: ErrCodeStatusWriteFailed, Message: fmt.Sprintf("Failed to write bmm-workflow-status.yaml: %v", err)}
+	}
+	return nil
+}
+
+// legacyTrack returns the value of the legacy track key of bmm-workflow-status.yaml, or "".
+func (w *WorkflowStatusWriter) legacyTrack(config *types.BMadConfig) string {
+	data, err := os.ReadFile(filepath.Join(config.PlanningArtifacts, "bmm-workflow-status.yaml"))
+	if err != nil {
+		return ""
+	}
+	var status types.WorkflowStatus
+	if err := yaml.Unmarshal(data, &status); err != nil {
+		return ""
+	}
+	return status.Track
+}
+
+// reload re-reads the status files, broadcasts the new workflow statuses and returns the status.
+func (w *WorkflowStatusWriter) reload() (*types.StatusResponse, error) {
+	if err := w.workflowStatusService.Reload(); err != nil {
+		log.Printf("Warning: Failed to reload workflow status: %v", err)
+	}
+	status, err := w.workflowStatusService.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	if w.hub != nil {
+		w.hub.BroadcastEvent(types.NewWorkflowStatusChangedEvent(status.WorkflowStatuses))
+	}
+	return status, nil
+}
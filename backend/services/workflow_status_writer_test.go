@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+const writerStatusFile = `# Workflow status for test-project
+generated: "2026-01-27"
+project: "test-project"
+selected_track: "bmad-method"
+
+workflow_status:
+  # Phase 1
+  product-brief: required
+
+  # Phase 2
+  prd: required # Next up
+`
+
+// setupStatusWriterTest creates a project with two tracks and the given bmm-workflow-status.yaml
+// (none if statusContent is empty) and returns a writer over it and the project root.
+func setupStatusWriterTest(t *testing.T, statusContent string) (*WorkflowStatusWriter, *WorkflowStatusService, *WorkflowPathService, string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	bmadDir := filepath.Join(tmpDir, "_bmad", "bmm")
+	pathsDir := filepath.Join(bmadDir, "workflows", "workflow-status", "paths")
+	planningDir := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts")
+	for _, dir := range []string{pathsDir, planningDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configContent := `project_name: test
+planning_artifacts: "{project-root}/_bmad-output/planning-artifacts"
+implementation_artifacts: "{project-root}/_bmad-output/implementation-artifacts"
+output_folder: "{project-root}/_bmad-output"
+`
+	files := map[string]string{
+		filepath.Join(bmadDir, "config.yaml"): configContent,
+		filepath.Join(pathsDir, "method-greenfield.yaml"): `method_name: "BMAD Method"
+track: "bmad-method"
+phases:
+  - phase: 1
+    name: "Analysis"
+    workflows:
+      - id: "product-brief"
+        required: true
+  - phase: 2
+    name: "Planning"
+    workflows:
+      - id: "prd"
+        required: true
+`,
+		filepath.Join(pathsDir, "quick-flow.yaml"): `method_name: "Quick Flow"
+track: "quick-flow"
+phases:
+  - phase: 1
+    name: "Spec"
+    workflows:
+      - id: "tech-spec"
+        required: true
+`,
+		filepath.Join(planningDir, "prd.md"): "# PRD\n",
+	}
+	if statusContent != "" {
+		files[filepath.Join(planningDir, "bmm-workflow-status.yaml")] = statusContent
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configService := NewBMadConfigService()
+	if err := configService.LoadConfig(tmpDir); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	pathService := NewWorkflowPathService(configService)
+	if err := pathService.LoadPaths(); err != nil {
+		t.Fatalf("Failed to load paths: %v", err)
+	}
+	statusService := NewWorkflowStatusService(configService, pathService)
+	if err := statusService.LoadStatus(); err != nil {
+		t.Fatalf("Failed to load status: %v", err)
+	}
+	return NewWorkflowStatusWriter(configService, pathService, statusService, nil), statusService, pathService, tmpDir
+}
+
+func readStatusFile(t *testing.T, root string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, "_bmad-output", "planning-artifacts", "bmm-workflow-status.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWorkflowStatusWriter_SetStatusKeyword(t *testing.T) {
+	writer, _, _, root := setupStatusWriterTest(t, writerStatusFile)
+
+	status, err := writer.SetWorkflowStatus("product-brief", types.WorkflowStatusUpdateRequest{Status: types.StatusSkipped})
+	if err != nil {
+		t.Fatalf("SetWorkflowStatus failed: %v", err)
+	}
+	if !status.WorkflowStatuses["product-brief"].IsComplete {
+		t.Error("Expected skipped product-brief to be complete")
+	}
+	if status.NextWorkflowID == nil || *status.NextWorkflowID != "prd" {
+		t.Errorf("Expected next workflow prd, got %v", status.NextWorkflowID)
+	}
+
+	want := strings.Replace(writerStatusFile, "product-brief: required", "product-brief: skipped", 1)
+	if got := readStatusFile(t, root); got != want {
+		t.Errorf("Expected only the value to change, got:\n%s", got)
+	}
+}
+
+func TestWorkflowStatusWriter_SetArtifactPath(t *testing.T) {
+	writer, _, _, root := setupStatusWriterTest(t, writerStatusFile)
+
+	status, err := writer.SetWorkflowStatus("prd", types.WorkflowStatusUpdateRequest{ArtifactPath: "_bmad-output/planning-artifacts/prd.md"})
+	if err != nil {
+		t.Fatalf("SetWorkflowStatus failed: %v", err)
+	}
+	prd := status.WorkflowStatuses["prd"]
+	if !prd.IsComplete || prd.ArtifactPath == nil || *prd.ArtifactPath != "_bmad-output/planning-artifacts/prd.md" {
+		t.Errorf("Expected prd complete with artifact path, got %+v", prd)
+	}
+
+	got := readStatusFile(t, root)
+	if !strings.Contains(got, "prd: _bmad-output/planning-artifacts/prd.md # Next up\n") {
+		t.Errorf("Expected the path in place with its comment kept, got:\n%s", got)
+	}
+	if !strings.Contains(got, "# Phase 1\n  product-brief: required\n\n  # Phase 2") {
+		t.Errorf("Expected comments and blank lines kept, got:\n%s", got)
+	}
+}
+
+func TestWorkflowStatusWriter_AddsMissingEntry(t *testing.T) {
+	writer, statusService, _, root := setupStatusWriterTest(t, "")
+
+	if _, err := writer.SetWorkflowStatus("prd", types.WorkflowStatusUpdateRequest{Status: types.StatusOptional}); err != nil {
+		t.Fatalf("SetWorkflowStatus failed: %v", err)
+	}
+	if got := readStatusFile(t, root); got != "workflow_status:\n  prd: optional\n" {
+		t.Errorf("Unexpected status file:\n%s", got)
+	}
+	status, err := statusService.GetStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.WorkflowStatuses["prd"].Status != types.StatusOptional {
+		t.Errorf("Expected prd optional after reload, got %q", status.WorkflowStatuses["prd"].Status)
+	}
+}
+
+func TestWorkflowStatusWriter_RejectsInvalidRequests(t *testing.T) {
+	writer, _, _, root := setupStatusWriterTest(t, writerStatusFile)
+
+	tests := []struct {
+		name       string
+		workflowID string
+		req        types.WorkflowStatusUpdateRequest
+		wantCode   string
+	}{
+		{"neither set", "prd", types.WorkflowStatusUpdateRequest{}, ErrCodeInvalidWorkflowStatus},
+		{"both set", "prd", types.WorkflowStatusUpdateRequest{Status: "skipped", ArtifactPath: "_bmad-output/planning-artifacts/prd.md"}, ErrCodeInvalidWorkflowStatus},
+		{"unknown keyword", "prd", types.WorkflowStatusUpdateRequest{Status: "complete"}, ErrCodeInvalidWorkflowStatus},
+		{"missing artifact", "prd", types.WorkflowStatusUpdateRequest{ArtifactPath: "docs/missing.md"}, ErrCodeInvalidWorkflowStatus},
+		{"escaping artifact", "prd", types.WorkflowStatusUpdateRequest{ArtifactPath: "../outside.md"}, ErrCodeInvalidWorkflowStatus},
+		{"unknown workflow", "tech-spec", types.WorkflowStatusUpdateRequest{Status: "skipped"}, ErrCodeWorkflowNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := writer.SetWorkflowStatus(tt.workflowID, tt.req)
+			var statusErr *WorkflowStatusError
+			if !errors.As(err, &statusErr) || statusErr.Code != tt.wantCode {
+				t.Errorf("Expected %s error, got %v", tt.wantCode, err)
+			}
+		})
+	}
+
+	if got := readStatusFile(t, root); got != writerStatusFile {
+		t.Errorf("Expected status file unchanged, got:\n%s", got)
+	}
+}
+
+func TestWorkflowStatusWriter_SetSelectedTrack(t *testing.T) {
+	writer, _, pathService, root := setupStatusWriterTest(t, writerStatusFile)
+
+	status, err := writer.SetSelectedTrack("quick-flow")
+	if err != nil {
+		t.Fatalf("SetSelectedTrack failed: %v", err)
+	}
+	if pathService.GetSelectedTrack() != "quick-flow" {
+		t.Errorf("Expected path service on quick-flow, got %q", pathService.GetSelectedTrack())
+	}
+	if status.NextWorkflowID == nil || *status.NextWorkflowID != "tech-spec" {
+		t.Errorf("Expected next workflow tech-spec, got %v", status.NextWorkflowID)
+	}
+	if got := readStatusFile(t, root); !strings.Contains(got, `selected_track: "quick-flow"`) {
+		t.Errorf("Expected quoted selected_track to be updated, got:\n%s", got)
+	}
+
+	_, err = writer.SetSelectedTrack("enterprise")
+	var statusErr *WorkflowStatusError
+	if !errors.As(err, &statusErr) || statusErr.Code != ErrCodeTrackNotFound {
+		t.Errorf("Expected track_not_found error, got %v", err)
+	}
+}
+
+func TestWorkflowStatusWriter_SetSelectedTrackUpdatesLegacyKey(t *testing.T) {
+	writer, _, pathService, root := setupStatusWriterTest(t, "track: bmad-method\n")
+
+	if _, err := writer.SetSelectedTrack("quick-flow"); err != nil {
+		t.Fatalf("SetSelectedTrack failed: %v", err)
+	}
+	if got := readStatusFile(t, root); got != "track: quick-flow\nselected_track: quick-flow\n" {
+		t.Errorf("Unexpected status file:\n%s", got)
+	}
+	if pathService.GetSelectedTrack() != "quick-flow" {
+		t.Errorf("Expected path service on quick-flow, got %q", pathService.GetSelectedTrack())
+	}
+}
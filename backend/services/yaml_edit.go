@@ -0,0 +1,161 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// setYAMLValue sets the string at path in a YAML document, creating missing mappings and keys,
+// and returns the new document and the previous value ("" if the key was missing).
+func setYAMLValue(content []byte, value string, path ...string) ([]byte, string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, "", err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	node := doc.Content[0]
+	for i, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return nil, "", fmt.Errorf("%s is not a mapping", strings.Join(path[:i], "."))
+		}
+		next := yamlMappingValue(node, key)
+		if next == nil {
+			// Missing keys are appended, which needs the whole tree re-encoded
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if i == len(path)-1 {
+				next = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, next)
+			if i == len(path)-1 {
+				updated, err := encodeYAMLDocument(&doc)
+				return updated, "", err
+			}
+		}
+		node = next
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, "", fmt.Errorf("%s is not a scalar", strings.Join(path, "."))
+	}
+	previous := node.Value
+	updated, err := setYAMLScalar(content, &doc, node, value)
+	return updated, previous, err
+}
+
+// yamlMappingValue returns the value node of key in a mapping node, or nil if mapping is nil,
+// not a mapping, or lacks the key.
+func yamlMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setYAMLScalar sets a scalar node of doc, parsed from content, to value and returns the new document.
+// The value is replaced in place when possible so the rest of the file stays byte-for-byte
+// identical; otherwise the tree is re-encoded, which keeps comments and key order but not blank lines.
+func setYAMLScalar(content []byte, doc, node *yaml.Node, value string) ([]byte, error) {
+	if out, ok := replaceScalarInPlace(content, node, value); ok {
+		return out, nil
+	}
+
+	node.Value = value
+	node.Tag = "!!str"
+	node.Style &^= yaml.TaggedStyle
+	return encodeYAMLDocument(doc)
+}
+
+// encodeYAMLDocument encodes a node tree with the two-space indent BMAD files use.
+func encodeYAMLDocument(doc *yaml.Node) ([]byte, error) {
+	var encoded bytes.Buffer
+	enc := yaml.NewEncoder(&encoded)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return encoded.Bytes(), nil
+}
+
+// replaceScalarInPlace swaps the source text of a plain or quoted scalar for value, keeping its quoting.
+// Plain scalars are double-quoted if value would not read back as the same string.
+// It reports false if the node's text cannot be found at its recorded position.
+func replaceScalarInPlace(content []byte, node *yaml.Node, value string) ([]byte, bool) {
+	var raw, replacement string
+	switch node.Style {
+	case 0:
+		raw, replacement = node.Value, value
+		if !isPlainYAMLString(value) {
+			replacement = strconv.Quote(value)
+		}
+	case yaml.SingleQuotedStyle:
+		raw, replacement = "'"+strings.ReplaceAll(node.Value, "'", "''")+"'", "'"+strings.ReplaceAll(value, "'", "''")+"'"
+	case yaml.DoubleQuotedStyle:
+		raw, replacement = strconv.Quote(node.Value), strconv.Quote(value)
+	default:
+		return nil, false
+	}
+
+	lineStart := 0
+	for line := 1; line < node.Line; line++ {
+		next := bytes.IndexByte(content[lineStart:], '\n')
+		if next == -1 {
+			return nil, false
+		}
+		lineStart += next + 1
+	}
+	start := lineStart + node.Column - 1
+	if start < lineStart || start > len(content) || !bytes.HasPrefix(content[start:], []byte(raw)) {
+		return nil, false
+	}
+
+	out := make([]byte, 0, len(content)+len(replacement)-len(raw))
+	out = append(out, content[:start]...)
+	out = append(out, replacement...)
+	return append(out, content[start+len(raw):]...), true
+}
+
+// isPlainYAMLString reports whether value written unquoted reads back as the same string.
+func isPlainYAMLString(value string) bool {
+	if value == "" || strings.ContainsAny(value, "\n#") {
+		return false
+	}
+	var decoded interface{}
+	if err := yaml.Unmarshal([]byte("v: "+value), &decoded); err != nil {
+		return false
+	}
+	m, ok := decoded.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	s, ok := m["v"].(string)
+	return ok && s == value
+}
+
+// writeFileAtomic writes content to a temporary file beside path and renames it into place.
+func writeFileAtomic(path string, content []byte) error {
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, content, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return err
+	}
+	return nil
+}
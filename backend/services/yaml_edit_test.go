@@ -0,0 +1,39 @@
+package services
+
+import "testing"
+
+func TestSetYAMLValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		value    string
+		path     []string
+		want     string
+		previous string
+	}{
+		{"plain value", "a:\n  b: old # note\n\nc: 1\n", "new", []string{"a", "b"}, "a:\n  b: new # note\n\nc: 1\n", "old"},
+		{"single quoted", "a: 'it''s'\n", "won't", []string{"a"}, "a: 'won''t'\n", "it's"},
+		{"double quoted", "a: \"old\"\n", "new", []string{"a"}, "a: \"new\"\n", "old"},
+		{"plain needing quotes", "a: old\n", "true", []string{"a"}, "a: \"true\"\n", "old"},
+		{"missing key", "a: 1\n", "x", []string{"b", "c"}, "a: 1\nb:\n  c: x\n", ""},
+		{"empty document", "", "x", []string{"a"}, "a: x\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, previous, err := setYAMLValue([]byte(tt.content), tt.value, tt.path...)
+			if err != nil {
+				t.Fatalf("setYAMLValue failed: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+			if previous != tt.previous {
+				t.Errorf("Expected previous %q, got %q", tt.previous, previous)
+			}
+		})
+	}
+
+	if _, _, err := setYAMLValue([]byte("a: [1, 2]\n"), "x", "a"); err == nil {
+		t.Error("Expected an error setting a sequence")
+	}
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"bmad-studio/backend/types"
+)
+
+// EventStore keeps an append-only log of progress events per project, one JSON object per line.
+type EventStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewEventStore creates an EventStore that persists to ~/bmad-studio/events.
+func NewEventStore() (*EventStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, "bmad-studio", "events")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &EventStore{dir: dir}, nil
+}
+
+// NewEventStoreWithDir creates an EventStore with a custom directory (used for testing).
+func NewEventStoreWithDir(dir string) *EventStore {
+	return &EventStore{dir: dir}
+}
+
+// logPath names a project's log by a hash of its root.
+func (es *EventStore) logPath(projectRoot string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(projectRoot)))
+	return filepath.Join(es.dir, hex.EncodeToString(sum[:8])+".jsonl")
+}
+
+// Append adds events to the end of a project's log.
+func (es *EventStore) Append(projectRoot string, events []types.ProgressEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if err := os.MkdirAll(es.dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(es.logPath(projectRoot), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// Load returns a project's events in the order they were appended. A project without a log
+// has no events. A line cut short by a crash is skipped.
+func (es *EventStore) Load(projectRoot string) ([]types.ProgressEvent, error) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	f, err := os.Open(es.logPath(projectRoot))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []types.ProgressEvent{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	events := []types.ProgressEvent{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event types.ProgressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bmad-studio/backend/types"
+)
+
+func TestEventStore_AppendAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "events")
+	es := NewEventStoreWithDir(dir)
+
+	empty, err := es.Load("/project")
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("Expected no events for a new project, got %v, %v", empty, err)
+	}
+
+	ts := types.Timestamp(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	if err := es.Append("/project", []types.ProgressEvent{
+		{Timestamp: ts, Kind: types.ProgressEventSprint, Key: "1-1-setup", To: "backlog", Baseline: true},
+	}); err != nil {
+		t.Fatalf("Append error: %v", err)
+	}
+	if err := es.Append("/project", []types.ProgressEvent{
+		{Timestamp: ts, Kind: types.ProgressEventSprint, Key: "1-1-setup", From: "backlog", To: "in-progress"},
+	}); err != nil {
+		t.Fatalf("Append error: %v", err)
+	}
+
+	events, err := es.Load("/project")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if len(events) != 2 || !events[0].Baseline || events[1].To != "in-progress" || !events[1].Timestamp.Time().Equal(ts.Time()) {
+		t.Errorf("Unexpected events %+v", events)
+	}
+
+	other, _ := es.Load("/other-project")
+	if len(other) != 0 {
+		t.Error("Expected event logs to be scoped per project")
+	}
+}
+
+func TestEventStore_SkipsTruncatedLines(t *testing.T) {
+	dir := t.TempDir()
+	es := NewEventStoreWithDir(dir)
+
+	if err := es.Append("/project", []types.ProgressEvent{{Timestamp: types.Now(), Kind: types.ProgressEventWorkflow, Key: "prd", To: "required"}}); err != nil {
+		t.Fatal(err)
+	}
+	logs, _ := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if len(logs) != 1 {
+		t.Fatalf("Expected one log file, got %v", logs)
+	}
+	f, err := os.OpenFile(logs[0], os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"timestamp":"2026-`)
+	f.Close()
+
+	events, err := es.Load("/project")
+	if err != nil || len(events) != 1 {
+		t.Errorf("Expected the complete event only, got %v, %v", events, err)
+	}
+}
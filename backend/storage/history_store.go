@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"bmad-studio/backend/types"
+)
+
+// blobHashRegex matches the SHA-256 hex digests used as blob names
+var blobHashRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// HistoryStore keeps artifact snapshots per project: content-addressed blobs shared by
+// all artifacts, plus one timeline file per artifact listing its snapshots.
+type HistoryStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewHistoryStore creates a HistoryStore that persists to ~/bmad-studio/history.
+func NewHistoryStore() (*HistoryStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, "bmad-studio", "history")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &HistoryStore{dir: dir}, nil
+}
+
+// NewHistoryStoreWithDir creates a HistoryStore with a custom directory (used for testing).
+func NewHistoryStoreWithDir(dir string) *HistoryStore {
+	return &HistoryStore{dir: dir}
+}
+
+// projectDir returns the directory for a project, named by a hash of its root.
+func (hs *HistoryStore) projectDir(projectRoot string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(projectRoot)))
+	return filepath.Join(hs.dir, hex.EncodeToString(sum[:8]))
+}
+
+// blobPath fans blobs out into subdirectories by the first two hex digits.
+func (hs *HistoryStore) blobPath(projectRoot, hash string) string {
+	return filepath.Join(hs.projectDir(projectRoot), "objects", hash[:2], hash[2:])
+}
+
+// timelinePath names timeline files by a hash of the artifact ID, which may contain any character.
+func (hs *HistoryStore) timelinePath(projectRoot, artifactID string) string {
+	sum := sha256.Sum256([]byte(artifactID))
+	return filepath.Join(hs.projectDir(projectRoot), "timelines", hex.EncodeToString(sum[:8])+".json")
+}
+
+// PutBlob stores content under its SHA-256 digest and returns the digest.
+// Content that is already stored is not written again.
+func (hs *HistoryStore) PutBlob(projectRoot string, content []byte) (string, error) {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	path := hs.blobPath(projectRoot, hash)
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+	if err := writeFileAtomic(path, content); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// GetBlob returns the content stored under hash.
+func (hs *HistoryStore) GetBlob(projectRoot, hash string) ([]byte, error) {
+	if !blobHashRegex.MatchString(hash) {
+		return nil, fmt.Errorf("invalid blob hash: %q", hash)
+	}
+	return os.ReadFile(hs.blobPath(projectRoot, hash))
+}
+
+// LoadTimeline reads an artifact's timeline. It returns nil without error if none exists.
+func (hs *HistoryStore) LoadTimeline(projectRoot, artifactID string) (*types.ArtifactTimeline, error) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	data, err := os.ReadFile(hs.timelinePath(projectRoot, artifactID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var timeline types.ArtifactTimeline
+	if err := json.Unmarshal(data, &timeline); err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
+
+// SaveTimeline writes an artifact's timeline atomically.
+func (hs *HistoryStore) SaveTimeline(projectRoot string, timeline *types.ArtifactTimeline) error {
+	data, err := json.MarshalIndent(timeline, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	return writeFileAtomic(hs.timelinePath(projectRoot, timeline.ArtifactID), data)
+}
+
+// writeFileAtomic writes data to path via a temp file, creating parent directories.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
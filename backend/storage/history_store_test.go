@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+func TestHistoryStore_BlobRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	hs := NewHistoryStoreWithDir(dir)
+
+	hash, err := hs.PutBlob("/project", []byte("# PRD\n"))
+	if err != nil {
+		t.Fatalf("PutBlob error: %v", err)
+	}
+	again, err := hs.PutBlob("/project", []byte("# PRD\n"))
+	if err != nil || again != hash {
+		t.Fatalf("Expected identical content to map to %s, got %s (%v)", hash, again, err)
+	}
+
+	content, err := hs.GetBlob("/project", hash)
+	if err != nil {
+		t.Fatalf("GetBlob error: %v", err)
+	}
+	if string(content) != "# PRD\n" {
+		t.Errorf("GetBlob = %q", content)
+	}
+
+	objects, _ := filepath.Glob(filepath.Join(dir, "*", "objects", "*", "*"))
+	if len(objects) != 1 {
+		t.Errorf("Expected one stored blob, got %v", objects)
+	}
+}
+
+func TestHistoryStore_GetBlobRejectsInvalidHash(t *testing.T) {
+	hs := NewHistoryStoreWithDir(t.TempDir())
+
+	if _, err := hs.GetBlob("/project", "../../etc/passwd"); err == nil {
+		t.Error("Expected an error for a non-hash blob name")
+	}
+}
+
+func TestHistoryStore_TimelineRoundTrip(t *testing.T) {
+	hs := NewHistoryStoreWithDir(t.TempDir())
+
+	missing, err := hs.LoadTimeline("/project", "prd")
+	if err != nil || missing != nil {
+		t.Fatalf("Expected nil timeline for a new artifact, got %v, %v", missing, err)
+	}
+
+	timeline := &types.ArtifactTimeline{
+		ArtifactID: "prd",
+		Versions:   []types.ArtifactVersion{{ID: "local:1", Source: types.VersionSourceLocal, ContentHash: "abc"}},
+	}
+	if err := hs.SaveTimeline("/project", timeline); err != nil {
+		t.Fatalf("SaveTimeline error: %v", err)
+	}
+
+	loaded, err := hs.LoadTimeline("/project", "prd")
+	if err != nil {
+		t.Fatalf("LoadTimeline error: %v", err)
+	}
+	if loaded == nil || len(loaded.Versions) != 1 || loaded.Versions[0].ID != "local:1" {
+		t.Errorf("Unexpected timeline %+v", loaded)
+	}
+
+	other, _ := hs.LoadTimeline("/other-project", "prd")
+	if other != nil {
+		t.Error("Expected timelines to be scoped per project")
+	}
+}
+
+func TestHistoryStore_CreatesDirectories(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "history")
+	hs := NewHistoryStoreWithDir(dir)
+
+	if _, err := hs.PutBlob("/project", []byte("x")); err != nil {
+		t.Fatalf("PutBlob error: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("Expected store directory to be created: %v", err)
+	}
+}
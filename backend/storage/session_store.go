@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"bmad-studio/backend/types"
+)
+
+// ErrSessionNotFound is returned when no session file exists for an ID.
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionStore persists conversation sessions as one JSON file per session.
+type SessionStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewSessionStore creates a SessionStore that persists to ~/bmad-studio/sessions.
+func NewSessionStore() (*SessionStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, "bmad-studio", "sessions")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &SessionStore{dir: dir}, nil
+}
+
+// NewSessionStoreWithDir creates a SessionStore with a custom directory (used for testing).
+func NewSessionStoreWithDir(dir string) *SessionStore {
+	return &SessionStore{dir: dir}
+}
+
+// path returns the file path for a session ID.
+// IDs are generated by the service, but are sanitised so a crafted ID cannot escape dir.
+func (ss *SessionStore) path(id string) string {
+	return filepath.Join(ss.dir, filepath.Base(filepath.Clean("/"+id))+".json")
+}
+
+// List returns all sessions, most recently updated first.
+func (ss *SessionStore) List() ([]types.Session, error) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	entries, err := os.ReadDir(ss.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []types.Session{}, nil
+		}
+		return nil, err
+	}
+
+	sessions := make([]types.Session, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		s, err := ss.getLocked(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			log.Printf("Warning: skipping unreadable session file %s: %v", entry.Name(), err)
+			continue
+		}
+		sessions = append(sessions, *s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.Time().After(sessions[j].UpdatedAt.Time())
+	})
+	return sessions, nil
+}
+
+// Get reads a single session. Returns ErrSessionNotFound if it does not exist.
+func (ss *SessionStore) Get(id string) (*types.Session, error) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	return ss.getLocked(id)
+}
+
+// getLocked reads a session without acquiring a lock (caller must hold mu).
+func (ss *SessionStore) getLocked(id string) (*types.Session, error) {
+	data, err := os.ReadFile(ss.path(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	var s types.Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Save writes a session, replacing any existing file with the same ID.
+func (ss *SessionStore) Save(s *types.Session) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	return ss.saveLocked(s)
+}
+
+// saveLocked writes a session via a temp file and rename so readers never see a partial file.
+func (ss *SessionStore) saveLocked(s *types.Session) error {
+	if err := os.MkdirAll(ss.dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	target := ss.path(s.ID)
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
+
+// Update atomically loads, modifies, and saves a session under a single lock.
+// If fn returns an error the session is not saved and the error is returned.
+func (ss *SessionStore) Update(id string, fn func(*types.Session) error) (*types.Session, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	s, err := ss.getLocked(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(s); err != nil {
+		return nil, err
+	}
+
+	if err := ss.saveLocked(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Delete removes a session. Returns ErrSessionNotFound if it does not exist.
+func (ss *SessionStore) Delete(id string) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if err := os.Remove(ss.path(id)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+	return nil
+}
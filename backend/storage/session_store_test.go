@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bmad-studio/backend/types"
+)
+
+func newTestSession(id string, updated time.Time) *types.Session {
+	return &types.Session{
+		BaseEntity: types.BaseEntity{
+			ID:        id,
+			CreatedAt: types.Timestamp(updated),
+			UpdatedAt: types.Timestamp(updated),
+		},
+		AgentID: "architect",
+	}
+}
+
+func TestSessionStore_SaveAndGet_RoundTrip(t *testing.T) {
+	ss := NewSessionStoreWithDir(t.TempDir())
+	s := newTestSession("sess_1", time.Now())
+	s.Messages = []types.SessionMessage{{ID: "msg_1", Role: "user", Content: "Hello"}}
+	s.ActiveLeafID = "msg_1"
+
+	if err := ss.Save(s); err != nil {
+		t.Fatalf("save error: %v", err)
+	}
+
+	loaded, err := ss.Get("sess_1")
+	if err != nil {
+		t.Fatalf("get error: %v", err)
+	}
+	if loaded.AgentID != "architect" {
+		t.Errorf("expected agent 'architect', got %q", loaded.AgentID)
+	}
+	if len(loaded.Messages) != 1 || loaded.ActiveLeafID != "msg_1" {
+		t.Errorf("expected message tree to round-trip, got %+v", loaded)
+	}
+}
+
+func TestSessionStore_Get_NotFound(t *testing.T) {
+	ss := NewSessionStoreWithDir(t.TempDir())
+	if _, err := ss.Get("missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestSessionStore_List_NewestFirst(t *testing.T) {
+	ss := NewSessionStoreWithDir(t.TempDir())
+	now := time.Now()
+	ss.Save(newTestSession("older", now.Add(-time.Hour)))
+	ss.Save(newTestSession("newer", now))
+
+	sessions, err := ss.List()
+	if err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	if sessions[0].ID != "newer" {
+		t.Errorf("expected newest session first, got %q", sessions[0].ID)
+	}
+}
+
+func TestSessionStore_List_MissingDir(t *testing.T) {
+	ss := NewSessionStoreWithDir(filepath.Join(t.TempDir(), "does-not-exist"))
+	sessions, err := ss.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sessions == nil || len(sessions) != 0 {
+		t.Errorf("expected empty non-nil list, got %v", sessions)
+	}
+}
+
+func TestSessionStore_List_SkipsCorruptedFiles(t *testing.T) {
+	dir := t.TempDir()
+	ss := NewSessionStoreWithDir(dir)
+	ss.Save(newTestSession("good", time.Now()))
+	os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{not json"), 0644)
+
+	sessions, err := ss.List()
+	if err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "good" {
+		t.Errorf("expected only the readable session, got %+v", sessions)
+	}
+}
+
+func TestSessionStore_Update_AbortsOnError(t *testing.T) {
+	ss := NewSessionStoreWithDir(t.TempDir())
+	ss.Save(newTestSession("sess_1", time.Now()))
+
+	abort := errors.New("abort")
+	_, err := ss.Update("sess_1", func(s *types.Session) error {
+		s.Title = "changed"
+		return abort
+	})
+	if !errors.Is(err, abort) {
+		t.Fatalf("expected abort error, got %v", err)
+	}
+
+	loaded, _ := ss.Get("sess_1")
+	if loaded.Title != "" {
+		t.Errorf("expected update to be discarded, got title %q", loaded.Title)
+	}
+}
+
+func TestSessionStore_Delete(t *testing.T) {
+	ss := NewSessionStoreWithDir(t.TempDir())
+	ss.Save(newTestSession("sess_1", time.Now()))
+
+	if err := ss.Delete("sess_1"); err != nil {
+		t.Fatalf("delete error: %v", err)
+	}
+	if err := ss.Delete("sess_1"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound on second delete, got %v", err)
+	}
+}
+
+func TestSessionStore_PathCannotEscapeDir(t *testing.T) {
+	dir := t.TempDir()
+	ss := NewSessionStoreWithDir(dir)
+	if got := ss.path("../../etc/passwd"); filepath.Dir(got) != dir {
+		t.Errorf("expected path inside %s, got %s", dir, got)
+	}
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"bmad-studio/backend/types"
+)
+
+// VectorStore persists one embedding index per project as a JSON file.
+type VectorStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewVectorStore creates a VectorStore that persists to ~/bmad-studio/vectors.
+func NewVectorStore() (*VectorStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(home, "bmad-studio", "vectors")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &VectorStore{dir: dir}, nil
+}
+
+// NewVectorStoreWithDir creates a VectorStore with a custom directory (used for testing).
+func NewVectorStoreWithDir(dir string) *VectorStore {
+	return &VectorStore{dir: dir}
+}
+
+// path returns the index file for a project, named by a hash of its root so that
+// indexes for different projects never collide.
+func (vs *VectorStore) path(projectRoot string) string {
+	sum := sha256.Sum256([]byte(filepath.Clean(projectRoot)))
+	return filepath.Join(vs.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// Load reads the index for a project. It returns nil without error if none exists.
+func (vs *VectorStore) Load(projectRoot string) (*types.VectorIndex, error) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	data, err := os.ReadFile(vs.path(projectRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var index types.VectorIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// Save writes the index for index.ProjectRoot atomically via a temp file.
+func (vs *VectorStore) Save(index *types.VectorIndex) error {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(vs.dir, 0755); err != nil {
+		return err
+	}
+
+	path := vs.path(index.ProjectRoot)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
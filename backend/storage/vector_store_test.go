@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"bmad-studio/backend/types"
+)
+
+func TestVectorStore_LoadMissingReturnsNil(t *testing.T) {
+	vs := NewVectorStoreWithDir(t.TempDir())
+
+	index, err := vs.Load("/projects/demo")
+	if err != nil || index != nil {
+		t.Errorf("Expected nil index without error, got %v, %v", index, err)
+	}
+}
+
+func TestVectorStore_RoundTripPerProject(t *testing.T) {
+	vs := NewVectorStoreWithDir(t.TempDir())
+	index := &types.VectorIndex{
+		Version:     1,
+		ProjectRoot: "/projects/demo",
+		Provider:    "ollama",
+		Model:       "nomic-embed-text",
+		Artifacts: map[string]types.VectorArtifact{
+			"prd": {ModifiedAt: 42, Chunks: []types.VectorChunk{{ID: "prd#0", Text: "Goals", Vector: []float32{0.5, 0.25}}}},
+		},
+	}
+	if err := vs.Save(index); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	loaded, err := vs.Load("/projects/demo")
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	chunk := loaded.Artifacts["prd"].Chunks[0]
+	if loaded.Model != "nomic-embed-text" || chunk.Vector[1] != 0.25 {
+		t.Errorf("Round trip mismatch: %+v", loaded)
+	}
+
+	if other, _ := vs.Load("/projects/other"); other != nil {
+		t.Error("Expected indexes to be separate per project")
+	}
+}
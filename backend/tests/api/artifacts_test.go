@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"bmad-studio/backend/api"
@@ -274,3 +275,186 @@ func TestGetArtifacts_Returns404WhenServiceNotAvailable(t *testing.T) {
 		t.Errorf("Expected status 404 when artifact service is nil, got %d", rec.Code)
 	}
 }
+
+const prdArtifactID = "_bmad-output-planning-artifacts-prd"
+
+func TestGetArtifactContent_ReturnsMarkdown(t *testing.T) {
+	configService, artifactService, tmpDir := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, Artifact: artifactService})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+prdArtifactID+"/content", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/markdown; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	want, _ := os.ReadFile(filepath.Join(tmpDir, "_bmad-output", "planning-artifacts", "prd.md"))
+	if rec.Body.String() != string(want) {
+		t.Errorf("Body differs from file: %s", rec.Body.String())
+	}
+}
+
+func TestGetArtifactFrontmatter_ReturnsJSON(t *testing.T) {
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, Artifact: artifactService})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+prdArtifactID+"/frontmatter", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var result types.ArtifactFrontmatterResponse
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.Frontmatter["workflowType"] != "prd" || result.Frontmatter["completedAt"] != "2026-01-27" {
+		t.Errorf("Unexpected frontmatter %v", result.Frontmatter)
+	}
+}
+
+func TestGetArtifactOutlineAndSection(t *testing.T) {
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, Artifact: artifactService})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+prdArtifactID+"/outline", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var outline types.ArtifactOutlineResponse
+	if err := json.NewDecoder(rec.Body).Decode(&outline); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(outline.Sections) != 1 || outline.Sections[0].ID != "product-requirements-document" {
+		t.Fatalf("Unexpected outline %+v", outline.Sections)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+prdArtifactID+"/sections/product-requirements-document", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var section types.ArtifactSectionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&section); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if section.Content != "# Product Requirements Document\n\nTest PRD content.\n" {
+		t.Errorf("Unexpected section content %q", section.Content)
+	}
+}
+
+func TestGetArtifactSection_Returns404ForUnknownSection(t *testing.T) {
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, Artifact: artifactService})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+prdArtifactID+"/sections/nope", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected status 404, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var errResp response.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != "section_not_found" {
+		t.Errorf("Expected error code 'section_not_found', got '%s'", errResp.Error.Code)
+	}
+}
+
+func TestPutArtifactContent_OptimisticConcurrency(t *testing.T) {
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, Artifact: artifactService})
+	url := "/api/v1/bmad/artifacts/" + prdArtifactID + "/content"
+
+	getRec := httptest.NewRecorder()
+	router.ServeHTTP(getRec, httptest.NewRequest(http.MethodGet, url, nil))
+	etag := getRec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag on GET content")
+	}
+
+	req := httptest.NewRequest(http.MethodPut, url, strings.NewReader("# PRD\n\nRewritten.\n"))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusPreconditionRequired {
+		t.Errorf("Expected 428 without If-Match, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, url, strings.NewReader("# PRD\n\nRewritten.\n"))
+	req.Header.Set("If-Match", etag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("ETag") == etag {
+		t.Error("Expected a new ETag after the write")
+	}
+
+	req = httptest.NewRequest(http.MethodPut, url, strings.NewReader("# Stale\n"))
+	req.Header.Set("If-Match", etag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412 for a stale ETag, got %d", rec.Code)
+	}
+}
+
+func TestPatchArtifactFrontmatter_UpdatesStatus(t *testing.T) {
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, Artifact: artifactService})
+	archID := "_bmad-output-planning-artifacts-architecture"
+
+	getRec := httptest.NewRecorder()
+	router.ServeHTTP(getRec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+archID, nil))
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/bmad/artifacts/"+archID+"/frontmatter",
+		strings.NewReader(`{"status":"complete","steps_completed":[1,2,3,4]}`))
+	req.Header.Set("If-Match", getRec.Header().Get("ETag"))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var artifact types.ArtifactResponse
+	if err := json.NewDecoder(rec.Body).Decode(&artifact); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if artifact.Status != "complete" || len(artifact.StepsCompleted) != 4 {
+		t.Errorf("Unexpected artifact after patch: %+v", artifact)
+	}
+}
+
+func TestCreateArtifact_Returns201AndConflict(t *testing.T) {
+	configService, artifactService, tmpDir := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, Artifact: artifactService})
+
+	body := `{"type":"ux_design","content":"# UX Design\n\nFlows.\n"}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/artifacts", strings.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "_bmad-output", "planning-artifacts", "ux-design.md")); err != nil {
+		t.Errorf("Expected file in planning artifacts: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/artifacts", strings.NewReader(body)))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409 for an existing file, got %d", rec.Code)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"bmad-studio/backend/api"
@@ -316,3 +317,103 @@ func TestGetStatus_ReturnsErrorWhenServiceNotLoaded(t *testing.T) {
 		t.Errorf("Expected error code 'status_not_loaded', got '%s'", errResp.Error.Code)
 	}
 }
+
+func TestUpdateWorkflowStatus(t *testing.T) {
+	configService, pathService, statusService, tmpDir := setupStatusTestServices(t)
+	writer := services.NewWorkflowStatusWriter(configService, pathService, statusService, nil)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, WorkflowPath: pathService, WorkflowStatus: statusService, StatusWriter: writer})
+
+	put := func(url, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, url, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := put("/api/v1/bmad/status/workflows/prd", `{"status":"skipped"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var result types.StatusResponse
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.NextWorkflowID != nil {
+		t.Errorf("Expected no next workflow once prd is skipped, got %v", *result.NextWorkflowID)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "_bmad-output", "planning-artifacts", "bmm-workflow-status.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "workflow_path: \"method-greenfield.yaml\"\n\nworkflow_status:\n") || !strings.Contains(string(data), "  prd: skipped\n") {
+		t.Errorf("Expected prd skipped with the file layout kept, got:\n%s", data)
+	}
+
+	tests := []struct {
+		url    string
+		body   string
+		status int
+	}{
+		{"/api/v1/bmad/status/workflows/prd", `{"status":"done"}`, http.StatusBadRequest},
+		{"/api/v1/bmad/status/workflows/prd", `{"artifact_path":"missing/prd.md"}`, http.StatusBadRequest},
+		{"/api/v1/bmad/status/workflows/prd", `not json`, http.StatusBadRequest},
+		{"/api/v1/bmad/status/workflows/unknown", `{"status":"skipped"}`, http.StatusNotFound},
+		{"/api/v1/bmad/status/track", `{"track":"enterprise"}`, http.StatusNotFound},
+		{"/api/v1/bmad/status/track", `{}`, http.StatusBadRequest},
+		{"/api/v1/bmad/status/track", `{"track":"bmad-method"}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if rec := put(tt.url, tt.body); rec.Code != tt.status {
+			t.Errorf("PUT %s with %s: expected status %d, got %d. Body: %s", tt.url, tt.body, tt.status, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestTracks_ListPhasesAndCompare(t *testing.T) {
+	configService, pathService, statusService, _ := setupStatusTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, WorkflowPath: pathService, WorkflowStatus: statusService})
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	rec := get("/api/v1/bmad/tracks")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var tracks types.TracksResponse
+	if err := json.NewDecoder(rec.Body).Decode(&tracks); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if tracks.SelectedTrack != "bmad-method" || len(tracks.Tracks) != 1 || tracks.Tracks[0].FieldType != "greenfield" {
+		t.Errorf("Unexpected tracks %+v", tracks)
+	}
+
+	rec = get("/api/v1/bmad/tracks/bmad-method/compare")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var comparison types.TrackComparisonResponse
+	if err := json.NewDecoder(rec.Body).Decode(&comparison); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(comparison.CarriedOver) != 2 || len(comparison.NewlyRequired) != 0 || len(comparison.RemainingRequired) != 1 {
+		t.Errorf("Unexpected comparison with the selected track %+v", comparison)
+	}
+
+	tests := []struct {
+		url    string
+		status int
+	}{
+		{"/api/v1/bmad/tracks/bmad-method/phases", http.StatusOK},
+		{"/api/v1/bmad/tracks/enterprise/phases", http.StatusNotFound},
+		{"/api/v1/bmad/tracks/enterprise/compare", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rec := get(tt.url); rec.Code != tt.status {
+			t.Errorf("GET %s: expected status %d, got %d. Body: %s", tt.url, tt.status, rec.Code, rec.Body.String())
+		}
+	}
+}
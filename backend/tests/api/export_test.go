@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+)
+
+func setupExportRouter(t *testing.T) http.Handler {
+	t.Helper()
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	return api.NewRouterWithServices(api.RouterServices{
+		BMadConfig: configService,
+		Artifact:   artifactService,
+		Export:     services.NewExportService(configService, artifactService),
+	})
+}
+
+func TestExport_ReturnsZipBundle(t *testing.T) {
+	router := setupExportRouter(t)
+
+	body := `{"artifact_ids": ["_bmad-output-planning-artifacts-prd", "_bmad-output-planning-artifacts-architecture"], "title": "Plan", "include_pdf": true}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/export", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/zip" {
+		t.Errorf("Expected application/zip, got %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="plan.zip"` {
+		t.Errorf("Unexpected Content-Disposition %q", cd)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("Failed to read zip: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "plan.html,plan.md,plan.pdf" {
+		t.Errorf("Unexpected bundle contents %v", names)
+	}
+}
+
+func TestExportDownload_QueryParameters(t *testing.T) {
+	router := setupExportRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/export?artifact_ids=_bmad-output-planning-artifacts-prd", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	for _, query := range []string{"", "?phase=two", "?artifact_ids=x&pdf=maybe"} {
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/export"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %q, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestExport_UnknownArtifact(t *testing.T) {
+	router := setupExportRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/export", strings.NewReader(`{"artifact_ids": ["missing"]}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
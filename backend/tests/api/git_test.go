@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+)
+
+// gitEnv gives commits a fixed identity and isolates git from the user's configuration.
+var gitEnv = map[string]string{
+	"GIT_AUTHOR_NAME":     "Test Author",
+	"GIT_AUTHOR_EMAIL":    "author@example.com",
+	"GIT_COMMITTER_NAME":  "Test Author",
+	"GIT_COMMITTER_EMAIL": "author@example.com",
+	"GIT_CONFIG_GLOBAL":   "/dev/null",
+	"GIT_CONFIG_NOSYSTEM": "1",
+}
+
+func setupGitRouter(t *testing.T) http.Handler {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	for k, v := range gitEnv {
+		t.Setenv(k, v)
+	}
+	configService, artifactService, tmpDir := setupArtifactTestServices(t)
+	if out, err := exec.Command("git", "-C", tmpDir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+
+	return api.NewRouterWithServices(api.RouterServices{
+		BMadConfig: configService,
+		Artifact:   artifactService,
+		Git:        services.NewGitService(configService, artifactService),
+	})
+}
+
+func TestGit_CommitArtifactAndReadStatus(t *testing.T) {
+	router := setupGitRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+prdArtifactID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var artifact types.ArtifactResponse
+	if err := json.NewDecoder(rec.Body).Decode(&artifact); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if artifact.Git == nil || !artifact.Git.Untracked {
+		t.Fatalf("Expected the PRD untracked, got %+v", artifact.Git)
+	}
+
+	body, _ := json.Marshal(types.GitCommitRequest{ArtifactIDs: []string{prdArtifactID}, Message: "Add PRD"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/git/commit", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var commit types.GitCommitResponse
+	if err := json.NewDecoder(rec.Body).Decode(&commit); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if commit.Commit.Message != "Add PRD" || commit.Commit.Author != "Test Author" {
+		t.Errorf("Unexpected commit %+v", commit.Commit)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts", nil))
+	var list types.ArtifactsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	for _, a := range list.Artifacts {
+		if a.ID == prdArtifactID && (a.Git == nil || !a.Git.Tracked || a.Git.LastCommit == nil) {
+			t.Errorf("Expected the PRD tracked with a last commit, got %+v", a.Git)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/git/status", nil))
+	var status types.GitRepoStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !status.IsRepo || status.Head == nil || status.Head.Hash != commit.Commit.Hash {
+		t.Errorf("Unexpected repo status %+v", status)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/git/commit", bytes.NewReader(body)))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 with nothing to commit, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGit_CommitRequiresMessage(t *testing.T) {
+	router := setupGitRouter(t)
+
+	body, _ := json.Marshal(types.GitCommitRequest{ArtifactIDs: []string{prdArtifactID}})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/git/commit", bytes.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
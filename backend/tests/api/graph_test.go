@@ -0,0 +1,57 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+)
+
+func TestArtifactGraph_ReturnsNodesEdgesAndDangling(t *testing.T) {
+	configService, artifactService, tmpDir := setupArtifactTestServices(t)
+	router := api.NewRouterWithServices(api.RouterServices{
+		BMadConfig:    configService,
+		Artifact:      artifactService,
+		ArtifactGraph: services.NewArtifactGraphService(configService, artifactService),
+	})
+
+	epicsPath := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts", "epics.md")
+	epicsContent := "---\ninputDocuments: [\"prd.md\", \"architecture.md\", \"ux-design.md\"]\n---\n# Epics\n"
+	if err := os.WriteFile(epicsPath, []byte(epicsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := artifactService.ProcessSingleArtifact(epicsPath); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/graph", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	var graph types.ArtifactGraphResponse
+	if err := json.NewDecoder(rec.Body).Decode(&graph); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(graph.Nodes) != 3 {
+		t.Errorf("Expected 3 nodes, got %d", len(graph.Nodes))
+	}
+	if len(graph.Edges) != 2 {
+		t.Errorf("Expected 2 edges, got %+v", graph.Edges)
+	}
+	for _, e := range graph.Edges {
+		if e.To != "_bmad-output-planning-artifacts-epics" {
+			t.Errorf("Unexpected edge %+v", e)
+		}
+	}
+	if len(graph.Dangling) != 1 || graph.Dangling[0].Reference != "ux-design.md" || graph.Dangling[0].Exists {
+		t.Errorf("Expected ux-design.md dangling, got %+v", graph.Dangling)
+	}
+}
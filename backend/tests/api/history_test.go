@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+func setupHistoryRouter(t *testing.T) (http.Handler, *services.ArtifactService, *services.HistoryService, string) {
+	t.Helper()
+	configService, artifactService, tmpDir := setupArtifactTestServices(t)
+
+	historyService := services.NewHistoryService(configService, artifactService, storage.NewHistoryStoreWithDir(t.TempDir()))
+	if err := historyService.Seed(); err != nil {
+		t.Fatalf("Seed error: %v", err)
+	}
+
+	router := api.NewRouterWithServices(api.RouterServices{
+		BMadConfig: configService,
+		Artifact:   artifactService,
+		History:    historyService,
+	})
+	return router, artifactService, historyService, tmpDir
+}
+
+func TestArtifactHistory_ListDiffAndRestore(t *testing.T) {
+	router, artifactService, historyService, tmpDir := setupHistoryRouter(t)
+	base := "/api/v1/bmad/artifacts/" + prdArtifactID
+
+	// Simulate an external edit picked up by the watcher
+	path := filepath.Join(tmpDir, "_bmad-output", "planning-artifacts", "prd.md")
+	if err := os.WriteFile(path, []byte("# Product Requirements Document\n\nEdited PRD content.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	artifact, err := artifactService.ProcessSingleArtifact(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	historyService.ArtifactChanged(artifact)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, base+"/history", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var history types.ArtifactHistoryResponse
+	if err := json.NewDecoder(rec.Body).Decode(&history); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(history.Versions) != 2 || history.Versions[0].ID != "local:2" {
+		t.Fatalf("Unexpected history %+v", history.Versions)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, base+"/diff?from=local:1&mode=word", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var diff types.ArtifactDiffResponse
+	if err := json.NewDecoder(rec.Body).Decode(&diff); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if diff.Mode != types.DiffModeWord || len(diff.Words) == 0 {
+		t.Errorf("Unexpected diff %+v", diff)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, base+"/history/local:1", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Test PRD content.") {
+		t.Fatalf("Expected version content, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, base+"/restore", strings.NewReader(`{"version_id":"local:1"}`))
+	req.Header.Set("If-Match", services.ArtifactETag(artifact))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	restored, _ := os.ReadFile(path)
+	if !strings.Contains(string(restored), "Test PRD content.") {
+		t.Errorf("Expected the original content restored, got %q", restored)
+	}
+}
+
+func TestArtifactDiff_Validation(t *testing.T) {
+	router, _, _, _ := setupHistoryRouter(t)
+	base := "/api/v1/bmad/artifacts/" + prdArtifactID
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", http.StatusBadRequest},
+		{"?from=local:1&mode=split", http.StatusBadRequest},
+		{"?from=local:42", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, base+"/diff"+tt.query, nil))
+		if rec.Code != tt.want {
+			t.Errorf("diff%s: expected %d, got %d", tt.query, tt.want, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+func TestMetrics_ReturnsMetricsAndTimeline(t *testing.T) {
+	configService, pathService, statusService, _ := setupStatusTestServices(t)
+	metricsService := services.NewMetricsService(configService, pathService, statusService, storage.NewEventStoreWithDir(t.TempDir()))
+	if err := metricsService.Seed(); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+	router := api.NewRouterWithServices(api.RouterServices{BMadConfig: configService, WorkflowPath: pathService, WorkflowStatus: statusService, Metrics: metricsService})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var metrics types.MetricsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&metrics); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if metrics.TrackingSince == nil || len(metrics.Burndown) != 1 || len(metrics.Throughput) != 1 || len(metrics.PhaseDurations) != 2 {
+		t.Errorf("Unexpected metrics for a freshly seeded project %+v", metrics)
+	}
+	if p := metrics.Burndown[0]; p.Total != 2 || p.Done != 1 || p.Remaining != 1 {
+		t.Errorf("Expected burndown of the sprint's two stories, got %+v", p)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/metrics/timeline", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var timeline types.ProgressTimelineResponse
+	if err := json.NewDecoder(rec.Body).Decode(&timeline); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	// Three workflow_status and three development_status entries
+	if len(timeline.Events) != 6 {
+		t.Errorf("Expected 6 baseline events, got %+v", timeline.Events)
+	}
+}
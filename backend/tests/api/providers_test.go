@@ -282,3 +282,19 @@ func TestIntegration_ProviderValidate_APIKeyNotInResponse(t *testing.T) {
 		t.Errorf("Response should not contain API key")
 	}
 }
+
+func TestIntegration_ProviderModels_OllamaRouteNotShadowed(t *testing.T) {
+	router := newRouterWithProvider()
+
+	// Ollama model management routes must not shadow GET /providers/{type}/models
+	req, _ := http.NewRequest("GET", "/api/v1/providers/ollama/models", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code == http.StatusNotFound || rr.Code == http.StatusMethodNotAllowed {
+		t.Errorf("Expected list models handler, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	if strings.Contains(rr.Body.String(), "Model name is required") {
+		t.Errorf("Request was routed to the model details handler. Body: %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,125 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// setupRetrievalRouter serves the artifact fixtures with a fake Ollama embedding endpoint
+// that places "architecture" text and everything else on orthogonal axes.
+func setupRetrievalRouter(t *testing.T) (http.Handler, string) {
+	t.Helper()
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		embeddings := make([]string, len(req.Input))
+		for i, text := range req.Input {
+			if strings.Contains(strings.ToLower(text), "architecture") {
+				embeddings[i] = "[1,0]"
+			} else {
+				embeddings[i] = "[0,1]"
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"model":"nomic-embed-text","embeddings":[%s]}`, strings.Join(embeddings, ","))
+	}))
+	t.Cleanup(ollama.Close)
+
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	retrievalService := services.NewRetrievalService(configService, artifactService, services.NewProviderService(), nil, storage.NewVectorStoreWithDir(t.TempDir()))
+	tools := services.NewToolRegistry()
+	tools.Register(services.NewRetrievalTool(retrievalService))
+
+	router := api.NewRouterWithServices(api.RouterServices{
+		BMadConfig: configService,
+		Artifact:   artifactService,
+		Retrieval:  retrievalService,
+		Tools:      tools,
+	})
+	return router, ollama.URL
+}
+
+func TestRetrieval_QueryReturnsTopChunks(t *testing.T) {
+	router, endpoint := setupRetrievalRouter(t)
+
+	body := fmt.Sprintf(`{"query":"architecture decisions","top_k":1,"api_key":%q}`, endpoint)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/retrieval/query", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var result types.RetrievalResponse
+	json.NewDecoder(rec.Body).Decode(&result)
+	if len(result.Results) != 1 || result.Results[0].ArtifactType != "architecture" {
+		t.Fatalf("Expected the architecture chunk, got %+v", result.Results)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/retrieval/status", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	var status types.RetrievalIndexStatus
+	json.NewDecoder(rec.Body).Decode(&status)
+	if status.Artifacts != 2 || status.Stale != 0 || status.Model != "nomic-embed-text" {
+		t.Errorf("Expected an up-to-date index of 2 artifacts, got %+v", status)
+	}
+}
+
+func TestRetrieval_EmptyQuery(t *testing.T) {
+	router, _ := setupRetrievalRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/retrieval/query", strings.NewReader(`{"query":""}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestTools_ListAndInvoke(t *testing.T) {
+	router, endpoint := setupRetrievalRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tools", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var list types.ToolsResponse
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list.Tools) != 1 || list.Tools[0].Name != "search_project_docs" {
+		t.Fatalf("Expected search_project_docs tool, got %+v", list.Tools)
+	}
+
+	body := fmt.Sprintf(`{"input":{"query":"architecture"},"api_key":%q}`, endpoint)
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tools/search_project_docs", strings.NewReader(body))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var result types.ToolResult
+	json.NewDecoder(rec.Body).Decode(&result)
+	if !strings.HasPrefix(result.Content, "[1] Architecture") {
+		t.Errorf("Expected architecture passage first, got %q", result.Content)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tools/unknown", strings.NewReader(`{"input":{}}`))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown tool, got %d", rec.Code)
+	}
+}
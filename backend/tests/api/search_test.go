@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+func setupSearchRouter(t *testing.T) http.Handler {
+	t.Helper()
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	searchService := services.NewSearchService(artifactService, storage.NewSessionStoreWithDir(t.TempDir()))
+	if err := searchService.Rebuild(); err != nil {
+		t.Fatalf("Failed to build search index: %v", err)
+	}
+	return api.NewRouterWithServices(api.RouterServices{
+		BMadConfig: configService,
+		Artifact:   artifactService,
+		Search:     searchService,
+	})
+}
+
+func TestSearch_ReturnsHighlightedResults(t *testing.T) {
+	router := setupSearchRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=architecture+content", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	var result types.SearchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.Total != 1 || len(result.Results) != 1 {
+		t.Fatalf("Expected one result, got %+v", result)
+	}
+	hit := result.Results[0]
+	if hit.Kind != types.SearchKindArtifact || hit.ArtifactType != "architecture" || hit.Phase == nil || *hit.Phase != 3 {
+		t.Errorf("Unexpected hit metadata: %+v", hit)
+	}
+	if hit.Snippet == "" || len(hit.Highlights) == 0 {
+		t.Errorf("Expected snippet with highlights, got %q %v", hit.Snippet, hit.Highlights)
+	}
+}
+
+func TestSearch_FiltersByPhase(t *testing.T) {
+	router := setupSearchRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=content&phase=2", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var result types.SearchResponse
+	json.NewDecoder(rec.Body).Decode(&result)
+	if len(result.Results) != 1 || result.Results[0].ArtifactType != "prd" {
+		t.Errorf("Expected only the PRD in phase 2, got %+v", result.Results)
+	}
+}
+
+func TestSearch_InvalidParameters(t *testing.T) {
+	router := setupSearchRouter(t)
+
+	for _, query := range []string{"", "?q=", "?q=the", "?q=prd&phase=two", "?q=prd&kind=agent", "?q=prd&limit=-1"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/search"+query, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET /api/v1/search%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/api/response"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/storage"
+	"bmad-studio/backend/types"
+)
+
+// newSessionRouter returns a router with sessions backed by a temp store, plus the URL of a
+// fake Ollama server that answers chat requests with "reply N" and title requests with "Generated Title".
+func newSessionRouter(t *testing.T) (http.Handler, string) {
+	t.Helper()
+
+	calls := 0
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		reply := "Generated Title"
+		if len(req.Messages) == 0 || !strings.Contains(req.Messages[0].Content, "titles for conversations") {
+			calls++
+			reply = fmt.Sprintf("reply %d", calls)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintf(w, `{"model":"llama3.2","message":{"role":"assistant","content":%q},"done":false}`+"\n", reply)
+		fmt.Fprintln(w, `{"model":"llama3.2","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":3,"eval_count":2}`)
+	}))
+	t.Cleanup(ollama.Close)
+
+	providerService := services.NewProviderService()
+	sessionService := services.NewSessionService(storage.NewSessionStoreWithDir(t.TempDir()), providerService, nil)
+	router := api.NewRouterWithServices(api.RouterServices{
+		Provider: providerService,
+		Session:  sessionService,
+	})
+	return router, ollama.URL
+}
+
+func doSessionRequest(t *testing.T, router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeSession(t *testing.T, rec *httptest.ResponseRecorder) types.SessionResponse {
+	t.Helper()
+	var s types.SessionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&s); err != nil {
+		t.Fatalf("Failed to decode session: %v", err)
+	}
+	return s
+}
+
+// waitForSessionTitle polls the session until the background title generation has stored a title.
+func waitForSessionTitle(t *testing.T, router http.Handler, path string) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		session := decodeSession(t, doSessionRequest(t, router, http.MethodGet, path, ""))
+		if session.Title != "" || time.Now().After(deadline) {
+			return session.Title
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestIntegration_Sessions_BranchingFlow(t *testing.T) {
+	router, ollamaURL := newSessionRouter(t)
+
+	rec := doSessionRequest(t, router, http.MethodPost, "/api/v1/sessions", `{"agent_id":"pm","provider":"ollama","model":"llama3.2"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	session := decodeSession(t, rec)
+	base := "/api/v1/sessions/" + session.ID
+
+	rec = doSessionRequest(t, router, http.MethodPost, base+"/messages", fmt.Sprintf(`{"content":"Phrasing A","api_key":%q}`, ollamaURL))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from send, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	session = decodeSession(t, rec)
+	if len(session.ActiveBranch) != 2 || session.ActiveBranch[1].Content != "reply 1" {
+		t.Fatalf("Unexpected branch after send: %+v", session.ActiveBranch)
+	}
+	if title := waitForSessionTitle(t, router, base); title != "Generated Title" {
+		t.Errorf("Expected title generated after first exchange, got %q", title)
+	}
+	original := session.ActiveBranch[0]
+
+	rec = doSessionRequest(t, router, http.MethodPost, base+"/messages/"+original.ID+"/fork", fmt.Sprintf(`{"content":"Phrasing B","api_key":%q}`, ollamaURL))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from fork, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	session = decodeSession(t, rec)
+	if session.ActiveBranch[0].Content != "Phrasing B" {
+		t.Errorf("Expected forked message on active branch, got %+v", session.ActiveBranch)
+	}
+
+	rec = doSessionRequest(t, router, http.MethodGet, base+"/messages/"+original.ID+"/siblings", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from siblings, got %d", rec.Code)
+	}
+	var siblings types.MessageSiblingsResponse
+	json.NewDecoder(rec.Body).Decode(&siblings)
+	if len(siblings.Siblings) != 2 {
+		t.Errorf("Expected 2 sibling phrasings, got %d", len(siblings.Siblings))
+	}
+
+	rec = doSessionRequest(t, router, http.MethodPost, base+"/regenerate", fmt.Sprintf(`{"api_key":%q}`, ollamaURL))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from regenerate, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	session = decodeSession(t, rec)
+	if session.ActiveBranch[1].Content != "reply 3" {
+		t.Errorf("Expected regenerated reply, got %+v", session.ActiveBranch)
+	}
+
+	rec = doSessionRequest(t, router, http.MethodPut, base+"/branch", fmt.Sprintf(`{"message_id":%q}`, original.ID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from branch switch, got %d", rec.Code)
+	}
+	session = decodeSession(t, rec)
+	if len(session.ActiveBranch) != 2 || session.ActiveBranch[0].Content != "Phrasing A" || session.ActiveBranch[1].Content != "reply 1" {
+		t.Errorf("Expected original branch restored, got %+v", session.ActiveBranch)
+	}
+	if len(session.Messages) != 5 {
+		t.Errorf("Expected 5 messages in tree, got %d", len(session.Messages))
+	}
+}
+
+func TestIntegration_Sessions_ListAndDelete(t *testing.T) {
+	router, _ := newSessionRouter(t)
+
+	rec := doSessionRequest(t, router, http.MethodPost, "/api/v1/sessions", `{"agent_id":"architect","title":"Architecture review"}`)
+	session := decodeSession(t, rec)
+
+	rec = doSessionRequest(t, router, http.MethodGet, "/api/v1/sessions", "")
+	var list types.SessionsResponse
+	json.NewDecoder(rec.Body).Decode(&list)
+	if len(list.Sessions) != 1 || list.Sessions[0].Title != "Architecture review" {
+		t.Fatalf("Unexpected session list: %+v", list)
+	}
+
+	rec = doSessionRequest(t, router, http.MethodDelete, "/api/v1/sessions/"+session.ID, "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", rec.Code)
+	}
+
+	rec = doSessionRequest(t, router, http.MethodGet, "/api/v1/sessions/"+session.ID, "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected 404 after delete, got %d", rec.Code)
+	}
+	var errResp response.ErrorResponse
+	json.NewDecoder(rec.Body).Decode(&errResp)
+	if errResp.Error.Code != "session_not_found" {
+		t.Errorf("Expected session_not_found, got %q", errResp.Error.Code)
+	}
+}
+
+func TestIntegration_Sessions_SwitchBranchRequiresMessageID(t *testing.T) {
+	router, _ := newSessionRouter(t)
+
+	rec := doSessionRequest(t, router, http.MethodPost, "/api/v1/sessions", `{"agent_id":"pm"}`)
+	session := decodeSession(t, rec)
+
+	rec = doSessionRequest(t, router, http.MethodPut, "/api/v1/sessions/"+session.ID+"/branch", `{}`)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", rec.Code)
+	}
+}
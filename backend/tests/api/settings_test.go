@@ -180,3 +180,108 @@ func TestIntegration_PutSettings_Persistence(t *testing.T) {
 		t.Errorf("expected custom endpoint, got %q", s.OllamaEndpoint)
 	}
 }
+
+func TestIntegration_PutSettings_AgentGenerationDefaults(t *testing.T) {
+	router := newRouterWithSettings(t)
+
+	body := `{"generation_defaults":{"temperature":0.7},"agent_generation_defaults":{"architect":{"temperature":0.2,"stop_sequences":["END"]}}}`
+	req, _ := http.NewRequest("PUT", "/api/v1/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+
+	// A second update for another agent must not drop the first
+	body = `{"agent_generation_defaults":{"pm":{"top_p":0.9}}}`
+	req, _ = http.NewRequest("PUT", "/api/v1/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var s types.Settings
+	if err := json.NewDecoder(rr.Body).Decode(&s); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+
+	if s.GenerationDefaults == nil || s.GenerationDefaults.Temperature == nil || *s.GenerationDefaults.Temperature != 0.7 {
+		t.Errorf("expected global temperature 0.7, got %+v", s.GenerationDefaults)
+	}
+	architect, ok := s.AgentGenerationDefaults["architect"]
+	if !ok || architect.Temperature == nil || *architect.Temperature != 0.2 {
+		t.Errorf("expected architect temperature 0.2, got %+v", s.AgentGenerationDefaults)
+	}
+	if pm, ok := s.AgentGenerationDefaults["pm"]; !ok || pm.TopP == nil || *pm.TopP != 0.9 {
+		t.Errorf("expected pm top_p 0.9, got %+v", s.AgentGenerationDefaults)
+	}
+}
+
+func TestIntegration_PutSettings_InvalidGenerationPreset(t *testing.T) {
+	router := newRouterWithSettings(t)
+
+	body := `{"agent_generation_defaults":{"architect":{"temperature":3.5}}}`
+	req, _ := http.NewRequest("PUT", "/api/v1/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for out-of-range temperature, got %d", rr.Code)
+	}
+}
+
+func TestIntegration_PutSettings_UtilityModel(t *testing.T) {
+	router := newRouterWithSettings(t)
+
+	body := `{"utility_model":{"provider":"ollama","model":"qwen2.5:0.5b","summarize_after_tokens":12000}}`
+	req, _ := http.NewRequest("PUT", "/api/v1/settings", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	var s types.Settings
+	json.NewDecoder(rr.Body).Decode(&s)
+	if s.UtilityModel == nil || s.UtilityModel.Model != "qwen2.5:0.5b" || s.UtilityModel.SummarizeAfterTokens != 12000 {
+		t.Errorf("expected utility model to be saved, got %+v", s.UtilityModel)
+	}
+
+	req, _ = http.NewRequest("PUT", "/api/v1/settings", strings.NewReader(`{"utility_model":{"provider":"bogus"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid utility provider, got %d", rr.Code)
+	}
+}
+
+func TestIntegration_PutSettings_Embedding(t *testing.T) {
+	router := newRouterWithSettings(t)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/settings", strings.NewReader(`{"embedding":{"provider":"openai","model":"text-embedding-3-large"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d. Body: %s", rr.Code, rr.Body.String())
+	}
+	var s types.Settings
+	json.NewDecoder(rr.Body).Decode(&s)
+	if s.Embedding == nil || s.Embedding.Model != "text-embedding-3-large" {
+		t.Errorf("expected embedding settings to be saved, got %+v", s.Embedding)
+	}
+
+	// Claude has no embeddings API
+	req, _ = http.NewRequest("PUT", "/api/v1/settings", strings.NewReader(`{"embedding":{"provider":"claude"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for embedding provider without embeddings, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,190 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// setupStoryRouter adds a sprint status and two stories to the artifact fixtures.
+func setupStoryRouter(t *testing.T) *chi.Mux {
+	t.Helper()
+	configService, artifactService, tmpDir := setupArtifactTestServices(t)
+
+	implDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	if err := os.MkdirAll(implDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"sprint-status.yaml": "development_status:\n  epic-1: in-progress\n  1-1-setup: done\n  1-2-login: in-progress\n  2-1-export: backlog\n",
+		"1-1-setup.md":       "# Story 1.1: Setup\n\nStatus: done\n\n## Tasks / Subtasks\n\n- [x] Init repo\n",
+		"1-2-login.md":       "# Story 1.2: Login\n\nStatus: ready-for-dev\n\n## Acceptance Criteria\n\n1. Users can sign in\n\n## Tasks / Subtasks\n\n- [x] Form\n- [ ] Session\n  - [ ] Cookie\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(implDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Ext(name) == ".md" {
+			if _, err := artifactService.ProcessSingleArtifact(path); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	statusService := services.NewWorkflowStatusService(configService, nil)
+	if err := statusService.LoadStatus(); err != nil {
+		t.Fatal(err)
+	}
+	storyService := services.NewStoryService(artifactService, statusService)
+	return api.NewRouterWithServices(api.RouterServices{
+		BMadConfig: configService,
+		Artifact:   artifactService,
+		Story:      storyService,
+		Sprint:     services.NewSprintService(configService, artifactService, statusService, storyService, nil),
+	})
+}
+
+func TestStories_ListJoinsSprintStatus(t *testing.T) {
+	router := setupStoryRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/stories", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp types.StoriesResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Stories) != 3 {
+		t.Fatalf("Expected 3 stories, got %+v", resp.Stories)
+	}
+	login := resp.Stories[1]
+	if login.Key != "1-2-login" || login.Status != types.StoryInProgress || login.FileStatus != types.StoryReadyForDev {
+		t.Errorf("Expected login in progress per sprint status, got %+v", login)
+	}
+	if login.TasksTotal != 2 || login.TasksCompleted != 1 || login.SubtasksTotal != 1 {
+		t.Errorf("Unexpected task counts %+v", login)
+	}
+}
+
+func TestStories_FilterByEpic(t *testing.T) {
+	router := setupStoryRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/stories?epic=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var resp types.StoriesResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Stories) != 1 || resp.Stories[0].Key != "2-1-export" {
+		t.Errorf("Expected only 2-1-export, got %+v", resp.Stories)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/stories?epic=two", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a bad epic, got %d", rec.Code)
+	}
+}
+
+func TestStories_GetByKey(t *testing.T) {
+	router := setupStoryRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/stories/1-2-login", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var story types.Story
+	if err := json.NewDecoder(rec.Body).Decode(&story); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if story.Title != "Login" || len(story.AcceptanceCriteria) != 1 {
+		t.Errorf("Unexpected story %+v", story)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/stories/9-9-missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestEpics_ListsEpicsWithStoryCounts(t *testing.T) {
+	router := setupStoryRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/epics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp types.EpicsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Epics) != 2 {
+		t.Fatalf("Expected 2 epics, got %+v", resp.Epics)
+	}
+	if e := resp.Epics[0]; e.Status != types.StoryInProgress || e.StoriesTotal != 2 || e.StoriesDone != 1 {
+		t.Errorf("Unexpected epic 1 %+v", e)
+	}
+	if e := resp.Epics[1]; e.Status != types.StoryBacklog || e.StoriesTotal != 1 {
+		t.Errorf("Unexpected epic 2 %+v", e)
+	}
+}
+
+func TestStories_Move(t *testing.T) {
+	router := setupStoryRouter(t)
+
+	move := func(key, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/bmad/stories/"+key+"/move", strings.NewReader(body))
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := move("1-2-login", `{"status":"review"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var story types.Story
+	if err := json.NewDecoder(rec.Body).Decode(&story); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if story.Status != types.StoryReview || story.FileStatus != types.StoryReview {
+		t.Errorf("Expected the story in review, got %q/%q", story.Status, story.FileStatus)
+	}
+
+	tests := []struct {
+		key    string
+		body   string
+		status int
+	}{
+		{"1-1-setup", `{"status":"in-progress"}`, http.StatusConflict},
+		{"1-2-login", `{"status":"blocked"}`, http.StatusBadRequest},
+		{"1-2-login", `not json`, http.StatusBadRequest},
+		{"9-9-missing", `{"status":"review"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if rec := move(tt.key, tt.body); rec.Code != tt.status {
+			t.Errorf("Moving %s with %s: expected status %d, got %d", tt.key, tt.body, tt.status, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// setupTrackerRouter returns a router syncing a two-story sprint with a FileTracker project "PROJ".
+func setupTrackerRouter(t *testing.T, trackingSystem string) (*chi.Mux, *services.FileTracker) {
+	t.Helper()
+	configService, artifactService, tmpDir := setupArtifactTestServices(t)
+
+	implDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	if err := os.MkdirAll(implDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	board := "project_key: PROJ\ntracking_system: " + trackingSystem + "\ndevelopment_status:\n  epic-1: in-progress\n  1-1-setup: done\n  1-2-login: in-progress\n"
+	if err := os.WriteFile(filepath.Join(implDir, "sprint-status.yaml"), []byte(board), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	statusService := services.NewWorkflowStatusService(configService, nil)
+	if err := statusService.LoadStatus(); err != nil {
+		t.Fatal(err)
+	}
+	storyService := services.NewStoryService(artifactService, statusService)
+	sprintService := services.NewSprintService(configService, artifactService, statusService, storyService, nil)
+	tracker := services.NewFileTrackerWithDir(t.TempDir())
+	trackerSync := services.NewTrackerSyncService(configService, statusService, storyService, sprintService)
+	trackerSync.Register(services.FileTrackerSystem, tracker)
+
+	return api.NewRouterWithServices(api.RouterServices{
+		BMadConfig:  configService,
+		Artifact:    artifactService,
+		Story:       storyService,
+		Sprint:      sprintService,
+		TrackerSync: trackerSync,
+	}), tracker
+}
+
+func TestTracker_SyncAndStatus(t *testing.T) {
+	router, tracker := setupTrackerRouter(t, services.FileTrackerSystem)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/tracker/sync", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var result types.TrackerSyncResponse
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(result.Created) != 3 {
+		t.Errorf("Expected an issue per epic and story, got %+v", result.Created)
+	}
+
+	// Move a story in the tracker; the next sync pulls it
+	if err := tracker.UpdateIssueStatus(context.Background(), "PROJ", "PROJ-3", types.StoryReview); err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/tracker/sync", strings.NewReader(`{"dry_run": false}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	result = types.TrackerSyncResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(result.Pulled) != 1 || result.Pulled[0].Key != "1-2-login" || result.Pulled[0].To != types.StoryReview {
+		t.Errorf("Expected 1-2-login pulled to review, got %+v", result.Pulled)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/tracker", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+	var status types.TrackerStatusResponse
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !status.Supported || status.LastSyncedAt == nil || status.Links["1-2-login"] != (types.TrackerLink{IssueID: "PROJ-3", SyncedStatus: types.StoryReview}) {
+		t.Errorf("Unexpected tracker status %+v", status)
+	}
+}
+
+func TestTracker_SyncErrors(t *testing.T) {
+	router, _ := setupTrackerRouter(t, "file-system")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/tracker/sync", nil))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 without a tracker, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/bmad/tracker/sync", strings.NewReader(`{"resolutions": {"1-1-setup": "mine"}}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid resolution, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/tracker", nil))
+	var status types.TrackerStatusResponse
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if rec.Code != http.StatusOK || status.Supported || status.TrackingSystem != "file-system" {
+		t.Errorf("Expected an unsupported tracking system, got %d %+v", rec.Code, status)
+	}
+}
@@ -0,0 +1,59 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bmad-studio/backend/api"
+	"bmad-studio/backend/services"
+	"bmad-studio/backend/types"
+)
+
+func setupValidationRouter(t *testing.T) http.Handler {
+	t.Helper()
+	configService, artifactService, _ := setupArtifactTestServices(t)
+	return api.NewRouterWithServices(api.RouterServices{
+		BMadConfig: configService,
+		Artifact:   artifactService,
+		Validation: services.NewValidationService(configService, artifactService, nil),
+	})
+}
+
+func TestArtifactDiagnostics_ReportsMissingPRDSections(t *testing.T) {
+	router := setupValidationRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/"+prdArtifactID+"/diagnostics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+
+	var result types.ArtifactDiagnosticsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.ArtifactID != prdArtifactID {
+		t.Errorf("Expected artifact_id %s, got %s", prdArtifactID, result.ArtifactID)
+	}
+	// The fixture PRD is complete but has none of the required sections
+	if result.Errors != 3 || len(result.Diagnostics) != 3 {
+		t.Errorf("Expected 3 missing-section errors, got %+v", result.Diagnostics)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule != "prd-required-sections" {
+			t.Errorf("Unexpected diagnostic %+v", d)
+		}
+	}
+}
+
+func TestArtifactDiagnostics_UnknownArtifact(t *testing.T) {
+	router := setupValidationRouter(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/bmad/artifacts/missing/diagnostics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d. Body: %s", rec.Code, rec.Body.String())
+	}
+}
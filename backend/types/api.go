@@ -22,12 +22,31 @@ type Project struct {
 	Description string `json:"description,omitempty"`
 }
 
-// Session represents a conversation session
+// Session represents a conversation session.
+// Messages form a tree: editing an earlier message or regenerating a reply adds a sibling
+// instead of overwriting, and ActiveLeafID selects which root-to-leaf path is current.
 type Session struct {
 	BaseEntity
-	ProjectID string `json:"project_id"`
-	AgentID   string `json:"agent_id"`
-	Title     string `json:"title,omitempty"`
+	ProjectID    string            `json:"project_id"`
+	AgentID      string            `json:"agent_id"`
+	Title        string            `json:"title,omitempty"`
+	Provider     string            `json:"provider,omitempty"`
+	Model        string            `json:"model,omitempty"`
+	SystemPrompt string            `json:"system_prompt,omitempty"`
+	Generation   *GenerationPreset `json:"generation,omitempty"`
+	Messages     []SessionMessage  `json:"messages,omitempty"`
+	ActiveLeafID string            `json:"active_leaf_id,omitempty"`
+	Summaries    []SessionSummary  `json:"summaries,omitempty"` // Running summaries, one per summarized branch prefix
+}
+
+// GenerationPreset holds stored sampling parameters (temperature, top_p, etc.).
+// Nil fields fall through to the next layer: session, then agent default, then global default.
+type GenerationPreset struct {
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"top_p,omitempty"`
+	TopK          *int     `json:"top_k,omitempty"`
+	StopSequences []string `json:"stop_sequences,omitempty"`
+	Seed          *int64   `json:"seed,omitempty"`
 }
 
 // ProviderSettings holds per-provider configuration (keys are NOT stored here)
@@ -38,10 +57,24 @@ type ProviderSettings struct {
 
 // Settings represents global application settings
 type Settings struct {
-	DefaultProvider string                      `json:"default_provider"`
-	DefaultModel    string                      `json:"default_model"`
-	OllamaEndpoint  string                      `json:"ollama_endpoint"`
-	Providers       map[string]ProviderSettings `json:"providers"`
+	DefaultProvider         string                      `json:"default_provider"`
+	DefaultModel            string                      `json:"default_model"`
+	OllamaEndpoint          string                      `json:"ollama_endpoint"`
+	Providers               map[string]ProviderSettings `json:"providers"`
+	GenerationDefaults      *GenerationPreset           `json:"generation_defaults,omitempty"`
+	AgentGenerationDefaults map[string]GenerationPreset `json:"agent_generation_defaults,omitempty"` // Keyed by agent ID
+	UtilityModel            *UtilityModelSettings       `json:"utility_model,omitempty"`
+	Embedding               *EmbeddingSettings          `json:"embedding,omitempty"`
+}
+
+// UtilityModelSettings selects the cheap model used for background tasks such as
+// session titles and running summaries. Empty fields fall back to a per-provider default.
+type UtilityModelSettings struct {
+	Provider             string `json:"provider,omitempty"`
+	Model                string `json:"model,omitempty"`
+	SummarizeAfterTokens int    `json:"summarize_after_tokens,omitempty"` // Estimated branch size that triggers summarization
+	DisableTitles        bool   `json:"disable_titles,omitempty"`
+	DisableSummaries     bool   `json:"disable_summaries,omitempty"`
 }
 
 // Provider represents a configured LLM provider
@@ -32,6 +32,30 @@ type ArtifactFrontmatter struct {
 	ProjectName    string      `yaml:"project_name"` // For title extraction
 }
 
+// ArtifactClassificationConfig is a project's classification file, _bmad/artifact-types.yaml.
+// Its rules are tried before the built-in filename and workflowType classification.
+type ArtifactClassificationConfig struct {
+	Types []ArtifactTypeDefinition     `yaml:"types"`
+	Rules []ArtifactClassificationRule `yaml:"rules"`
+}
+
+// ArtifactTypeDefinition declares a custom artifact type, or overrides the phase of a built-in one
+type ArtifactTypeDefinition struct {
+	ID        string `yaml:"id"`
+	Phase     int    `yaml:"phase"`      // BMAD phase number 0-4
+	PhaseName string `yaml:"phase_name"` // Defaults to the BMAD name of the phase
+}
+
+// ArtifactClassificationRule assigns Type to artifacts that match any of Globs and all of Frontmatter.
+// Globs are matched against the project-relative path; "**" spans directories and a pattern
+// without "/" matches the file name. Frontmatter values are compared case-insensitively,
+// and a list matches any of its values.
+type ArtifactClassificationRule struct {
+	Type        string                 `yaml:"type"`
+	Globs       []string               `yaml:"globs"`
+	Frontmatter map[string]interface{} `yaml:"frontmatter"`
+}
+
 // Artifact represents an internal artifact record
 type Artifact struct {
 	ID             string
@@ -51,6 +75,7 @@ type Artifact struct {
 	ParentID       *string  // Parent artifact ID for child shards
 	ModifiedAt     int64    // Unix timestamp for cache validation
 	FileSize       int64    // File size in bytes
+	ContentHash    string   // SHA-256 of the file content, for warm-start cache validation
 }
 
 // ArtifactResponse is the API response format for a single artifact
@@ -71,9 +96,67 @@ type ArtifactResponse struct {
 	ParentID       *string  `json:"parent_id"`
 	ModifiedAt     int64    `json:"modified_at"`
 	FileSize       int64    `json:"file_size"`
+
+	Git *ArtifactGitStatus `json:"git,omitempty"` // Set when the project is a git repository
 }
 
 // ArtifactsResponse is the API response wrapper for listing artifacts
 type ArtifactsResponse struct {
 	Artifacts []ArtifactResponse `json:"artifacts"`
 }
+
+// ArtifactRegistryEntry is an artifact as persisted in artifact-registry.json
+type ArtifactRegistryEntry struct {
+	ArtifactResponse
+	ContentHash string `json:"content_hash,omitempty"`
+}
+
+// ArtifactRegistry is the artifact-registry.json file the output folder is indexed into
+type ArtifactRegistry struct {
+	SchemaVersion      int                     `json:"schema_version"`                // 0 for registries written before versioning
+	ClassificationHash string                  `json:"classification_hash,omitempty"` // Fingerprint of the classification rules used
+	Artifacts          []ArtifactRegistryEntry `json:"artifacts"`
+}
+
+// ArtifactFrontmatterResponse is the API response for an artifact's frontmatter as JSON
+type ArtifactFrontmatterResponse struct {
+	ArtifactID  string                 `json:"artifact_id"`
+	Frontmatter map[string]interface{} `json:"frontmatter"`
+}
+
+// ArtifactSection is one heading in an artifact's outline
+type ArtifactSection struct {
+	ID       string  `json:"id"`        // Slug of the heading, unique within the artifact
+	Heading  string  `json:"heading"`   // Heading text without markers
+	Level    int     `json:"level"`     // Heading level 1-6
+	Line     int     `json:"line"`      // 1-based line of the heading in the file
+	ParentID *string `json:"parent_id"` // Enclosing section, nil for top-level headings
+}
+
+// ArtifactOutlineResponse is the API response for an artifact's heading outline
+type ArtifactOutlineResponse struct {
+	ArtifactID string            `json:"artifact_id"`
+	Sections   []ArtifactSection `json:"sections"`
+}
+
+// ArtifactSectionResponse is the API response for a single section of an artifact
+type ArtifactSectionResponse struct {
+	ArtifactSection
+	ArtifactID string `json:"artifact_id"`
+	Content    string `json:"content"` // Markdown from the heading line through its last subsection
+}
+
+// ArtifactFrontmatterUpdate is the request body for PATCH /artifacts/{id}/frontmatter.
+// Omitted fields are left unchanged; an empty completed_at removes the key.
+type ArtifactFrontmatterUpdate struct {
+	Status         *string       `json:"status,omitempty"`
+	StepsCompleted []interface{} `json:"steps_completed,omitempty"` // Strings or step numbers, written as given
+	CompletedAt    *string       `json:"completed_at,omitempty"`
+}
+
+// CreateArtifactRequest is the request body for POST /artifacts
+type CreateArtifactRequest struct {
+	Type     string `json:"type"`               // ArtifactType constant; decides the target folder
+	Filename string `json:"filename,omitempty"` // Defaults to the conventional name for the type
+	Content  string `json:"content"`            // Markdown, with or without frontmatter
+}
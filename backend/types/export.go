@@ -0,0 +1,17 @@
+package types
+
+// ExportRequest is the request body for POST /api/v1/bmad/export. Exactly one of
+// ArtifactIDs and Phase is set.
+type ExportRequest struct {
+	ArtifactIDs []string `json:"artifact_ids"` // Exported in the order given
+	Phase       *int     `json:"phase"`        // Export every artifact of a BMAD phase
+	Title       string   `json:"title"`        // Defaults to the phase name or the artifact name
+	IncludePDF  bool     `json:"include_pdf"`  // Add a PDF next to the markdown and HTML files
+}
+
+// ExportBundle is a zip of the documents of an export, sent as a download
+type ExportBundle struct {
+	Filename    string   // Zip file name, e.g. "planning.zip"
+	Content     []byte   // Zip archive
+	ArtifactIDs []string // Exported artifacts in document order, shards included
+}
@@ -0,0 +1,39 @@
+package types
+
+// GitCommitInfo describes a git commit
+type GitCommitInfo struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Date    Timestamp `json:"date"`
+	Message string    `json:"message"` // Subject line
+}
+
+// ArtifactGitStatus is the git state of an artifact's file
+type ArtifactGitStatus struct {
+	Tracked    bool           `json:"tracked"`     // Committed or staged
+	Dirty      bool           `json:"dirty"`       // Work tree differs from the index
+	Staged     bool           `json:"staged"`      // Index differs from HEAD
+	Untracked  bool           `json:"untracked"`   // Not yet added to git
+	LastCommit *GitCommitInfo `json:"last_commit"` // Most recent commit touching the file
+}
+
+// GitRepoStatus is the API response for the project's repository state
+type GitRepoStatus struct {
+	IsRepo      bool           `json:"is_repo"`
+	Branch      string         `json:"branch,omitempty"`
+	Head        *GitCommitInfo `json:"head,omitempty"`
+	Uncommitted int            `json:"uncommitted"` // Files under the output folder with uncommitted changes
+}
+
+// GitCommitRequest is the request body for committing artifacts
+type GitCommitRequest struct {
+	ArtifactIDs []string `json:"artifact_ids"`
+	Message     string   `json:"message"`
+}
+
+// GitCommitResponse is the API response after committing artifacts
+type GitCommitResponse struct {
+	Commit      GitCommitInfo `json:"commit"`
+	ArtifactIDs []string      `json:"artifact_ids"`
+}
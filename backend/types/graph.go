@@ -0,0 +1,34 @@
+package types
+
+// ArtifactGraphNode is an artifact in the dependency graph
+type ArtifactGraphNode struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Path         string   `json:"path"`
+	Phase        int      `json:"phase"`
+	ModifiedAt   int64    `json:"modified_at"`
+	Stale        bool     `json:"stale"`         // An upstream artifact changed after this one
+	StaleSources []string `json:"stale_sources"` // Upstream artifact IDs modified after this one
+}
+
+// ArtifactGraphEdge links an input document to an artifact that lists it in inputDocuments
+type ArtifactGraphEdge struct {
+	From      string `json:"from"`      // Upstream (input) artifact ID
+	To        string `json:"to"`        // Downstream artifact ID
+	Reference string `json:"reference"` // inputDocuments entry as written
+}
+
+// DanglingReference is an inputDocuments entry that does not resolve to a known artifact
+type DanglingReference struct {
+	ArtifactID string `json:"artifact_id"`
+	Reference  string `json:"reference"`
+	Exists     bool   `json:"exists"` // The file exists but is not in the artifact registry
+}
+
+// ArtifactGraphResponse is the API response for the artifact dependency graph
+type ArtifactGraphResponse struct {
+	Nodes    []ArtifactGraphNode `json:"nodes"`
+	Edges    []ArtifactGraphEdge `json:"edges"`
+	Dangling []DanglingReference `json:"dangling"`
+}
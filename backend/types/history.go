@@ -0,0 +1,72 @@
+package types
+
+// Artifact version sources
+const (
+	VersionSourceLocal = "local" // Snapshot taken by the studio when the file changed
+	VersionSourceGit   = "git"   // Commit that touched the file
+)
+
+// VersionCurrent names the artifact's current content on disk in diff requests
+const VersionCurrent = "current"
+
+// Diff modes
+const (
+	DiffModeUnified = "unified"
+	DiffModeWord    = "word"
+)
+
+// Diff segment operations for word-level diffs
+const (
+	DiffOpEqual  = "equal"
+	DiffOpInsert = "insert"
+	DiffOpDelete = "delete"
+)
+
+// ArtifactVersion is one entry in an artifact's history timeline
+type ArtifactVersion struct {
+	ID          string    `json:"id"`           // "local:<n>" or "git:<commit>"
+	Source      string    `json:"source"`       // VersionSource constant
+	Timestamp   Timestamp `json:"timestamp"`    // Snapshot or commit time
+	ContentHash string    `json:"content_hash"` // SHA-256 of the content; empty for git versions
+	Size        int64     `json:"size"`         // Content size in bytes; 0 for git versions
+	Path        string    `json:"path"`         // Project-relative path of the file at this version
+	Commit      string    `json:"commit,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// ArtifactTimeline is the persisted list of local snapshots of one artifact, oldest first
+type ArtifactTimeline struct {
+	ArtifactID string            `json:"artifact_id"`
+	Versions   []ArtifactVersion `json:"versions"`
+}
+
+// ArtifactHistoryResponse is the API response for an artifact's merged local and git history, newest first
+type ArtifactHistoryResponse struct {
+	ArtifactID string            `json:"artifact_id"`
+	IsGitRepo  bool              `json:"is_git_repo"`
+	Versions   []ArtifactVersion `json:"versions"`
+}
+
+// DiffSegment is a run of words that is unchanged, inserted or deleted
+type DiffSegment struct {
+	Op   string `json:"op"` // DiffOp constant
+	Text string `json:"text"`
+}
+
+// ArtifactDiffResponse is the API response for a diff between two versions of an artifact
+type ArtifactDiffResponse struct {
+	ArtifactID string        `json:"artifact_id"`
+	From       string        `json:"from"`
+	To         string        `json:"to"`
+	Mode       string        `json:"mode"`
+	Additions  int           `json:"additions"` // Lines added
+	Deletions  int           `json:"deletions"` // Lines removed
+	Unified    string        `json:"unified,omitempty"`
+	Words      []DiffSegment `json:"words,omitempty"`
+}
+
+// RestoreVersionRequest is the request body for restoring an artifact to an earlier version
+type RestoreVersionRequest struct {
+	VersionID string `json:"version_id"`
+}
@@ -0,0 +1,64 @@
+package types
+
+// Progress event kinds
+const (
+	ProgressEventWorkflow = "workflow" // A workflow_status entry of bmm-workflow-status.yaml
+	ProgressEventSprint   = "sprint"   // A development_status entry of sprint-status.yaml
+)
+
+// ProgressEvent records one status transition observed in the project's status files
+type ProgressEvent struct {
+	Timestamp Timestamp `json:"timestamp"`
+	Kind      string    `json:"kind"`               // ProgressEvent kind constant
+	Key       string    `json:"key"`                // Workflow ID or development_status key
+	From      string    `json:"from"`               // Previous value; empty when the entry is new
+	To        string    `json:"to"`                 // New value; empty when the entry was removed
+	Baseline  bool      `json:"baseline,omitempty"` // State found when tracking began, not an observed transition
+}
+
+// PhaseDuration reports when a phase started and finished
+type PhaseDuration struct {
+	PhaseNum      int        `json:"phase"`
+	Name          string     `json:"name"`
+	StartedAt     *Timestamp `json:"started_at"`     // First recorded event for a workflow of the phase
+	CompletedAt   *Timestamp `json:"completed_at"`   // When the last required workflow completed; nil while incomplete
+	DurationHours *float64   `json:"duration_hours"` // Until CompletedAt, or until now for a phase in progress
+}
+
+// StoryCycleTime reports how long a story took from in-progress to done
+type StoryCycleTime struct {
+	Key            string     `json:"key"`
+	StartedAt      Timestamp  `json:"started_at"`       // First move to in-progress
+	CompletedAt    *Timestamp `json:"completed_at"`     // Last move to done; nil while the story is open
+	CycleTimeHours *float64   `json:"cycle_time_hours"` // nil while the story is open
+}
+
+// WeeklyThroughput counts completions in the week starting on WeekStart (a Monday, YYYY-MM-DD)
+type WeeklyThroughput struct {
+	WeekStart          string `json:"week_start"`
+	StoriesDone        int    `json:"stories_done"`
+	WorkflowsCompleted int    `json:"workflows_completed"`
+}
+
+// BurndownPoint is the story count at the end of a day (YYYY-MM-DD)
+type BurndownPoint struct {
+	Date      string `json:"date"`
+	Total     int    `json:"total"`
+	Done      int    `json:"done"`
+	Remaining int    `json:"remaining"`
+}
+
+// MetricsResponse is the API response for GET /api/v1/bmad/metrics
+type MetricsResponse struct {
+	TrackingSince         *Timestamp         `json:"tracking_since"` // Timestamp of the first recorded event
+	PhaseDurations        []PhaseDuration    `json:"phase_durations"`
+	StoryCycleTimes       []StoryCycleTime   `json:"story_cycle_times"`
+	AverageCycleTimeHours *float64           `json:"average_cycle_time_hours"`
+	Throughput            []WeeklyThroughput `json:"throughput"`
+	Burndown              []BurndownPoint    `json:"burndown"`
+}
+
+// ProgressTimelineResponse is the API response for GET /api/v1/bmad/metrics/timeline
+type ProgressTimelineResponse struct {
+	Events []ProgressEvent `json:"events"`
+}
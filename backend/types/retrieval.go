@@ -0,0 +1,64 @@
+package types
+
+// VectorIndex is the persisted embedding index for one project
+type VectorIndex struct {
+	Version     int                       `json:"version"`
+	ProjectRoot string                    `json:"project_root"`
+	Provider    string                    `json:"provider"`
+	Model       string                    `json:"model"`
+	Artifacts   map[string]VectorArtifact `json:"artifacts"` // Keyed by artifact ID
+}
+
+// VectorArtifact holds the embedded chunks of one artifact as of ModifiedAt
+type VectorArtifact struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Path       string        `json:"path"`
+	ModifiedAt int64         `json:"modified_at"`
+	Chunks     []VectorChunk `json:"chunks"`
+}
+
+// VectorChunk is a heading-delimited section of an artifact and its embedding
+type VectorChunk struct {
+	ID          string    `json:"id"`
+	HeadingPath []string  `json:"heading_path"` // Enclosing headings, outermost first
+	Text        string    `json:"text"`
+	Vector      []float32 `json:"vector"`
+}
+
+// RetrievalResult is a single chunk returned by semantic retrieval
+type RetrievalResult struct {
+	ArtifactID   string   `json:"artifact_id"`
+	ArtifactName string   `json:"artifact_name"`
+	ArtifactType string   `json:"artifact_type"`
+	Path         string   `json:"path"`
+	ChunkID      string   `json:"chunk_id"`
+	HeadingPath  []string `json:"heading_path"`
+	Text         string   `json:"text"`
+	Score        float64  `json:"score"` // Cosine similarity to the query
+}
+
+// RetrievalResponse is the API response for POST /api/v1/retrieval/query
+type RetrievalResponse struct {
+	Query   string            `json:"query"`
+	Model   string            `json:"model"`
+	Results []RetrievalResult `json:"results"`
+}
+
+// RetrievalIndexStatus describes the embedding index and the outcome of the last sync
+type RetrievalIndexStatus struct {
+	Provider  string `json:"provider"`
+	Model     string `json:"model"`
+	Artifacts int    `json:"artifacts"` // Artifacts in the index
+	Chunks    int    `json:"chunks"`
+	Stale     int    `json:"stale"`              // Artifacts missing from the index or modified since embedding
+	Embedded  int    `json:"embedded,omitempty"` // Artifacts (re-)embedded by this sync
+	Removed   int    `json:"removed,omitempty"`  // Deleted artifacts dropped by this sync
+}
+
+// EmbeddingSettings selects the provider and model used for semantic retrieval.
+// Empty fields default to a local Ollama embedding model.
+type EmbeddingSettings struct {
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
+}
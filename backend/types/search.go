@@ -0,0 +1,42 @@
+package types
+
+// Search result kinds
+const (
+	SearchKindArtifact = "artifact"
+	SearchKindMessage  = "message"
+)
+
+// TextRange marks a highlighted span within a snippet, in character (rune) offsets
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SearchResult is a single full-text search hit
+type SearchResult struct {
+	Kind         string      `json:"kind"` // "artifact" or "message"
+	ID           string      `json:"id"`   // Artifact ID or message ID
+	Title        string      `json:"title"`
+	Score        float64     `json:"score"`
+	Snippet      string      `json:"snippet"`
+	Highlights   []TextRange `json:"highlights"`
+	MatchedField string      `json:"matched_field"` // "title", "frontmatter" or "body"
+
+	// Artifact hits
+	ArtifactType string `json:"artifact_type,omitempty"`
+	Phase        *int   `json:"phase,omitempty"`
+	PhaseName    string `json:"phase_name,omitempty"`
+	Path         string `json:"path,omitempty"`
+
+	// Message hits
+	SessionID string `json:"session_id,omitempty"`
+	AgentID   string `json:"agent_id,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+// SearchResponse is the API response for GET /api/v1/search
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Total   int            `json:"total"` // Matches before limit and offset are applied
+	Results []SearchResult `json:"results"`
+}
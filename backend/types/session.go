@@ -0,0 +1,47 @@
+package types
+
+// SessionMessage is a single node in a session's message tree
+type SessionMessage struct {
+	ID        string      `json:"id"`
+	ParentID  string      `json:"parent_id,omitempty"` // Empty for the first message of a conversation
+	Role      string      `json:"role"`                // "user" or "assistant"
+	Content   string      `json:"content"`
+	Model     string      `json:"model,omitempty"` // Set on assistant messages
+	Usage     *TokenUsage `json:"usage,omitempty"` // Set on assistant messages
+	CreatedAt Timestamp   `json:"created_at"`
+}
+
+// SessionSummary condenses a branch from its first message through ThroughMessageID.
+// When the branch outgrows the context window, the summary replaces those messages.
+type SessionSummary struct {
+	ThroughMessageID string    `json:"through_message_id"`
+	Content          string    `json:"content"`
+	CreatedAt        Timestamp `json:"created_at"`
+}
+
+// TokenUsage records the tokens consumed generating an assistant message
+type TokenUsage struct {
+	InputTokens      int `json:"input_tokens"`
+	OutputTokens     int `json:"output_tokens"`
+	CacheReadTokens  int `json:"cache_read_tokens,omitempty"`
+	CacheWriteTokens int `json:"cache_write_tokens,omitempty"`
+}
+
+// SessionResponse is the API response for a single session, including the messages
+// on the active branch in conversation order
+type SessionResponse struct {
+	Session
+	ActiveBranch []SessionMessage `json:"active_branch"`
+}
+
+// SessionsResponse is the API response for listing sessions (message trees omitted)
+type SessionsResponse struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// MessageSiblingsResponse lists the alternatives sharing a message's parent
+type MessageSiblingsResponse struct {
+	MessageID string           `json:"message_id"`
+	Index     int              `json:"index"` // Position of MessageID within Siblings
+	Siblings  []SessionMessage `json:"siblings"`
+}
@@ -0,0 +1,56 @@
+package types
+
+// StoryTask is a checkbox item in a story's Tasks / Subtasks section
+type StoryTask struct {
+	Text     string      `json:"text"`
+	Done     bool        `json:"done"`
+	Subtasks []StoryTask `json:"subtasks,omitempty"`
+}
+
+// Story is an implementation story parsed from its markdown file and joined with sprint-status.yaml.
+// Stories listed in development_status without a file have only Key, EpicNum, StoryNum, Title and Status.
+type Story struct {
+	Key                string      `json:"key"` // Filename without .md, e.g. "1-2-user-login"; the development_status key
+	EpicNum            int         `json:"epic_num"`
+	StoryNum           int         `json:"story_num"`
+	Title              string      `json:"title"`
+	Status             string      `json:"status"`                // development_status value, else the file's Status line
+	FileStatus         string      `json:"file_status,omitempty"` // Status line of the story file
+	ArtifactID         *string     `json:"artifact_id"`           // nil when the story has no file yet
+	Path               string      `json:"path,omitempty"`
+	Narrative          string      `json:"narrative,omitempty"` // The "As a ..., I want ..." statement
+	AcceptanceCriteria []string    `json:"acceptance_criteria"`
+	Tasks              []StoryTask `json:"tasks"`
+	TasksTotal         int         `json:"tasks_total"`
+	TasksCompleted     int         `json:"tasks_completed"`
+	SubtasksTotal      int         `json:"subtasks_total"`
+	SubtasksCompleted  int         `json:"subtasks_completed"`
+	DevNotes           string      `json:"dev_notes,omitempty"`
+}
+
+// Epic groups the stories of one epic number
+type Epic struct {
+	Key                 string   `json:"key"` // development_status key, e.g. "epic-1"
+	Number              int      `json:"number"`
+	Title               string   `json:"title"`                          // From the "Epic N: Title" heading of the epics document
+	Status              string   `json:"status"`                         // development_status value, else derived from the stories
+	RetrospectiveStatus string   `json:"retrospective_status,omitempty"` // development_status value of "epic-N-retrospective"
+	StoryKeys           []string `json:"story_keys"`
+	StoriesTotal        int      `json:"stories_total"`
+	StoriesDone         int      `json:"stories_done"`
+}
+
+// StoriesResponse is the API response for GET /api/v1/bmad/stories
+type StoriesResponse struct {
+	Stories []Story `json:"stories"`
+}
+
+// EpicsResponse is the API response for GET /api/v1/bmad/epics
+type EpicsResponse struct {
+	Epics []Epic `json:"epics"`
+}
+
+// StoryMoveRequest is the request body for POST /api/v1/bmad/stories/{key}/move
+type StoryMoveRequest struct {
+	Status string `json:"status"` // Target status: backlog, ready-for-dev, in-progress, review or done
+}
@@ -0,0 +1,21 @@
+package types
+
+// ToolDefinition describes a tool agents can call. InputSchema is a JSON Schema
+// object, matching the shape both Anthropic and OpenAI tool definitions expect.
+type ToolDefinition struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// ToolsResponse is the API response for listing tools
+type ToolsResponse struct {
+	Tools []ToolDefinition `json:"tools"`
+}
+
+// ToolResult is the outcome of a tool invocation
+type ToolResult struct {
+	Tool    string      `json:"tool"`
+	Content string      `json:"content"`        // Text returned to the model
+	Data    interface{} `json:"data,omitempty"` // Structured result for the UI
+}
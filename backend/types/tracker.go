@@ -0,0 +1,83 @@
+package types
+
+// Tracker issue kinds
+const (
+	TrackerIssueEpic  = "epic"
+	TrackerIssueStory = "story"
+)
+
+// Tracker conflict resolutions
+const (
+	TrackerResolveLocal  = "local"  // Keep the development_status value and push it
+	TrackerResolveRemote = "remote" // Take the tracker's status
+)
+
+// TrackerIssue is an epic or story as held by an external issue tracker. Status uses the
+// development_status vocabulary; trackers map their own workflow states onto it.
+type TrackerIssue struct {
+	ID        string    `json:"id"`   // Tracker issue ID, e.g. "PROJ-12"
+	Key       string    `json:"key"`  // development_status key, e.g. "1-2-user-login" or "epic-1"
+	Kind      string    `json:"kind"` // Tracker issue kind constant
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	EpicKey   string    `json:"epic_key,omitempty"` // Parent epic of a story
+	UpdatedAt Timestamp `json:"updated_at"`
+}
+
+// TrackerLink records the issue a development_status key is synced with and the status
+// both sides agreed on at the last sync, the base for detecting which side changed.
+type TrackerLink struct {
+	IssueID      string `json:"issue_id"`
+	SyncedStatus string `json:"synced_status"`
+}
+
+// TrackerSyncState is persisted as tracker-sync.json beside sprint-status.yaml
+type TrackerSyncState struct {
+	TrackingSystem string                 `json:"tracking_system"`
+	ProjectKey     string                 `json:"project_key"`
+	LastSyncedAt   *Timestamp             `json:"last_synced_at"`
+	Links          map[string]TrackerLink `json:"links"`
+}
+
+// TrackerStatusResponse is the API response for GET /api/v1/bmad/tracker
+type TrackerStatusResponse struct {
+	TrackingSystem string                 `json:"tracking_system"`
+	ProjectKey     string                 `json:"project_key"`
+	Supported      bool                   `json:"supported"` // A tracker is registered for TrackingSystem
+	LastSyncedAt   *Timestamp             `json:"last_synced_at"`
+	Links          map[string]TrackerLink `json:"links"`
+}
+
+// TrackerSyncRequest is the request body for POST /api/v1/bmad/tracker/sync
+type TrackerSyncRequest struct {
+	DryRun      bool              `json:"dry_run"`     // Report what would change without changing anything
+	Resolutions map[string]string `json:"resolutions"` // Conflict resolutions by key: "local" or "remote"
+}
+
+// TrackerChange is a status copied from one side to the other
+type TrackerChange struct {
+	Key     string `json:"key"`
+	IssueID string `json:"issue_id"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// TrackerConflict is a key whose status changed on both sides since the last sync
+type TrackerConflict struct {
+	Key          string `json:"key"`
+	IssueID      string `json:"issue_id"`
+	SyncedStatus string `json:"synced_status"`
+	LocalStatus  string `json:"local_status"`
+	RemoteStatus string `json:"remote_status"`
+}
+
+// TrackerSyncResponse is the API response for POST /api/v1/bmad/tracker/sync
+type TrackerSyncResponse struct {
+	DryRun    bool              `json:"dry_run"`
+	Created   []TrackerChange   `json:"created"`   // Issues created for unlinked keys
+	Linked    []TrackerChange   `json:"linked"`    // Existing issues linked by key
+	Pushed    []TrackerChange   `json:"pushed"`    // Local changes sent to the tracker
+	Pulled    []TrackerChange   `json:"pulled"`    // Tracker changes written to development_status
+	Conflicts []TrackerConflict `json:"conflicts"` // Left unchanged until resolved
+	Unmapped  []string          `json:"unmapped"`  // Tracker issues whose key development_status does not list
+}
@@ -0,0 +1,26 @@
+package types
+
+// Diagnostic severity constants
+const (
+	DiagnosticSeverityError   = "error"
+	DiagnosticSeverityWarning = "warning"
+	DiagnosticSeverityInfo    = "info"
+)
+
+// ArtifactDiagnostic is one problem a validation rule found in an artifact
+type ArtifactDiagnostic struct {
+	Rule     string `json:"rule"`     // Name of the rule that reported it
+	Severity string `json:"severity"` // error, warning or info
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`  // 1-based line in the file, 0 when not tied to a line
+	Field    string `json:"field,omitempty"` // Frontmatter key the diagnostic is about
+}
+
+// ArtifactDiagnosticsResponse is the API response for an artifact's diagnostics
+type ArtifactDiagnosticsResponse struct {
+	ArtifactID  string               `json:"artifact_id"`
+	Path        string               `json:"path"`
+	Errors      int                  `json:"errors"`
+	Warnings    int                  `json:"warnings"`
+	Diagnostics []ArtifactDiagnostic `json:"diagnostics"`
+}
@@ -7,8 +7,12 @@ const (
 	EventTypeArtifactCreated       = "artifact:created"
 	EventTypeArtifactUpdated       = "artifact:updated"
 	EventTypeArtifactDeleted       = "artifact:deleted"
+	EventTypeArtifactDiagnostics   = "artifact:diagnostics"
 	EventTypeWorkflowStatusChanged = "workflow:status-changed"
+	EventTypeSprintStoryMoved      = "sprint:story-moved"
 	EventTypeConnectionStatus      = "connection:status"
+	EventTypeModelPullProgress     = "model:pull-progress"
+	EventTypeSessionStream         = "session:stream"
 )
 
 // WebSocketEvent represents a WebSocket message sent to clients
@@ -42,11 +46,44 @@ type WorkflowStatusEventPayload struct {
 	WorkflowStatuses map[string]WorkflowCompletionStatus `json:"workflow_statuses"`
 }
 
+// SprintStoryMovedPayload is the payload for sprint:story-moved events
+type SprintStoryMovedPayload struct {
+	Key        string  `json:"key"`
+	EpicNum    int     `json:"epic_num"`
+	From       string  `json:"from"`
+	To         string  `json:"to"`
+	ArtifactID *string `json:"artifact_id"` // nil when the story has no file
+}
+
 // ConnectionStatusPayload is the payload for connection:status events
 type ConnectionStatusPayload struct {
 	Status string `json:"status"` // "connected", "disconnected"
 }
 
+// ModelPullProgressPayload is the payload for model:pull-progress events
+type ModelPullProgressPayload struct {
+	Provider  string `json:"provider"`
+	Model     string `json:"model"`
+	Status    string `json:"status"`
+	Digest    string `json:"digest,omitempty"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+	Percent   int    `json:"percent"`
+	Done      bool   `json:"done"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SessionStreamPayload is the payload for session:stream events.
+// MessageID is the ID the assistant reply will be stored under once streaming completes.
+type SessionStreamPayload struct {
+	SessionID string      `json:"session_id"`
+	MessageID string      `json:"message_id"`
+	Type      string      `json:"type"` // start, chunk, end, error
+	Content   string      `json:"content,omitempty"`
+	Index     int         `json:"index"`
+	Usage     *TokenUsage `json:"usage,omitempty"`
+}
+
 // NewWebSocketEvent creates a new WebSocket event with current timestamp
 func NewWebSocketEvent(eventType string, payload interface{}) *WebSocketEvent {
 	return &WebSocketEvent{
@@ -90,6 +127,11 @@ func NewArtifactDeletedEvent(id, path string) *WebSocketEvent {
 	return NewWebSocketEvent(EventTypeArtifactDeleted, payload)
 }
 
+// NewArtifactDiagnosticsEvent creates an artifact:diagnostics event
+func NewArtifactDiagnosticsEvent(diagnostics *ArtifactDiagnosticsResponse) *WebSocketEvent {
+	return NewWebSocketEvent(EventTypeArtifactDiagnostics, diagnostics)
+}
+
 // NewWorkflowStatusChangedEvent creates a workflow:status-changed event
 func NewWorkflowStatusChangedEvent(statuses map[string]WorkflowCompletionStatus) *WebSocketEvent {
 	payload := &WorkflowStatusEventPayload{
@@ -97,3 +139,18 @@ func NewWorkflowStatusChangedEvent(statuses map[string]WorkflowCompletionStatus)
 	}
 	return NewWebSocketEvent(EventTypeWorkflowStatusChanged, payload)
 }
+
+// NewSprintStoryMovedEvent creates a sprint:story-moved event
+func NewSprintStoryMovedEvent(payload *SprintStoryMovedPayload) *WebSocketEvent {
+	return NewWebSocketEvent(EventTypeSprintStoryMoved, payload)
+}
+
+// NewModelPullProgressEvent creates a model:pull-progress event
+func NewModelPullProgressEvent(payload *ModelPullProgressPayload) *WebSocketEvent {
+	return NewWebSocketEvent(EventTypeModelPullProgress, payload)
+}
+
+// NewSessionStreamEvent creates a session:stream event
+func NewSessionStreamEvent(payload *SessionStreamPayload) *WebSocketEvent {
+	return NewWebSocketEvent(EventTypeSessionStream, payload)
+}
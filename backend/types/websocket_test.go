@@ -15,7 +15,9 @@ func TestWebSocketEventTypes(t *testing.T) {
 		{"artifact created", EventTypeArtifactCreated, "artifact:created"},
 		{"artifact updated", EventTypeArtifactUpdated, "artifact:updated"},
 		{"artifact deleted", EventTypeArtifactDeleted, "artifact:deleted"},
+		{"artifact diagnostics", EventTypeArtifactDiagnostics, "artifact:diagnostics"},
 		{"workflow status changed", EventTypeWorkflowStatusChanged, "workflow:status-changed"},
+		{"sprint story moved", EventTypeSprintStoryMoved, "sprint:story-moved"},
 		{"connection status", EventTypeConnectionStatus, "connection:status"},
 	}
 
@@ -152,6 +154,24 @@ func TestNewArtifactDeletedEvent(t *testing.T) {
 	}
 }
 
+func TestNewArtifactDiagnosticsEvent(t *testing.T) {
+	diagnostics := &ArtifactDiagnosticsResponse{
+		ArtifactID: "test-artifact",
+		Errors:     1,
+		Diagnostics: []ArtifactDiagnostic{
+			{Rule: "frontmatter-schema", Severity: DiagnosticSeverityError, Message: "bad"},
+		},
+	}
+	event := NewArtifactDiagnosticsEvent(diagnostics)
+
+	if event.Type != EventTypeArtifactDiagnostics {
+		t.Errorf("expected type %q, got %q", EventTypeArtifactDiagnostics, event.Type)
+	}
+	if event.Payload != diagnostics {
+		t.Errorf("expected the diagnostics as payload, got %v", event.Payload)
+	}
+}
+
 func TestNewWorkflowStatusChangedEvent(t *testing.T) {
 	statuses := map[string]WorkflowCompletionStatus{
 		"prd": {
@@ -189,6 +209,18 @@ func TestNewWorkflowStatusChangedEvent(t *testing.T) {
 	}
 }
 
+func TestNewSprintStoryMovedEvent(t *testing.T) {
+	payload := &SprintStoryMovedPayload{Key: "1-2-login", EpicNum: 1, From: StoryInProgress, To: StoryReview}
+	event := NewSprintStoryMovedEvent(payload)
+
+	if event.Type != EventTypeSprintStoryMoved {
+		t.Errorf("expected type %q, got %q", EventTypeSprintStoryMoved, event.Type)
+	}
+	if event.Payload != payload {
+		t.Errorf("expected the move as payload, got %v", event.Payload)
+	}
+}
+
 func TestWebSocketEventJSONSerialization(t *testing.T) {
 	artifact := &ArtifactResponse{
 		ID:        "test-artifact",
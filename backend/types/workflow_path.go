@@ -69,3 +69,21 @@ type PhasesResponse struct {
 	Description string          `json:"description"`
 	Phases      []PhaseResponse `json:"phases"`
 }
+
+// TrackSummary describes one loaded path definition for GET /api/v1/bmad/tracks
+type TrackSummary struct {
+	Track         string `json:"track"`
+	MethodName    string `json:"method_name"`
+	FieldType     string `json:"field_type"`
+	Description   string `json:"description"`
+	Selected      bool   `json:"selected"`
+	PhaseCount    int    `json:"phase_count"`
+	WorkflowCount int    `json:"workflow_count"`
+	RequiredCount int    `json:"required_count"` // Required workflows, excluding optional and conditional ones
+}
+
+// TracksResponse is the API response for GET /api/v1/bmad/tracks
+type TracksResponse struct {
+	SelectedTrack string         `json:"selected_track"`
+	Tracks        []TrackSummary `json:"tracks"`
+}
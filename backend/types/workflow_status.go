@@ -1,9 +1,10 @@
 package types
 
 // WorkflowStatus represents the parsed bmm-workflow-status.yaml file
-// Used for reading track selection (kept for backward compatibility)
+// Used for reading track selection; Track is the legacy key older files use
 type WorkflowStatus struct {
-	Track string `json:"track" yaml:"track"`
+	SelectedTrack string `json:"selected_track" yaml:"selected_track"`
+	Track         string `json:"track" yaml:"track"`
 }
 
 // Status value constants for workflow status
@@ -15,6 +16,8 @@ const (
 	StatusConditional = "conditional"
 	StatusNotStarted  = "not_started"
 	StatusComplete    = "complete"
+	// StatusNotApplicable is reported for a conditional workflow whose condition the project does not meet
+	StatusNotApplicable = "not_applicable"
 )
 
 // Story status constants for sprint-status.yaml
@@ -64,6 +67,8 @@ type WorkflowCompletionStatus struct {
 	IsComplete   bool    `json:"is_complete"`
 	IsRequired   bool    `json:"is_required"`
 	IsOptional   bool    `json:"is_optional"`
+	IsApplicable bool    `json:"is_applicable"`           // False when the workflow's condition is not met
+	ConditionMet *bool   `json:"condition_met,omitempty"` // Outcome of the condition; nil if unconditional or undecidable
 }
 
 // StatusResponse is the API response for GET /api/v1/bmad/status
@@ -76,3 +81,31 @@ type StatusResponse struct {
 	WorkflowStatuses  map[string]WorkflowCompletionStatus `json:"workflow_statuses"`
 	StoryStatuses     map[string]string                `json:"story_statuses,omitempty"`
 }
+
+// WorkflowStatusUpdateRequest is the request body for PUT /api/v1/bmad/status/workflows/{id}.
+// Exactly one of Status and ArtifactPath must be set.
+type WorkflowStatusUpdateRequest struct {
+	Status       string `json:"status,omitempty"`        // required, optional, recommended, conditional, skipped or not_started
+	ArtifactPath string `json:"artifact_path,omitempty"` // Project-relative path of the artifact that completes the workflow
+}
+
+// TrackUpdateRequest is the request body for PUT /api/v1/bmad/status/track
+type TrackUpdateRequest struct {
+	Track string `json:"track"`
+}
+
+// TrackComparisonResponse is the API response for GET /api/v1/bmad/tracks/{track}/compare.
+// It previews how the project's current workflow statuses would map onto another track.
+type TrackComparisonResponse struct {
+	FromTrack         string                  `json:"from_track"`
+	ToTrack           string                  `json:"to_track"`
+	CarriedOver       []string                `json:"carried_over"`       // Completed workflows the target track also has
+	NotInTarget       []string                `json:"not_in_target"`      // Completed workflows the target track does not have
+	NewlyRequired     []string                `json:"newly_required"`     // Incomplete workflows the target requires but the current track does not
+	RemainingRequired []string                `json:"remaining_required"` // All incomplete required workflows of the target track
+	CurrentPhase      int                     `json:"current_phase"`      // Current phase on the target track
+	CurrentPhaseName  string                  `json:"current_phase_name"`
+	NextWorkflowID    *string                 `json:"next_workflow_id"`
+	NextWorkflowAgent *string                 `json:"next_workflow_agent"`
+	PhaseCompletion   []PhaseCompletionStatus `json:"phase_completion"`
+}